@@ -733,9 +733,15 @@ func local_request_Greeter_SayHello_9(ctx context.Context, marshaler runtime.Mar
 
 // RegisterGreeterHandlerServer registers the http handlers for service Greeter to "mux".
 // UnaryRPC     :call GreeterServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call GreeterServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterGreeterHandlerFromEndpoint instead.
 func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, server GreeterServer) error {
+	return RegisterGreeterHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterGreeterHandlerServerWithOptions is same as RegisterGreeterHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGreeterHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server GreeterServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("GET", pattern_Greeter_SayHello_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -759,7 +765,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -783,7 +789,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -807,7 +813,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -831,7 +837,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -855,7 +861,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -879,7 +885,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_5(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -903,7 +909,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_6(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_7, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -927,7 +933,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_7(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_8, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -951,7 +957,7 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_8(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_9, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -975,20 +981,29 @@ func RegisterGreeterHandlerServer(ctx context.Context, mux *runtime.ServeMux, se
 
 		forward_Greeter_SayHello_9(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterGreeterHandlerFromEndpoint is same as RegisterGreeterHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterGreeterHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterGreeterHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterGreeterHandlerFromEndpointWithOptions is same as RegisterGreeterHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGreeterHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -996,19 +1011,26 @@ func RegisterGreeterHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeM
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterGreeterHandler(ctx, mux, conn)
+	return RegisterGreeterHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterGreeterHandler registers the http handlers for service Greeter to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterGreeterHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterGreeterHandlerClient(ctx, mux, NewGreeterClient(conn))
+	return RegisterGreeterHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterGreeterHandlerWithOptions is same as RegisterGreeterHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGreeterHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterGreeterHandlerClientWithOptions(ctx, mux, NewGreeterClient(conn), handleOpts...)
 }
 
 // RegisterGreeterHandlerClient registers the http handlers for service Greeter
@@ -1017,6 +1039,12 @@ func RegisterGreeterHandler(ctx context.Context, mux *runtime.ServeMux, conn *gr
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "GreeterClient" to call the correct interceptors.
 func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, client GreeterClient) error {
+	return RegisterGreeterHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterGreeterHandlerClientWithOptions is same as RegisterGreeterHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGreeterHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client GreeterClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("GET", pattern_Greeter_SayHello_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1037,7 +1065,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1058,7 +1086,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1079,7 +1107,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1100,7 +1128,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1121,7 +1149,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1142,7 +1170,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_5(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1163,7 +1191,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_6(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_7, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1184,7 +1212,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_7(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_8, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1205,7 +1233,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_8(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_Greeter_SayHello_9, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1226,7 +1254,7 @@ func RegisterGreeterHandlerClient(ctx context.Context, mux *runtime.ServeMux, cl
 
 		forward_Greeter_SayHello_9(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.helloworld.Greeter/SayHello")}, handleOpts...)...)
 
 	return nil
 }
@@ -1274,3 +1302,183 @@ var (
 
 	forward_Greeter_SayHello_9 = runtime.ForwardResponseMessage
 )
+
+// GreeterSayHelloURL returns the path of the "GET /say/{name}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURLWithMode is same as GreeterSayHelloURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURLWithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["name"], err = runtime.FormatFieldFromPath(protoReq, "name", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL1 returns the path of the "GET /say/strval/{strVal}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL1(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL1WithMode is same as GreeterSayHelloURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL1WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["strVal"], err = runtime.FormatFieldFromPath(protoReq, "strVal", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL2 returns the path of the "GET /say/floatval/{floatVal}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL2(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL2WithMode is same as GreeterSayHelloURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL2WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["floatVal"], err = runtime.FormatFieldFromPath(protoReq, "floatVal", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_2.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL3 returns the path of the "GET /say/doubleval/{doubleVal}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL3(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL3WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL3WithMode is same as GreeterSayHelloURL3 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL3WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["doubleVal"], err = runtime.FormatFieldFromPath(protoReq, "doubleVal", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_3.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL4 returns the path of the "GET /say/boolval/{boolVal}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL4(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL4WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL4WithMode is same as GreeterSayHelloURL4 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL4WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["boolVal"], err = runtime.FormatFieldFromPath(protoReq, "boolVal", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_4.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL5 returns the path of the "GET /say/bytesval/{bytesVal}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL5(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL5WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL5WithMode is same as GreeterSayHelloURL5 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL5WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["bytesVal"], err = runtime.FormatFieldFromPath(protoReq, "bytesVal", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_5.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL6 returns the path of the "GET /say/int32val/{int32Val}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL6(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL6WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL6WithMode is same as GreeterSayHelloURL6 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL6WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["int32Val"], err = runtime.FormatFieldFromPath(protoReq, "int32Val", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_6.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL7 returns the path of the "GET /say/uint32val/{uint32Val}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL7(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL7WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL7WithMode is same as GreeterSayHelloURL7 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL7WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uint32Val"], err = runtime.FormatFieldFromPath(protoReq, "uint32Val", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_7.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL8 returns the path of the "GET /say/int64val/{int64Val}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL8(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL8WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL8WithMode is same as GreeterSayHelloURL8 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL8WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["int64Val"], err = runtime.FormatFieldFromPath(protoReq, "int64Val", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_8.ExpandAndEscape(params, unescapingMode)
+}
+
+// GreeterSayHelloURL9 returns the path of the "GET /say/uint64val/{uint64Val}" binding of Greeter.SayHello,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GreeterSayHelloURL9(protoReq *HelloRequest) (string, error) {
+	return GreeterSayHelloURL9WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GreeterSayHelloURL9WithMode is same as GreeterSayHelloURL9 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GreeterSayHelloURL9WithMode(protoReq *HelloRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uint64Val"], err = runtime.FormatFieldFromPath(protoReq, "uint64Val", ","); err != nil {
+		return "", err
+	}
+	return pattern_Greeter_SayHello_9.ExpandAndEscape(params, unescapingMode)
+}
@@ -0,0 +1,190 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/sub"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// pingPongServer echoes each message of BulkEcho as soon as it receives it.
+type pingPongServer struct {
+	examplepb.UnimplementedStreamServiceServer
+}
+
+func (pingPongServer) BulkEcho(stream examplepb.StreamService_BulkEchoServer) error {
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// newPingPongClient serves pingPongServer over an in-memory connection and returns a client of it.
+func newPingPongClient(t *testing.T) examplepb.StreamServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	examplepb.RegisterStreamServiceServer(s, pingPongServer{})
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Errorf("s.Serve failed with %v; want success", err)
+		}
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.Dial failed with %v; want success", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return examplepb.NewStreamServiceClient(conn)
+}
+
+func TestBidiStreamingFullDuplex(t *testing.T) {
+	ctx := context.Background()
+	for _, spec := range []struct {
+		name     string
+		register func(*testing.T, *runtime.ServeMux) error
+	}{
+		{
+			name: "client",
+			register: func(t *testing.T, mux *runtime.ServeMux) error {
+				return examplepb.RegisterStreamServiceHandlerClient(ctx, mux, newPingPongClient(t))
+			},
+		},
+		{
+			name: "in process",
+			register: func(t *testing.T, mux *runtime.ServeMux) error {
+				return examplepb.RegisterStreamServiceHandlerServer(ctx, mux, pingPongServer{})
+			},
+		},
+	} {
+		for _, opts := range []pingPongOptions{
+			{},
+			{http2: true},
+			{trailer: true},
+			{http2: true, trailer: true},
+		} {
+			opts := opts
+			t.Run(fmt.Sprintf("%s/http2=%t/trailer=%t", spec.name, opts.http2, opts.trailer), func(t *testing.T) {
+				mux := runtime.NewServeMux()
+				if err := spec.register(t, mux); err != nil {
+					t.Fatalf("failed to register the handlers: %v", err)
+				}
+				s := httptest.NewUnstartedServer(mux)
+				if opts.http2 {
+					s.EnableHTTP2 = true
+					s.StartTLS()
+				} else {
+					s.Start()
+				}
+				defer s.Close()
+
+				testPingPong(t, s.Client(), s.URL+"/v1/example/a_bit_of_everything/echo", opts)
+			})
+		}
+	}
+}
+
+type pingPongOptions struct {
+	// http2 is whether the request is sent over HTTP/2.
+	http2 bool
+	// trailer is whether the request declares a trailer, sent after the last message.
+	trailer bool
+}
+
+// testPingPong sends each message after receiving the echo of the previous one.
+func testPingPong(t *testing.T, client *http.Client, url string, opts pingPongOptions) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pr, pw := io.Pipe()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
+	if err != nil {
+		t.Fatalf("http.NewRequest failed with %v; want success", err)
+	}
+	if opts.trailer {
+		req.Trailer = http.Header{"Grpc-Metadata-Foo": nil}
+	}
+	respCh := make(chan *http.Response, 1)
+	go func() {
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("client.Do failed with %v; want success", err)
+			pr.CloseWithError(err)
+			close(respCh)
+			return
+		}
+		respCh <- resp
+	}()
+
+	var dec *json.Decoder
+	for i := 0; i < 3; i++ {
+		want := &sub.StringMessage{Value: proto.String(fmt.Sprintf("ping %d", i))}
+		buf, err := marshaler.Marshal(want)
+		if err != nil {
+			t.Fatalf("marshaler.Marshal(%v) failed with %v; want success", want, err)
+		}
+		if _, err := pw.Write(buf); err != nil {
+			t.Fatalf("pw.Write failed with %v; want success", err)
+		}
+		if dec == nil {
+			resp, ok := <-respCh
+			if !ok {
+				return
+			}
+			defer resp.Body.Close()
+			if got, want := resp.ProtoMajor == 2, opts.http2; got != want {
+				t.Errorf("resp.ProtoMajor = %d; want HTTP/2 %t", resp.ProtoMajor, want)
+			}
+			dec = json.NewDecoder(resp.Body)
+		}
+
+		var item struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if err := dec.Decode(&item); err != nil {
+			t.Fatalf("dec.Decode failed with %v; want success; i = %d", err, i)
+		}
+		got := new(sub.StringMessage)
+		if err := marshaler.Unmarshal(item.Result, got); err != nil {
+			t.Fatalf("marshaler.Unmarshal(%s) failed with %v; want success", item.Result, err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("message %d = %v; want %v", i, got, want)
+		}
+	}
+	if opts.trailer {
+		req.Trailer.Set("Grpc-Metadata-Foo", "bar")
+	}
+	if err := pw.Close(); err != nil {
+		t.Fatalf("pw.Close failed with %v; want success", err)
+	}
+	var item map[string]interface{}
+	if err := dec.Decode(&item); err != io.EOF {
+		t.Errorf("dec.Decode = %v, %v; want io.EOF", item, err)
+	}
+}
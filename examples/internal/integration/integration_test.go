@@ -56,6 +56,115 @@ func TestEcho(t *testing.T) {
 	}
 }
 
+func TestEchoAcceptProblemDetails(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+		return
+	}
+
+	for _, spec := range []struct {
+		name            string
+		method, path    string
+		body            io.Reader
+		wantStatus      int
+		wantContentType string
+	}{
+		{
+			name:            "success",
+			method:          "POST",
+			path:            "/v1/example/echo/myid",
+			body:            strings.NewReader("{}"),
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "error",
+			method:          "GET",
+			path:            "/v1/example/a_bit_of_everything/not_exist",
+			wantStatus:      http.StatusNotFound,
+			wantContentType: runtime.MIMEProblemJSON,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			apiURL := fmt.Sprintf("http://localhost:%d%s", 8088, spec.path)
+			req, err := http.NewRequest(spec.method, apiURL, spec.body)
+			if err != nil {
+				t.Fatalf("http.NewRequest(%q) failed with %v; want success", apiURL, err)
+			}
+			req.Header.Set("Accept", runtime.MIMEProblemJSON)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("http.DefaultClient.Do(%q) failed with %v; want success", apiURL, err)
+			}
+			defer resp.Body.Close()
+			buf, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ioutil.ReadAll(resp.Body) failed with %v; want success", err)
+			}
+			if got, want := resp.StatusCode, spec.wantStatus; got != want {
+				t.Errorf("resp.StatusCode = %d; want %d", got, want)
+				t.Logf("%s", buf)
+			}
+			if got, want := resp.Header.Get("Content-Type"), spec.wantContentType; got != want {
+				t.Errorf(`resp.Header.Get("Content-Type") = %q; want %q`, got, want)
+			}
+		})
+	}
+}
+
+func TestEchoGeneratedURL(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+		return
+	}
+
+	withSlash := &examplepb.SimpleMessage{Id: "my/id", Num: 42}
+	if path, err := examplepb.EchoServiceEchoURL1(withSlash); err == nil {
+		t.Errorf("examplepb.EchoServiceEchoURL1(%v) = %q; want error", withSlash, path)
+	}
+	path, err := examplepb.EchoServiceEchoURL1WithMode(withSlash, runtime.UnescapingModeAllExceptReserved)
+	if err != nil {
+		t.Fatalf("examplepb.EchoServiceEchoURL1WithMode failed with %v; want success", err)
+	}
+	if got, want := path, "/v1/example/echo/my%2Fid/42"; got != want {
+		t.Errorf("examplepb.EchoServiceEchoURL1WithMode = %q; want %q", got, want)
+	}
+
+	path, err = examplepb.EchoServiceEchoURL1(&examplepb.SimpleMessage{Id: "my id", Num: 42})
+	if err != nil {
+		t.Fatalf("examplepb.EchoServiceEchoURL1 failed with %v; want success", err)
+	}
+	if got, want := path, "/v1/example/echo/my%20id/42"; got != want {
+		t.Errorf("examplepb.EchoServiceEchoURL1 = %q; want %q", got, want)
+	}
+
+	apiURL := fmt.Sprintf("http://localhost:%d%s", 8088, path)
+	resp, err := http.Get(apiURL)
+	if err != nil {
+		t.Fatalf("http.Get(%q) failed with %v; want success", apiURL, err)
+	}
+	defer resp.Body.Close()
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ioutil.ReadAll(resp.Body) failed with %v; want success", err)
+	}
+	if got, want := resp.StatusCode, http.StatusOK; got != want {
+		t.Errorf("resp.StatusCode = %d; want %d", got, want)
+		t.Logf("%s", buf)
+	}
+
+	msg := new(examplepb.SimpleMessage)
+	if err := marshaler.Unmarshal(buf, msg); err != nil {
+		t.Fatalf("marshaler.Unmarshal(%s, msg) failed with %v; want success", buf, err)
+	}
+	if got, want := msg.Id, "my id"; got != want {
+		t.Errorf("msg.Id = %q; want %q", got, want)
+	}
+	if got, want := msg.Num, int64(42); got != want {
+		t.Errorf("msg.Num = %d; want %d", got, want)
+	}
+}
+
 func TestEchoUnauthorized(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -216,6 +325,78 @@ func TestForwardResponseOptionHTTPPathPattern(t *testing.T) {
 	testEcho(t, port, "v1", "/v1/example/echo/{id}")
 }
 
+func TestProtoDelimitedStream(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+		return
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	const contentType = "application/x-protobuf-delimited"
+	m := &runtime.ProtoDelimitedMarshaller{}
+	port := 7081
+	go func() {
+		if err := runGateway(
+			ctx,
+			fmt.Sprintf(":%d", port),
+			runtime.WithMarshalerOption(contentType, m),
+		); err != nil {
+			t.Errorf("runGateway() failed with %v; want success", err)
+			return
+		}
+	}()
+	if err := waitForGateway(ctx, uint16(port)); err != nil {
+		t.Errorf("waitForGateway(ctx, %d) failed with %v; want success", port, err)
+	}
+
+	var (
+		body bytes.Buffer
+		want []*sub.StringMessage
+	)
+	enc := m.NewEncoder(&body)
+	for i := 0; i < 3; i++ {
+		s := fmt.Sprintf("message %d", i)
+		msg := &sub.StringMessage{Value: &s}
+		if err := enc.Encode(msg); err != nil {
+			t.Fatalf("enc.Encode(%v) failed with %v; want success", msg, err)
+		}
+		want = append(want, msg)
+	}
+
+	apiURL := fmt.Sprintf("http://localhost:%d/v1/example/a_bit_of_everything/echo", port)
+	resp, err := http.Post(apiURL, contentType, &body)
+	if err != nil {
+		t.Fatalf("http.Post(%q) failed with %v; want success", apiURL, err)
+	}
+	defer resp.Body.Close()
+	if got, want := resp.StatusCode, http.StatusOK; got != want {
+		t.Errorf("resp.StatusCode = %d; want %d", got, want)
+	}
+	if got := resp.Header.Get("Content-Type"); got != contentType {
+		t.Errorf(`resp.Header.Get("Content-Type") = %q; want %q`, got, contentType)
+	}
+
+	var got []*sub.StringMessage
+	dec := m.NewDecoder(resp.Body)
+	for {
+		msg := new(sub.StringMessage)
+		err := dec.Decode(msg)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("dec.Decode(msg) failed with %v; want success", err)
+		}
+		got = append(got, msg)
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
 func testEcho(t *testing.T, port int, apiPrefix string, contentType string) {
 	apiURL := fmt.Sprintf("http://localhost:%d/%s/example/echo/myid", port, apiPrefix)
 	resp, err := http.Post(apiURL, "application/json", strings.NewReader("{}"))
@@ -424,11 +605,13 @@ func TestABE(t *testing.T) {
 	testABECreateBody(t, 8088)
 	testABEBulkCreate(t, 8088, true)
 	testABEBulkCreate(t, 8088, false)
-	testABEBulkCreateWithError(t, 8088)
+	testABEBulkCreateWithError(t, 8088, false)
+	testABEBulkCreateWithError(t, 8088, true)
 	testABELookup(t, 8088)
 	testABELookupNotFound(t, 8088, true)
 	testABELookupNotFound(t, 8088, false)
 	testABEList(t, 8088)
+	testABEListTrailers(t, 8088)
 	testABEDownload(t, 8088)
 	testABEBulkEcho(t, 8088)
 	testABEBulkEchoZeroLength(t, 8088)
@@ -440,6 +623,23 @@ func TestABE(t *testing.T) {
 	testABETrace(t, 8088)
 }
 
+func TestABEStreamingInProcessGateway(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+		return
+	}
+
+	testABEBulkCreate(t, 8089, true)
+	testABEBulkCreate(t, 8089, false)
+	testABEBulkCreateWithError(t, 8089, false)
+	testABEBulkCreateWithError(t, 8089, true)
+	testABEList(t, 8089)
+	testABEListTrailers(t, 8089)
+	testABEDownload(t, 8089)
+	testABEBulkEcho(t, 8089)
+	testABEBulkEchoZeroLength(t, 8089)
+}
+
 func testABECreate(t *testing.T, port int) {
 	want := &examplepb.ABitOfEverything{
 		FloatValue:               1.5,
@@ -745,11 +945,17 @@ func testABEBulkCreate(t *testing.T, port int, useTrailers bool) {
 	}
 }
 
-func testABEBulkCreateWithError(t *testing.T, port int) {
+// testABEBulkCreateWithError sends the error metadata as a request header, or as a request
+// trailer if "useTrailers" is true.
+func testABEBulkCreateWithError(t *testing.T, port int, useTrailers bool) {
 	count := 0
 	r, w := io.Pipe()
+	trailer := http.Header{"Grpc-Metadata-Error": nil}
 	go func(w io.WriteCloser) {
 		defer func() {
+			if useTrailers {
+				trailer.Set("Grpc-Metadata-Error", "some error")
+			}
 			if cerr := w.Close(); cerr != nil {
 				t.Errorf("w.Close() failed with %v; want success", cerr)
 			}
@@ -784,7 +990,11 @@ func testABEBulkCreateWithError(t *testing.T, port int) {
 	if err != nil {
 		t.Fatalf("http.NewRequest(%q, %q, nil) failed with %v; want success", "POST", apiURL, err)
 	}
-	request.Header.Add("Grpc-Metadata-error", "some error")
+	if useTrailers {
+		request.Trailer = trailer
+	} else {
+		request.Header.Add("Grpc-Metadata-error", "some error")
+	}
 
 	resp, err := http.DefaultClient.Do(request)
 	if err != nil {
@@ -1224,11 +1434,50 @@ func testABEList(t *testing.T, port int) {
 	}
 }
 
+func testABEListTrailers(t *testing.T, port int) {
+	apiURL := fmt.Sprintf("http://localhost:%d/v1/example/a_bit_of_everything", port)
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest(%q, %q, nil) failed with %v; want success", "GET", apiURL, err)
+	}
+	req.Header.Set("TE", "trailers")
+	req.Header.Set("Grpc-Metadata-Error", "some error")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("http.Get(%q) failed with %v; want success", apiURL, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	// The trailers are only received after the body.
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ioutil.ReadAll(resp.Body) failed with %v; want success", err)
+	}
+	if !strings.Contains(string(buf), `"error"`) {
+		t.Errorf("resp.Body = %s; want to end with an error", buf)
+	}
+	for trailer, want := range map[string]string{
+		"Grpc-Trailer-Foo": "foo2",
+		"Grpc-Trailer-Bar": "bar2",
+	} {
+		if got := resp.Trailer.Get(trailer); got != want {
+			t.Errorf("resp.Trailer.Get(%q) = %q; want %q", trailer, got, want)
+		}
+	}
+}
+
 func testABEDownload(t *testing.T, port int) {
 	apiURL := fmt.Sprintf("http://localhost:%d/v1/example/download", port)
-	resp, err := http.Get(apiURL)
+	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
-		t.Errorf("http.Get(%q) failed with %v; want success", apiURL, err)
+		t.Fatalf("http.NewRequest(%q) failed with %v; want success", apiURL, err)
+	}
+	// HttpBody responses set their own Content-Type, which no marshaler is registered for.
+	req.Header.Set("Accept", "text/html")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("http.DefaultClient.Do(%q) failed with %v; want success", apiURL, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -1619,7 +1868,7 @@ func TestUnknownPath(t *testing.T) {
 	}
 }
 
-func TestNotImplemented(t *testing.T) {
+func TestMethodNotAllowed(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 		return
@@ -1628,7 +1877,7 @@ func TestNotImplemented(t *testing.T) {
 	apiURL := "http://localhost:8088/v1/example/echo/myid"
 	resp, err := http.Get(apiURL)
 	if err != nil {
-		t.Errorf("http.Post(%q) failed with %v; want success", apiURL, err)
+		t.Errorf("http.Get(%q) failed with %v; want success", apiURL, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -1637,10 +1886,13 @@ func TestNotImplemented(t *testing.T) {
 		t.Errorf("ioutil.ReadAll(resp.Body) failed with %v; want success", err)
 		return
 	}
-	if got, want := resp.StatusCode, http.StatusNotImplemented; got != want {
+	if got, want := resp.StatusCode, http.StatusMethodNotAllowed; got != want {
 		t.Errorf("resp.StatusCode = %d; want %d", got, want)
 		t.Logf("%s", buf)
 	}
+	if got, want := resp.Header.Get("Allow"), "POST"; got != want {
+		t.Errorf(`resp.Header.Get("Allow") = %q; want %q`, got, want)
+	}
 }
 
 func TestInvalidArgument(t *testing.T) {
@@ -2621,9 +2621,15 @@ func local_request_CamelCaseServiceName_Empty_0(ctx context.Context, marshaler r
 
 // RegisterABitOfEverythingServiceHandlerServer registers the http handlers for service ABitOfEverythingService to "mux".
 // UnaryRPC     :call ABitOfEverythingServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call ABitOfEverythingServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterABitOfEverythingServiceHandlerFromEndpoint instead.
 func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ABitOfEverythingServiceServer) error {
+	return RegisterABitOfEverythingServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterABitOfEverythingServiceHandlerServerWithOptions is same as RegisterABitOfEverythingServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterABitOfEverythingServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server ABitOfEverythingServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2647,7 +2653,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Create_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Create")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_CreateBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2671,7 +2677,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CreateBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBody")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_CreateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2695,7 +2701,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CreateBook_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBook")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_ABitOfEverythingService_UpdateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2719,7 +2725,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateBook_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateBook")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Lookup_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2743,7 +2749,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Lookup_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Lookup")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_Custom_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2767,7 +2773,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Custom_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Custom")}, handleOpts...)...)
 
 	mux.Handle("PUT", pattern_ABitOfEverythingService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2791,7 +2797,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Update_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Update")}, handleOpts...)...)
 
 	mux.Handle("PUT", pattern_ABitOfEverythingService_UpdateV2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2815,7 +2821,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateV2_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_ABitOfEverythingService_UpdateV2_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2839,7 +2845,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateV2_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_ABitOfEverythingService_UpdateV2_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2863,7 +2869,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateV2_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2")}, handleOpts...)...)
 
 	mux.Handle("DELETE", pattern_ABitOfEverythingService_Delete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2887,7 +2893,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Delete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Delete")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_GetQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2911,7 +2917,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_GetQuery_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetQuery")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_GetRepeatedQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2935,7 +2941,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_GetRepeatedQuery_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetRepeatedQuery")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2959,7 +2965,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2983,7 +2989,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Echo_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3007,7 +3013,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Echo_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_DeepPathEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3031,7 +3037,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_DeepPathEcho_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DeepPathEcho")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Timeout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3055,7 +3061,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Timeout_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Timeout")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_ErrorWithDetails_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3079,7 +3085,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_ErrorWithDetails_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/ErrorWithDetails")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_GetMessageWithBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3103,7 +3109,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_GetMessageWithBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetMessageWithBody")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_PostWithEmptyBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3127,7 +3133,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_PostWithEmptyBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostWithEmptyBody")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3151,7 +3157,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckGetQueryParams_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckGetQueryParams")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3175,7 +3181,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckNestedEnumGetQueryParams")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_CheckPostQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3199,7 +3205,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckPostQueryParams_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckPostQueryParams")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_OverwriteResponseContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3223,7 +3229,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_OverwriteResponseContentType_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteResponseContentType")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckExternalPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3247,7 +3253,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckExternalPathEnum_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalPathEnum")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3271,7 +3277,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckExternalNestedPathEnum_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalNestedPathEnum")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3295,7 +3301,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckStatus_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckStatus")}, handleOpts...)...)
 
 	mux.Handle("HEAD", pattern_ABitOfEverythingService_Exists_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3319,7 +3325,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Exists_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Exists")}, handleOpts...)...)
 
 	mux.Handle("OPTIONS", pattern_ABitOfEverythingService_CustomOptionsRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3343,7 +3349,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CustomOptionsRequest_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CustomOptionsRequest")}, handleOpts...)...)
 
 	mux.Handle("TRACE", pattern_ABitOfEverythingService_TraceRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3367,16 +3373,22 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_TraceRequest_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/TraceRequest")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterCamelCaseServiceNameHandlerServer registers the http handlers for service CamelCaseServiceName to "mux".
 // UnaryRPC     :call CamelCaseServiceNameServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call CamelCaseServiceNameServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterCamelCaseServiceNameHandlerFromEndpoint instead.
 func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime.ServeMux, server CamelCaseServiceNameServer) error {
+	return RegisterCamelCaseServiceNameHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterCamelCaseServiceNameHandlerServerWithOptions is same as RegisterCamelCaseServiceNameHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterCamelCaseServiceNameHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server CamelCaseServiceNameServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("GET", pattern_CamelCaseServiceName_Empty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3400,20 +3412,29 @@ func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime
 
 		forward_CamelCaseServiceName_Empty_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName/Empty")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterABitOfEverythingServiceHandlerFromEndpoint is same as RegisterABitOfEverythingServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterABitOfEverythingServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterABitOfEverythingServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterABitOfEverythingServiceHandlerFromEndpointWithOptions is same as RegisterABitOfEverythingServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterABitOfEverythingServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -3421,19 +3442,26 @@ func RegisterABitOfEverythingServiceHandlerFromEndpoint(ctx context.Context, mux
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterABitOfEverythingServiceHandler(ctx, mux, conn)
+	return RegisterABitOfEverythingServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterABitOfEverythingServiceHandler registers the http handlers for service ABitOfEverythingService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterABitOfEverythingServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterABitOfEverythingServiceHandlerClient(ctx, mux, NewABitOfEverythingServiceClient(conn))
+	return RegisterABitOfEverythingServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterABitOfEverythingServiceHandlerWithOptions is same as RegisterABitOfEverythingServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterABitOfEverythingServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterABitOfEverythingServiceHandlerClientWithOptions(ctx, mux, NewABitOfEverythingServiceClient(conn), handleOpts...)
 }
 
 // RegisterABitOfEverythingServiceHandlerClient registers the http handlers for service ABitOfEverythingService
@@ -3442,6 +3470,12 @@ func RegisterABitOfEverythingServiceHandler(ctx context.Context, mux *runtime.Se
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ABitOfEverythingServiceClient" to call the correct interceptors.
 func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
+	return RegisterABitOfEverythingServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterABitOfEverythingServiceHandlerClientWithOptions is same as RegisterABitOfEverythingServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterABitOfEverythingServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3462,7 +3496,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Create_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Create")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_CreateBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3483,7 +3517,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CreateBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBody")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_CreateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3504,7 +3538,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CreateBook_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBook")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_ABitOfEverythingService_UpdateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3525,7 +3559,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateBook_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateBook")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Lookup_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3546,7 +3580,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Lookup_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Lookup")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_Custom_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3567,7 +3601,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Custom_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Custom")}, handleOpts...)...)
 
 	mux.Handle("PUT", pattern_ABitOfEverythingService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3588,7 +3622,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Update_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Update")}, handleOpts...)...)
 
 	mux.Handle("PUT", pattern_ABitOfEverythingService_UpdateV2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3609,7 +3643,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateV2_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_ABitOfEverythingService_UpdateV2_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3630,7 +3664,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateV2_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_ABitOfEverythingService_UpdateV2_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3651,7 +3685,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_UpdateV2_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2")}, handleOpts...)...)
 
 	mux.Handle("DELETE", pattern_ABitOfEverythingService_Delete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3672,7 +3706,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Delete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Delete")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_GetQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3693,7 +3727,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_GetQuery_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetQuery")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_GetRepeatedQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3714,7 +3748,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_GetRepeatedQuery_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetRepeatedQuery")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3735,7 +3769,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3756,7 +3790,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Echo_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3777,7 +3811,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Echo_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_DeepPathEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3798,7 +3832,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_DeepPathEcho_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DeepPathEcho")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_Timeout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3819,7 +3853,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Timeout_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Timeout")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_ErrorWithDetails_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3840,7 +3874,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_ErrorWithDetails_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/ErrorWithDetails")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_GetMessageWithBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3861,7 +3895,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_GetMessageWithBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetMessageWithBody")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_PostWithEmptyBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3882,7 +3916,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_PostWithEmptyBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostWithEmptyBody")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3903,7 +3937,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckGetQueryParams_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckGetQueryParams")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3924,7 +3958,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckNestedEnumGetQueryParams")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ABitOfEverythingService_CheckPostQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3945,7 +3979,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckPostQueryParams_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckPostQueryParams")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_OverwriteResponseContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3966,7 +4000,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_OverwriteResponseContentType_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteResponseContentType")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckExternalPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -3987,7 +4021,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckExternalPathEnum_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalPathEnum")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -4008,7 +4042,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckExternalNestedPathEnum_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalNestedPathEnum")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ABitOfEverythingService_CheckStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -4029,7 +4063,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CheckStatus_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckStatus")}, handleOpts...)...)
 
 	mux.Handle("HEAD", pattern_ABitOfEverythingService_Exists_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -4050,7 +4084,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_Exists_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Exists")}, handleOpts...)...)
 
 	mux.Handle("OPTIONS", pattern_ABitOfEverythingService_CustomOptionsRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -4071,7 +4105,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_CustomOptionsRequest_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CustomOptionsRequest")}, handleOpts...)...)
 
 	mux.Handle("TRACE", pattern_ABitOfEverythingService_TraceRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -4092,7 +4126,7 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 
 		forward_ABitOfEverythingService_TraceRequest_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/TraceRequest")}, handleOpts...)...)
 
 	return nil
 }
@@ -4225,15 +4259,646 @@ var (
 	forward_ABitOfEverythingService_TraceRequest_0 = runtime.ForwardResponseMessage
 )
 
+// ABitOfEverythingServiceCreateURL returns the path of the "POST /v1/example/a_bit_of_everything/{float_value}/{double_value}/{int64_value}/separator/{uint64_value}/{int32_value}/{fixed64_value}/{fixed32_value}/{bool_value}/{string_value=strprefix/*}/{uint32_value}/{sfixed32_value}/{sfixed64_value}/{sint32_value}/{sint64_value}/{nonConventionalNameValue}/{enum_value}/{path_enum_value}/{nested_path_enum_value}/{enum_value_annotation}" binding of ABitOfEverythingService.Create,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCreateURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceCreateURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCreateURLWithMode is same as ABitOfEverythingServiceCreateURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCreateURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["float_value"], err = runtime.FormatFieldFromPath(protoReq, "float_value", ","); err != nil {
+		return "", err
+	}
+	if params["double_value"], err = runtime.FormatFieldFromPath(protoReq, "double_value", ","); err != nil {
+		return "", err
+	}
+	if params["int64_value"], err = runtime.FormatFieldFromPath(protoReq, "int64_value", ","); err != nil {
+		return "", err
+	}
+	if params["uint64_value"], err = runtime.FormatFieldFromPath(protoReq, "uint64_value", ","); err != nil {
+		return "", err
+	}
+	if params["int32_value"], err = runtime.FormatFieldFromPath(protoReq, "int32_value", ","); err != nil {
+		return "", err
+	}
+	if params["fixed64_value"], err = runtime.FormatFieldFromPath(protoReq, "fixed64_value", ","); err != nil {
+		return "", err
+	}
+	if params["fixed32_value"], err = runtime.FormatFieldFromPath(protoReq, "fixed32_value", ","); err != nil {
+		return "", err
+	}
+	if params["bool_value"], err = runtime.FormatFieldFromPath(protoReq, "bool_value", ","); err != nil {
+		return "", err
+	}
+	if params["string_value"], err = runtime.FormatFieldFromPath(protoReq, "string_value", ","); err != nil {
+		return "", err
+	}
+	if params["uint32_value"], err = runtime.FormatFieldFromPath(protoReq, "uint32_value", ","); err != nil {
+		return "", err
+	}
+	if params["sfixed32_value"], err = runtime.FormatFieldFromPath(protoReq, "sfixed32_value", ","); err != nil {
+		return "", err
+	}
+	if params["sfixed64_value"], err = runtime.FormatFieldFromPath(protoReq, "sfixed64_value", ","); err != nil {
+		return "", err
+	}
+	if params["sint32_value"], err = runtime.FormatFieldFromPath(protoReq, "sint32_value", ","); err != nil {
+		return "", err
+	}
+	if params["sint64_value"], err = runtime.FormatFieldFromPath(protoReq, "sint64_value", ","); err != nil {
+		return "", err
+	}
+	if params["nonConventionalNameValue"], err = runtime.FormatFieldFromPath(protoReq, "nonConventionalNameValue", ","); err != nil {
+		return "", err
+	}
+	if params["enum_value"], err = runtime.FormatFieldFromPath(protoReq, "enum_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_enum_value"], err = runtime.FormatFieldFromPath(protoReq, "path_enum_value", ","); err != nil {
+		return "", err
+	}
+	if params["nested_path_enum_value"], err = runtime.FormatFieldFromPath(protoReq, "nested_path_enum_value", ","); err != nil {
+		return "", err
+	}
+	if params["enum_value_annotation"], err = runtime.FormatFieldFromPath(protoReq, "enum_value_annotation", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_Create_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCreateBodyURL returns the path of the "POST /v1/example/a_bit_of_everything" binding of ABitOfEverythingService.CreateBody,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCreateBodyURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceCreateBodyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCreateBodyURLWithMode is same as ABitOfEverythingServiceCreateBodyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCreateBodyURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ABitOfEverythingService_CreateBody_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ABitOfEverythingServiceCreateBookURL returns the path of the "POST /v1/{parent=publishers/*}/books" binding of ABitOfEverythingService.CreateBook,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCreateBookURL(protoReq *CreateBookRequest) (string, error) {
+	return ABitOfEverythingServiceCreateBookURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCreateBookURLWithMode is same as ABitOfEverythingServiceCreateBookURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCreateBookURLWithMode(protoReq *CreateBookRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["parent"], err = runtime.FormatFieldFromPath(protoReq, "parent", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_CreateBook_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceUpdateBookURL returns the path of the "PATCH /v1/{book.name=publishers/*/books/*}" binding of ABitOfEverythingService.UpdateBook,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceUpdateBookURL(protoReq *UpdateBookRequest) (string, error) {
+	return ABitOfEverythingServiceUpdateBookURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceUpdateBookURLWithMode is same as ABitOfEverythingServiceUpdateBookURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceUpdateBookURLWithMode(protoReq *UpdateBookRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["book.name"], err = runtime.FormatFieldFromPath(protoReq, "book.name", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_UpdateBook_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceLookupURL returns the path of the "GET /v1/example/a_bit_of_everything/{uuid}" binding of ABitOfEverythingService.Lookup,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceLookupURL(protoReq *sub2.IdMessage) (string, error) {
+	return ABitOfEverythingServiceLookupURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceLookupURLWithMode is same as ABitOfEverythingServiceLookupURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceLookupURLWithMode(protoReq *sub2.IdMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_Lookup_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCustomURL returns the path of the "POST /v1/example/a_bit_of_everything/{uuid}:custom" binding of ABitOfEverythingService.Custom,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCustomURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceCustomURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCustomURLWithMode is same as ABitOfEverythingServiceCustomURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCustomURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_Custom_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceUpdateURL returns the path of the "PUT /v1/example/a_bit_of_everything/{uuid}" binding of ABitOfEverythingService.Update,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceUpdateURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceUpdateURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceUpdateURLWithMode is same as ABitOfEverythingServiceUpdateURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceUpdateURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_Update_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceUpdateV2URL returns the path of the "PUT /v2/example/a_bit_of_everything/{abe.uuid}" binding of ABitOfEverythingService.UpdateV2,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceUpdateV2URL(protoReq *UpdateV2Request) (string, error) {
+	return ABitOfEverythingServiceUpdateV2URLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceUpdateV2URLWithMode is same as ABitOfEverythingServiceUpdateV2URL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceUpdateV2URLWithMode(protoReq *UpdateV2Request, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["abe.uuid"], err = runtime.FormatFieldFromPath(protoReq, "abe.uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_UpdateV2_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceUpdateV2URL1 returns the path of the "PATCH /v2/example/a_bit_of_everything/{abe.uuid}" binding of ABitOfEverythingService.UpdateV2,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceUpdateV2URL1(protoReq *UpdateV2Request) (string, error) {
+	return ABitOfEverythingServiceUpdateV2URL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceUpdateV2URL1WithMode is same as ABitOfEverythingServiceUpdateV2URL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceUpdateV2URL1WithMode(protoReq *UpdateV2Request, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["abe.uuid"], err = runtime.FormatFieldFromPath(protoReq, "abe.uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_UpdateV2_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceUpdateV2URL2 returns the path of the "PATCH /v2a/example/a_bit_of_everything/{abe.uuid}" binding of ABitOfEverythingService.UpdateV2,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceUpdateV2URL2(protoReq *UpdateV2Request) (string, error) {
+	return ABitOfEverythingServiceUpdateV2URL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceUpdateV2URL2WithMode is same as ABitOfEverythingServiceUpdateV2URL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceUpdateV2URL2WithMode(protoReq *UpdateV2Request, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["abe.uuid"], err = runtime.FormatFieldFromPath(protoReq, "abe.uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_UpdateV2_2.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceDeleteURL returns the path of the "DELETE /v1/example/a_bit_of_everything/{uuid}" binding of ABitOfEverythingService.Delete,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceDeleteURL(protoReq *sub2.IdMessage) (string, error) {
+	return ABitOfEverythingServiceDeleteURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceDeleteURLWithMode is same as ABitOfEverythingServiceDeleteURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceDeleteURLWithMode(protoReq *sub2.IdMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_Delete_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceGetQueryURL returns the path of the "GET /v1/example/a_bit_of_everything/query/{uuid}" binding of ABitOfEverythingService.GetQuery,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceGetQueryURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceGetQueryURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceGetQueryURLWithMode is same as ABitOfEverythingServiceGetQueryURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceGetQueryURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_GetQuery_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceGetRepeatedQueryURL returns the path of the "GET /v1/example/a_bit_of_everything_repeated/{path_repeated_float_value}/{path_repeated_double_value}/{path_repeated_int64_value}/{path_repeated_uint64_value}/{path_repeated_int32_value}/{path_repeated_fixed64_value}/{path_repeated_fixed32_value}/{path_repeated_bool_value}/{path_repeated_string_value}/{path_repeated_bytes_value}/{path_repeated_uint32_value}/{path_repeated_enum_value}/{path_repeated_sfixed32_value}/{path_repeated_sfixed64_value}/{path_repeated_sint32_value}/{path_repeated_sint64_value}" binding of ABitOfEverythingService.GetRepeatedQuery,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceGetRepeatedQueryURL(protoReq *ABitOfEverythingRepeated) (string, error) {
+	return ABitOfEverythingServiceGetRepeatedQueryURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceGetRepeatedQueryURLWithMode is same as ABitOfEverythingServiceGetRepeatedQueryURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceGetRepeatedQueryURLWithMode(protoReq *ABitOfEverythingRepeated, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["path_repeated_float_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_float_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_double_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_double_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_int64_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_int64_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_uint64_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_uint64_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_int32_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_int32_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_fixed64_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_fixed64_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_fixed32_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_fixed32_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_bool_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_bool_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_string_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_string_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_bytes_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_bytes_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_uint32_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_uint32_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_enum_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_enum_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_sfixed32_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_sfixed32_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_sfixed64_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_sfixed64_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_sint32_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_sint32_value", ","); err != nil {
+		return "", err
+	}
+	if params["path_repeated_sint64_value"], err = runtime.FormatFieldFromPath(protoReq, "path_repeated_sint64_value", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_GetRepeatedQuery_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceEchoURL returns the path of the "GET /v1/example/a_bit_of_everything/echo/{value}" binding of ABitOfEverythingService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceEchoURL(protoReq *sub.StringMessage) (string, error) {
+	return ABitOfEverythingServiceEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceEchoURLWithMode is same as ABitOfEverythingServiceEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceEchoURLWithMode(protoReq *sub.StringMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["value"], err = runtime.FormatFieldFromPath(protoReq, "value", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_Echo_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceEchoURL1 returns the path of the "POST /v2/example/echo" binding of ABitOfEverythingService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceEchoURL1(protoReq *sub.StringMessage) (string, error) {
+	return ABitOfEverythingServiceEchoURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceEchoURL1WithMode is same as ABitOfEverythingServiceEchoURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceEchoURL1WithMode(protoReq *sub.StringMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ABitOfEverythingService_Echo_1.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ABitOfEverythingServiceEchoURL2 returns the path of the "GET /v2/example/echo" binding of ABitOfEverythingService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceEchoURL2(protoReq *sub.StringMessage) (string, error) {
+	return ABitOfEverythingServiceEchoURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceEchoURL2WithMode is same as ABitOfEverythingServiceEchoURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceEchoURL2WithMode(protoReq *sub.StringMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ABitOfEverythingService_Echo_2.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ABitOfEverythingServiceDeepPathEchoURL returns the path of the "POST /v1/example/deep_path/{single_nested.name}" binding of ABitOfEverythingService.DeepPathEcho,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceDeepPathEchoURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceDeepPathEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceDeepPathEchoURLWithMode is same as ABitOfEverythingServiceDeepPathEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceDeepPathEchoURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["single_nested.name"], err = runtime.FormatFieldFromPath(protoReq, "single_nested.name", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_DeepPathEcho_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceTimeoutURL returns the path of the "GET /v2/example/timeout" binding of ABitOfEverythingService.Timeout,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceTimeoutURL(protoReq *emptypb.Empty) (string, error) {
+	return ABitOfEverythingServiceTimeoutURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceTimeoutURLWithMode is same as ABitOfEverythingServiceTimeoutURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceTimeoutURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ABitOfEverythingService_Timeout_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ABitOfEverythingServiceErrorWithDetailsURL returns the path of the "GET /v2/example/errorwithdetails" binding of ABitOfEverythingService.ErrorWithDetails,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceErrorWithDetailsURL(protoReq *emptypb.Empty) (string, error) {
+	return ABitOfEverythingServiceErrorWithDetailsURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceErrorWithDetailsURLWithMode is same as ABitOfEverythingServiceErrorWithDetailsURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceErrorWithDetailsURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ABitOfEverythingService_ErrorWithDetails_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ABitOfEverythingServiceGetMessageWithBodyURL returns the path of the "POST /v2/example/withbody/{id}" binding of ABitOfEverythingService.GetMessageWithBody,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceGetMessageWithBodyURL(protoReq *MessageWithBody) (string, error) {
+	return ABitOfEverythingServiceGetMessageWithBodyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceGetMessageWithBodyURLWithMode is same as ABitOfEverythingServiceGetMessageWithBodyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceGetMessageWithBodyURLWithMode(protoReq *MessageWithBody, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_GetMessageWithBody_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServicePostWithEmptyBodyURL returns the path of the "POST /v2/example/postwithemptybody/{name}" binding of ABitOfEverythingService.PostWithEmptyBody,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServicePostWithEmptyBodyURL(protoReq *Body) (string, error) {
+	return ABitOfEverythingServicePostWithEmptyBodyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServicePostWithEmptyBodyURLWithMode is same as ABitOfEverythingServicePostWithEmptyBodyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServicePostWithEmptyBodyURLWithMode(protoReq *Body, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["name"], err = runtime.FormatFieldFromPath(protoReq, "name", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_PostWithEmptyBody_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCheckGetQueryParamsURL returns the path of the "GET /v1/example/a_bit_of_everything/params/get/{single_nested.name}" binding of ABitOfEverythingService.CheckGetQueryParams,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCheckGetQueryParamsURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceCheckGetQueryParamsURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCheckGetQueryParamsURLWithMode is same as ABitOfEverythingServiceCheckGetQueryParamsURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCheckGetQueryParamsURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["single_nested.name"], err = runtime.FormatFieldFromPath(protoReq, "single_nested.name", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_CheckGetQueryParams_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCheckNestedEnumGetQueryParamsURL returns the path of the "GET /v1/example/a_bit_of_everything/params/get/nested_enum/{single_nested.ok}" binding of ABitOfEverythingService.CheckNestedEnumGetQueryParams,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCheckNestedEnumGetQueryParamsURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceCheckNestedEnumGetQueryParamsURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCheckNestedEnumGetQueryParamsURLWithMode is same as ABitOfEverythingServiceCheckNestedEnumGetQueryParamsURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCheckNestedEnumGetQueryParamsURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["single_nested.ok"], err = runtime.FormatFieldFromPath(protoReq, "single_nested.ok", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCheckPostQueryParamsURL returns the path of the "POST /v1/example/a_bit_of_everything/params/post/{string_value}" binding of ABitOfEverythingService.CheckPostQueryParams,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCheckPostQueryParamsURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceCheckPostQueryParamsURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCheckPostQueryParamsURLWithMode is same as ABitOfEverythingServiceCheckPostQueryParamsURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCheckPostQueryParamsURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["string_value"], err = runtime.FormatFieldFromPath(protoReq, "string_value", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_CheckPostQueryParams_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceOverwriteResponseContentTypeURL returns the path of the "GET /v2/example/overwriteresponsecontenttype" binding of ABitOfEverythingService.OverwriteResponseContentType,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceOverwriteResponseContentTypeURL(protoReq *emptypb.Empty) (string, error) {
+	return ABitOfEverythingServiceOverwriteResponseContentTypeURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceOverwriteResponseContentTypeURLWithMode is same as ABitOfEverythingServiceOverwriteResponseContentTypeURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceOverwriteResponseContentTypeURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ABitOfEverythingService_OverwriteResponseContentType_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ABitOfEverythingServiceCheckExternalPathEnumURL returns the path of the "GET /v2/{value}:check" binding of ABitOfEverythingService.CheckExternalPathEnum,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCheckExternalPathEnumURL(protoReq *pathenum.MessageWithPathEnum) (string, error) {
+	return ABitOfEverythingServiceCheckExternalPathEnumURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCheckExternalPathEnumURLWithMode is same as ABitOfEverythingServiceCheckExternalPathEnumURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCheckExternalPathEnumURLWithMode(protoReq *pathenum.MessageWithPathEnum, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["value"], err = runtime.FormatFieldFromPath(protoReq, "value", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_CheckExternalPathEnum_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCheckExternalNestedPathEnumURL returns the path of the "GET /v3/{value}:check" binding of ABitOfEverythingService.CheckExternalNestedPathEnum,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCheckExternalNestedPathEnumURL(protoReq *pathenum.MessageWithNestedPathEnum) (string, error) {
+	return ABitOfEverythingServiceCheckExternalNestedPathEnumURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCheckExternalNestedPathEnumURLWithMode is same as ABitOfEverythingServiceCheckExternalNestedPathEnumURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCheckExternalNestedPathEnumURLWithMode(protoReq *pathenum.MessageWithNestedPathEnum, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["value"], err = runtime.FormatFieldFromPath(protoReq, "value", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCheckStatusURL returns the path of the "GET /v1/example/checkStatus" binding of ABitOfEverythingService.CheckStatus,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCheckStatusURL(protoReq *emptypb.Empty) (string, error) {
+	return ABitOfEverythingServiceCheckStatusURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCheckStatusURLWithMode is same as ABitOfEverythingServiceCheckStatusURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCheckStatusURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ABitOfEverythingService_CheckStatus_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ABitOfEverythingServiceExistsURL returns the path of the "HEAD /v1/example/a_bit_of_everything/{uuid}" binding of ABitOfEverythingService.Exists,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceExistsURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceExistsURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceExistsURLWithMode is same as ABitOfEverythingServiceExistsURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceExistsURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_Exists_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceCustomOptionsRequestURL returns the path of the "OPTIONS /v1/example/a_bit_of_everything/{uuid}" binding of ABitOfEverythingService.CustomOptionsRequest,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceCustomOptionsRequestURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceCustomOptionsRequestURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceCustomOptionsRequestURLWithMode is same as ABitOfEverythingServiceCustomOptionsRequestURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceCustomOptionsRequestURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_CustomOptionsRequest_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ABitOfEverythingServiceTraceRequestURL returns the path of the "TRACE /v1/example/a_bit_of_everything/{uuid}" binding of ABitOfEverythingService.TraceRequest,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ABitOfEverythingServiceTraceRequestURL(protoReq *ABitOfEverything) (string, error) {
+	return ABitOfEverythingServiceTraceRequestURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ABitOfEverythingServiceTraceRequestURLWithMode is same as ABitOfEverythingServiceTraceRequestURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ABitOfEverythingServiceTraceRequestURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["uuid"], err = runtime.FormatFieldFromPath(protoReq, "uuid", ","); err != nil {
+		return "", err
+	}
+	return pattern_ABitOfEverythingService_TraceRequest_0.ExpandAndEscape(params, unescapingMode)
+}
+
 // RegisterCamelCaseServiceNameHandlerFromEndpoint is same as RegisterCamelCaseServiceNameHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterCamelCaseServiceNameHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterCamelCaseServiceNameHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterCamelCaseServiceNameHandlerFromEndpointWithOptions is same as RegisterCamelCaseServiceNameHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterCamelCaseServiceNameHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -4241,19 +4906,26 @@ func RegisterCamelCaseServiceNameHandlerFromEndpoint(ctx context.Context, mux *r
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterCamelCaseServiceNameHandler(ctx, mux, conn)
+	return RegisterCamelCaseServiceNameHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterCamelCaseServiceNameHandler registers the http handlers for service CamelCaseServiceName to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterCamelCaseServiceNameHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterCamelCaseServiceNameHandlerClient(ctx, mux, NewCamelCaseServiceNameClient(conn))
+	return RegisterCamelCaseServiceNameHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterCamelCaseServiceNameHandlerWithOptions is same as RegisterCamelCaseServiceNameHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterCamelCaseServiceNameHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterCamelCaseServiceNameHandlerClientWithOptions(ctx, mux, NewCamelCaseServiceNameClient(conn), handleOpts...)
 }
 
 // RegisterCamelCaseServiceNameHandlerClient registers the http handlers for service CamelCaseServiceName
@@ -4262,6 +4934,12 @@ func RegisterCamelCaseServiceNameHandler(ctx context.Context, mux *runtime.Serve
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "CamelCaseServiceNameClient" to call the correct interceptors.
 func RegisterCamelCaseServiceNameHandlerClient(ctx context.Context, mux *runtime.ServeMux, client CamelCaseServiceNameClient) error {
+	return RegisterCamelCaseServiceNameHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterCamelCaseServiceNameHandlerClientWithOptions is same as RegisterCamelCaseServiceNameHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterCamelCaseServiceNameHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client CamelCaseServiceNameClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("GET", pattern_CamelCaseServiceName_Empty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -4282,7 +4960,7 @@ func RegisterCamelCaseServiceNameHandlerClient(ctx context.Context, mux *runtime
 
 		forward_CamelCaseServiceName_Empty_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName/Empty")}, handleOpts...)...)
 
 	return nil
 }
@@ -4294,3 +4972,16 @@ var (
 var (
 	forward_CamelCaseServiceName_Empty_0 = runtime.ForwardResponseMessage
 )
+
+// CamelCaseServiceNameEmptyURL returns the path of the "GET /v2/example/empty" binding of CamelCaseServiceName.Empty,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func CamelCaseServiceNameEmptyURL(protoReq *emptypb.Empty) (string, error) {
+	return CamelCaseServiceNameEmptyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// CamelCaseServiceNameEmptyURLWithMode is same as CamelCaseServiceNameEmptyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func CamelCaseServiceNameEmptyURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_CamelCaseServiceName_Empty_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -675,9 +675,15 @@ func local_request_EchoService_EchoUnauthorized_0(ctx context.Context, marshaler
 
 // RegisterEchoServiceHandlerServer registers the http handlers for service EchoService to "mux".
 // UnaryRPC     :call EchoServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call EchoServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterEchoServiceHandlerFromEndpoint instead.
 func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server EchoServiceServer) error {
+	return RegisterEchoServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterEchoServiceHandlerServerWithOptions is same as RegisterEchoServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterEchoServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server EchoServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_EchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -701,7 +707,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -725,7 +731,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -749,7 +755,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -773,7 +779,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -797,7 +803,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_EchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -821,7 +827,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoBody")}, handleOpts...)...)
 
 	mux.Handle("DELETE", pattern_EchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -845,7 +851,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoDelete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoDelete")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_EchoService_EchoPatch_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -869,7 +875,7 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoPatch_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoPatch")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_EchoUnauthorized_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -893,20 +899,29 @@ func RegisterEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoUnauthorized_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoUnauthorized")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterEchoServiceHandlerFromEndpoint is same as RegisterEchoServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterEchoServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterEchoServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterEchoServiceHandlerFromEndpointWithOptions is same as RegisterEchoServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterEchoServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -914,19 +929,26 @@ func RegisterEchoServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.Se
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterEchoServiceHandler(ctx, mux, conn)
+	return RegisterEchoServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterEchoServiceHandler registers the http handlers for service EchoService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterEchoServiceHandlerClient(ctx, mux, NewEchoServiceClient(conn))
+	return RegisterEchoServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterEchoServiceHandlerWithOptions is same as RegisterEchoServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterEchoServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterEchoServiceHandlerClientWithOptions(ctx, mux, NewEchoServiceClient(conn), handleOpts...)
 }
 
 // RegisterEchoServiceHandlerClient registers the http handlers for service EchoService
@@ -935,6 +957,12 @@ func RegisterEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "EchoServiceClient" to call the correct interceptors.
 func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient) error {
+	return RegisterEchoServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterEchoServiceHandlerClientWithOptions is same as RegisterEchoServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterEchoServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client EchoServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_EchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -955,7 +983,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -976,7 +1004,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -997,7 +1025,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1018,7 +1046,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_Echo_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1039,7 +1067,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_Echo_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_EchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1060,7 +1088,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoBody")}, handleOpts...)...)
 
 	mux.Handle("DELETE", pattern_EchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1081,7 +1109,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoDelete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoDelete")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_EchoService_EchoPatch_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1102,7 +1130,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoPatch_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoPatch")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_EchoService_EchoUnauthorized_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1123,7 +1151,7 @@ func RegisterEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux
 
 		forward_EchoService_EchoUnauthorized_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.EchoService/EchoUnauthorized")}, handleOpts...)...)
 
 	return nil
 }
@@ -1167,3 +1195,160 @@ var (
 
 	forward_EchoService_EchoUnauthorized_0 = runtime.ForwardResponseMessage
 )
+
+// EchoServiceEchoURL returns the path of the "POST /v1/example/echo/{id}" binding of EchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoURL(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoURLWithMode is same as EchoServiceEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoURLWithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	return pattern_EchoService_Echo_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// EchoServiceEchoURL1 returns the path of the "GET /v1/example/echo/{id}/{num}" binding of EchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoURL1(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoURL1WithMode is same as EchoServiceEchoURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoURL1WithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	if params["num"], err = runtime.FormatFieldFromPath(protoReq, "num", ","); err != nil {
+		return "", err
+	}
+	return pattern_EchoService_Echo_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// EchoServiceEchoURL2 returns the path of the "GET /v1/example/echo/{id}/{num}/{lang}" binding of EchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoURL2(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoURL2WithMode is same as EchoServiceEchoURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoURL2WithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	if params["num"], err = runtime.FormatFieldFromPath(protoReq, "num", ","); err != nil {
+		return "", err
+	}
+	if params["lang"], err = runtime.FormatFieldFromPath(protoReq, "lang", ","); err != nil {
+		return "", err
+	}
+	return pattern_EchoService_Echo_2.ExpandAndEscape(params, unescapingMode)
+}
+
+// EchoServiceEchoURL3 returns the path of the "GET /v1/example/echo1/{id}/{line_num}/{status.note}" binding of EchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoURL3(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoURL3WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoURL3WithMode is same as EchoServiceEchoURL3 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoURL3WithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	if params["line_num"], err = runtime.FormatFieldFromPath(protoReq, "line_num", ","); err != nil {
+		return "", err
+	}
+	if params["status.note"], err = runtime.FormatFieldFromPath(protoReq, "status.note", ","); err != nil {
+		return "", err
+	}
+	return pattern_EchoService_Echo_3.ExpandAndEscape(params, unescapingMode)
+}
+
+// EchoServiceEchoURL4 returns the path of the "GET /v1/example/echo2/{no.note}" binding of EchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoURL4(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoURL4WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoURL4WithMode is same as EchoServiceEchoURL4 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoURL4WithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["no.note"], err = runtime.FormatFieldFromPath(protoReq, "no.note", ","); err != nil {
+		return "", err
+	}
+	return pattern_EchoService_Echo_4.ExpandAndEscape(params, unescapingMode)
+}
+
+// EchoServiceEchoBodyURL returns the path of the "POST /v1/example/echo_body" binding of EchoService.EchoBody,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoBodyURL(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoBodyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoBodyURLWithMode is same as EchoServiceEchoBodyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoBodyURLWithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_EchoService_EchoBody_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// EchoServiceEchoDeleteURL returns the path of the "DELETE /v1/example/echo_delete" binding of EchoService.EchoDelete,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoDeleteURL(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoDeleteURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoDeleteURLWithMode is same as EchoServiceEchoDeleteURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoDeleteURLWithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_EchoService_EchoDelete_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// EchoServiceEchoPatchURL returns the path of the "PATCH /v1/example/echo_patch" binding of EchoService.EchoPatch,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoPatchURL(protoReq *DynamicMessageUpdate) (string, error) {
+	return EchoServiceEchoPatchURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoPatchURLWithMode is same as EchoServiceEchoPatchURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoPatchURLWithMode(protoReq *DynamicMessageUpdate, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_EchoService_EchoPatch_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// EchoServiceEchoUnauthorizedURL returns the path of the "GET /v1/example/echo_unauthorized" binding of EchoService.EchoUnauthorized,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func EchoServiceEchoUnauthorizedURL(protoReq *SimpleMessage) (string, error) {
+	return EchoServiceEchoUnauthorizedURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// EchoServiceEchoUnauthorizedURLWithMode is same as EchoServiceEchoUnauthorizedURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func EchoServiceEchoUnauthorizedURLWithMode(protoReq *SimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_EchoService_EchoUnauthorized_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -66,6 +66,19 @@ func request_FlowCombination_RpcEmptyStream_0(ctx context.Context, marshaler run
 
 }
 
+func local_request_FlowCombination_RpcEmptyStream_0(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq EmptyProto
+	var metadata runtime.ServerMetadata
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcEmptyStream(&protoReq, &local_FlowCombination_RpcEmptyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 func request_FlowCombination_StreamEmptyRpc_0(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var metadata runtime.ServerMetadata
 	stream, err := client.StreamEmptyRpc(ctx)
@@ -110,14 +123,33 @@ func request_FlowCombination_StreamEmptyRpc_0(ctx context.Context, marshaler run
 
 }
 
+func local_request_FlowCombination_StreamEmptyRpc_0(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	stream := runtime.NewInProcessStream(ctx, marshaler.NewDecoder(req.Body))
+	stream.Start(func() error {
+		return server.StreamEmptyRpc(&local_FlowCombination_StreamEmptyRpcServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+
+	defer stream.Close()
+	msg, err := stream.Response()
+	metadata.TrailerMD = stream.Trailer()
+	return msg, metadata, err
+
+}
+
 func request_FlowCombination_StreamEmptyStream_0(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (FlowCombination_StreamEmptyStreamClient, runtime.ServerMetadata, error) {
 	var metadata runtime.ServerMetadata
+	body, err := runtime.FullDuplexRequestBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
 	stream, err := client.StreamEmptyStream(ctx)
 	if err != nil {
 		grpclog.Infof("Failed to start streaming: %v", err)
 		return nil, metadata, err
 	}
-	dec := marshaler.NewDecoder(req.Body)
+	dec := marshaler.NewDecoder(body)
 	handleSend := func() error {
 		var protoReq EmptyProto
 		err := dec.Decode(&protoReq)
@@ -134,7 +166,7 @@ func request_FlowCombination_StreamEmptyStream_0(ctx context.Context, marshaler
 		}
 		return nil
 	}
-	go func() {
+	sendAll := func() {
 		for {
 			if err := handleSend(); err != nil {
 				break
@@ -143,7 +175,8 @@ func request_FlowCombination_StreamEmptyStream_0(ctx context.Context, marshaler
 		if err := stream.CloseSend(); err != nil {
 			grpclog.Infof("Failed to terminate client stream: %v", err)
 		}
-	}()
+	}
+	go sendAll()
 	header, err := stream.Header()
 	if err != nil {
 		grpclog.Infof("Failed to get header from client: %v", err)
@@ -153,6 +186,22 @@ func request_FlowCombination_StreamEmptyStream_0(ctx context.Context, marshaler
 	return stream, metadata, nil
 }
 
+func local_request_FlowCombination_StreamEmptyStream_0(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	body, err := runtime.FullDuplexRequestBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
+	stream := runtime.NewInProcessStream(ctx, marshaler.NewDecoder(body))
+	stream.Start(func() error {
+		return server.StreamEmptyStream(&local_FlowCombination_StreamEmptyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+
+	return stream, metadata, nil
+
+}
+
 func request_FlowCombination_RpcBodyRpc_0(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
 	var protoReq NonEmptyProto
 	var metadata runtime.ServerMetadata
@@ -968,6 +1017,27 @@ func request_FlowCombination_RpcBodyStream_0(ctx context.Context, marshaler runt
 
 }
 
+func local_request_FlowCombination_RpcBodyStream_0(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NonEmptyProto
+	var metadata runtime.ServerMetadata
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq); err != nil && err != io.EOF {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcBodyStream(&protoReq, &local_FlowCombination_RpcBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 func request_FlowCombination_RpcBodyStream_1(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (FlowCombination_RpcBodyStreamClient, runtime.ServerMetadata, error) {
 	var protoReq NonEmptyProto
 	var metadata runtime.ServerMetadata
@@ -1022,6 +1092,56 @@ func request_FlowCombination_RpcBodyStream_1(ctx context.Context, marshaler runt
 
 }
 
+func local_request_FlowCombination_RpcBodyStream_1(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NonEmptyProto
+	var metadata runtime.ServerMetadata
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a")
+	}
+
+	protoReq.A, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a", err)
+	}
+
+	val, ok = pathParams["b"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "b")
+	}
+
+	protoReq.B, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "b", err)
+	}
+
+	val, ok = pathParams["c"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "c")
+	}
+
+	protoReq.C, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "c", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcBodyStream(&protoReq, &local_FlowCombination_RpcBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 var (
 	filter_FlowCombination_RpcBodyStream_2 = &utilities.DoubleArray{Encoding: map[string]int{}, Base: []int(nil), Check: []int(nil)}
 )
@@ -1050,6 +1170,26 @@ func request_FlowCombination_RpcBodyStream_2(ctx context.Context, marshaler runt
 
 }
 
+func local_request_FlowCombination_RpcBodyStream_2(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NonEmptyProto
+	var metadata runtime.ServerMetadata
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcBodyStream_2); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcBodyStream(&protoReq, &local_FlowCombination_RpcBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 func request_FlowCombination_RpcBodyStream_3(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (FlowCombination_RpcBodyStreamClient, runtime.ServerMetadata, error) {
 	var protoReq NonEmptyProto
 	var metadata runtime.ServerMetadata
@@ -1102,6 +1242,54 @@ func request_FlowCombination_RpcBodyStream_3(ctx context.Context, marshaler runt
 
 }
 
+func local_request_FlowCombination_RpcBodyStream_3(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NonEmptyProto
+	var metadata runtime.ServerMetadata
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.C); err != nil && err != io.EOF {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a")
+	}
+
+	protoReq.A, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a", err)
+	}
+
+	val, ok = pathParams["b"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "b")
+	}
+
+	protoReq.B, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "b", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcBodyStream(&protoReq, &local_FlowCombination_RpcBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 var (
 	filter_FlowCombination_RpcBodyStream_4 = &utilities.DoubleArray{Encoding: map[string]int{"c": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
 )
@@ -1138,6 +1326,34 @@ func request_FlowCombination_RpcBodyStream_4(ctx context.Context, marshaler runt
 
 }
 
+func local_request_FlowCombination_RpcBodyStream_4(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NonEmptyProto
+	var metadata runtime.ServerMetadata
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.C); err != nil && err != io.EOF {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcBodyStream_4); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcBodyStream(&protoReq, &local_FlowCombination_RpcBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 var (
 	filter_FlowCombination_RpcBodyStream_5 = &utilities.DoubleArray{Encoding: map[string]int{"c": 0, "a": 1}, Base: []int{1, 1, 2, 0, 0}, Check: []int{0, 1, 1, 2, 3}}
 )
@@ -1191,6 +1407,51 @@ func request_FlowCombination_RpcBodyStream_5(ctx context.Context, marshaler runt
 
 }
 
+func local_request_FlowCombination_RpcBodyStream_5(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NonEmptyProto
+	var metadata runtime.ServerMetadata
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.C); err != nil && err != io.EOF {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a")
+	}
+
+	protoReq.A, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a", err)
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcBodyStream_5); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcBodyStream(&protoReq, &local_FlowCombination_RpcBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 var (
 	filter_FlowCombination_RpcBodyStream_6 = &utilities.DoubleArray{Encoding: map[string]int{"a": 0}, Base: []int{1, 1, 0}, Check: []int{0, 1, 2}}
 )
@@ -1236,12 +1497,8 @@ func request_FlowCombination_RpcBodyStream_6(ctx context.Context, marshaler runt
 
 }
 
-var (
-	filter_FlowCombination_RpcPathSingleNestedStream_0 = &utilities.DoubleArray{Encoding: map[string]int{"a": 0, "str": 1}, Base: []int{1, 1, 1, 0}, Check: []int{0, 1, 2, 3}}
-)
-
-func request_FlowCombination_RpcPathSingleNestedStream_0(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (FlowCombination_RpcPathSingleNestedStreamClient, runtime.ServerMetadata, error) {
-	var protoReq SingleNestedProto
+func local_request_FlowCombination_RpcBodyStream_6(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NonEmptyProto
 	var metadata runtime.ServerMetadata
 
 	var (
@@ -1251,52 +1508,40 @@ func request_FlowCombination_RpcPathSingleNestedStream_0(ctx context.Context, ma
 		_   = err
 	)
 
-	val, ok = pathParams["a.str"]
+	val, ok = pathParams["a"]
 	if !ok {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a.str")
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a")
 	}
 
-	err = runtime.PopulateFieldFromPath(&protoReq, "a.str", val)
+	protoReq.A, err = runtime.String(val)
 	if err != nil {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a.str", err)
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a", err)
 	}
 
 	if err := req.ParseForm(); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
-	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcPathSingleNestedStream_0); err != nil {
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcBodyStream_6); err != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	stream, err := client.RpcPathSingleNestedStream(ctx, &protoReq)
-	if err != nil {
-		return nil, metadata, err
-	}
-	header, err := stream.Header()
-	if err != nil {
-		return nil, metadata, err
-	}
-	metadata.HeaderMD = header
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcBodyStream(&protoReq, &local_FlowCombination_RpcBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
 	return stream, metadata, nil
 
 }
 
 var (
-	filter_FlowCombination_RpcPathNestedStream_0 = &utilities.DoubleArray{Encoding: map[string]int{"c": 0, "a": 1, "str": 2, "b": 3}, Base: []int{1, 1, 1, 2, 3, 0, 0, 0}, Check: []int{0, 1, 1, 3, 1, 2, 4, 5}}
+	filter_FlowCombination_RpcPathSingleNestedStream_0 = &utilities.DoubleArray{Encoding: map[string]int{"a": 0, "str": 1}, Base: []int{1, 1, 1, 0}, Check: []int{0, 1, 2, 3}}
 )
 
-func request_FlowCombination_RpcPathNestedStream_0(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (FlowCombination_RpcPathNestedStreamClient, runtime.ServerMetadata, error) {
-	var protoReq NestedProto
+func request_FlowCombination_RpcPathSingleNestedStream_0(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (FlowCombination_RpcPathSingleNestedStreamClient, runtime.ServerMetadata, error) {
+	var protoReq SingleNestedProto
 	var metadata runtime.ServerMetadata
 
-	newReader, berr := utilities.IOReaderFactory(req.Body)
-	if berr != nil {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
-	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.C); err != nil && err != io.EOF {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
-	}
-
 	var (
 		val string
 		ok  bool
@@ -1314,7 +1559,97 @@ func request_FlowCombination_RpcPathNestedStream_0(ctx context.Context, marshale
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a.str", err)
 	}
 
-	val, ok = pathParams["b"]
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcPathSingleNestedStream_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream, err := client.RpcPathSingleNestedStream(ctx, &protoReq)
+	if err != nil {
+		return nil, metadata, err
+	}
+	header, err := stream.Header()
+	if err != nil {
+		return nil, metadata, err
+	}
+	metadata.HeaderMD = header
+	return stream, metadata, nil
+
+}
+
+func local_request_FlowCombination_RpcPathSingleNestedStream_0(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq SingleNestedProto
+	var metadata runtime.ServerMetadata
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a.str"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a.str")
+	}
+
+	err = runtime.PopulateFieldFromPath(&protoReq, "a.str", val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a.str", err)
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcPathSingleNestedStream_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcPathSingleNestedStream(&protoReq, &local_FlowCombination_RpcPathSingleNestedStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
+var (
+	filter_FlowCombination_RpcPathNestedStream_0 = &utilities.DoubleArray{Encoding: map[string]int{"c": 0, "a": 1, "str": 2, "b": 3}, Base: []int{1, 1, 1, 2, 3, 0, 0, 0}, Check: []int{0, 1, 1, 3, 1, 2, 4, 5}}
+)
+
+func request_FlowCombination_RpcPathNestedStream_0(ctx context.Context, marshaler runtime.Marshaler, client FlowCombinationClient, req *http.Request, pathParams map[string]string) (FlowCombination_RpcPathNestedStreamClient, runtime.ServerMetadata, error) {
+	var protoReq NestedProto
+	var metadata runtime.ServerMetadata
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.C); err != nil && err != io.EOF {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a.str"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a.str")
+	}
+
+	err = runtime.PopulateFieldFromPath(&protoReq, "a.str", val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a.str", err)
+	}
+
+	val, ok = pathParams["b"]
 	if !ok {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "b")
 	}
@@ -1344,6 +1679,61 @@ func request_FlowCombination_RpcPathNestedStream_0(ctx context.Context, marshale
 
 }
 
+func local_request_FlowCombination_RpcPathNestedStream_0(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NestedProto
+	var metadata runtime.ServerMetadata
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.C); err != nil && err != io.EOF {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a.str"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a.str")
+	}
+
+	err = runtime.PopulateFieldFromPath(&protoReq, "a.str", val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a.str", err)
+	}
+
+	val, ok = pathParams["b"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "b")
+	}
+
+	protoReq.B, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "b", err)
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcPathNestedStream_0); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcPathNestedStream(&protoReq, &local_FlowCombination_RpcPathNestedStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 var (
 	filter_FlowCombination_RpcPathNestedStream_1 = &utilities.DoubleArray{Encoding: map[string]int{"a": 0, "str": 1}, Base: []int{1, 1, 1, 0}, Check: []int{0, 1, 2, 3}}
 )
@@ -1389,6 +1779,43 @@ func request_FlowCombination_RpcPathNestedStream_1(ctx context.Context, marshale
 
 }
 
+func local_request_FlowCombination_RpcPathNestedStream_1(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NestedProto
+	var metadata runtime.ServerMetadata
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a.str"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a.str")
+	}
+
+	err = runtime.PopulateFieldFromPath(&protoReq, "a.str", val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a.str", err)
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcPathNestedStream_1); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcPathNestedStream(&protoReq, &local_FlowCombination_RpcPathNestedStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 var (
 	filter_FlowCombination_RpcPathNestedStream_2 = &utilities.DoubleArray{Encoding: map[string]int{"c": 0, "a": 1, "str": 2}, Base: []int{1, 1, 1, 2, 0, 0}, Check: []int{0, 1, 1, 3, 2, 4}}
 )
@@ -1442,11 +1869,62 @@ func request_FlowCombination_RpcPathNestedStream_2(ctx context.Context, marshale
 
 }
 
+func local_request_FlowCombination_RpcPathNestedStream_2(ctx context.Context, marshaler runtime.Marshaler, server FlowCombinationServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq NestedProto
+	var metadata runtime.ServerMetadata
+
+	newReader, berr := utilities.IOReaderFactory(req.Body)
+	if berr != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
+	}
+	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.C); err != nil && err != io.EOF {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["a.str"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "a.str")
+	}
+
+	err = runtime.PopulateFieldFromPath(&protoReq, "a.str", val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "a.str", err)
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := runtime.PopulateQueryParameters(&protoReq, req.Form, filter_FlowCombination_RpcPathNestedStream_2); err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.RpcPathNestedStream(&protoReq, &local_FlowCombination_RpcPathNestedStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 // RegisterFlowCombinationHandlerServer registers the http handlers for service FlowCombination to "mux".
 // UnaryRPC     :call FlowCombinationServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call FlowCombinationServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterFlowCombinationHandlerFromEndpoint instead.
 func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.ServeMux, server FlowCombinationServer) error {
+	return RegisterFlowCombinationHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterFlowCombinationHandlerServerWithOptions is same as RegisterFlowCombinationHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterFlowCombinationHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server FlowCombinationServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_FlowCombination_RpcEmptyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1470,42 +1948,21 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcEmptyRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcEmptyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcEmptyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
-
-	mux.Handle("POST", pattern_FlowCombination_StreamEmptyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
-
-	mux.Handle("POST", pattern_FlowCombination_StreamEmptyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
-
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcEmptyStream", runtime.WithHTTPPathPattern("/rpc/empty/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcBodyRpc_0(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcEmptyStream_0(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1513,23 +1970,26 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcBodyRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_RpcEmptyStream_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcEmptyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_StreamEmptyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/path/{a}/{b}/{c}/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/StreamEmptyRpc", runtime.WithHTTPPathPattern("/stream/empty/rpc"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcBodyRpc_1(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_StreamEmptyRpc_0(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1537,23 +1997,23 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcBodyRpc_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		forward_FlowCombination_StreamEmptyRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/StreamEmptyRpc"), runtime.WithClientStreaming()}, handleOpts...)...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.Handle("POST", pattern_FlowCombination_StreamEmptyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/query/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/StreamEmptyStream", runtime.WithHTTPPathPattern("/stream/empty/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcBodyRpc_2(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_StreamEmptyStream_0(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1561,23 +2021,26 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcBodyRpc_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_StreamEmptyStream_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/StreamEmptyStream"), runtime.WithClientStreaming(), runtime.WithServerStreaming(), runtime.WithFullDuplex()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/path/{a}/{b}/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/rpc"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcBodyRpc_3(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcBodyRpc_0(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1585,23 +2048,23 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcBodyRpc_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		forward_FlowCombination_RpcBodyRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/query/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/path/{a}/{b}/{c}/rpc"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcBodyRpc_4(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcBodyRpc_1(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1609,9 +2072,81 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcBodyRpc_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		forward_FlowCombination_RpcBodyRpc_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/query/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyRpc_2(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcBodyRpc_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/path/{a}/{b}/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyRpc_3(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcBodyRpc_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/query/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyRpc_4(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcBodyRpc_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1620,12 +2155,291 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/path/{a}/query/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/body/path/{a}/query/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyRpc_5(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcBodyRpc_5(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/path/{a}/query/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyRpc_6(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcBodyRpc_6(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathSingleNestedRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathSingleNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested/{a.str}/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcPathSingleNestedRpc_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcPathSingleNestedRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathSingleNestedRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested/{a.str}/{b}/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcPathNestedRpc_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcPathNestedRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested1/{a.str}/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcPathNestedRpc_1(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcPathNestedRpc_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested2/{a.str}/rpc"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcPathNestedRpc_2(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_FlowCombination_RpcPathNestedRpc_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc")}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream", runtime.WithHTTPPathPattern("/rpc/body/stream"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyStream_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_FlowCombination_RpcBodyStream_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream", runtime.WithHTTPPathPattern("/rpc/path/{a}/{b}/{c}/stream"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyStream_1(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_FlowCombination_RpcBodyStream_1(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream", runtime.WithHTTPPathPattern("/rpc/query/stream"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyStream_2(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_FlowCombination_RpcBodyStream_2(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream", runtime.WithHTTPPathPattern("/rpc/body/path/{a}/{b}/stream"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyStream_3(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_FlowCombination_RpcBodyStream_3(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream", runtime.WithHTTPPathPattern("/rpc/body/query/stream"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_FlowCombination_RpcBodyStream_4(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_FlowCombination_RpcBodyStream_4(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream", runtime.WithHTTPPathPattern("/rpc/body/path/{a}/query/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcBodyRpc_5(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcBodyStream_5(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1633,23 +2447,26 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcBodyRpc_5(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_RpcBodyStream_5(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc", runtime.WithHTTPPathPattern("/rpc/path/{a}/query/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream", runtime.WithHTTPPathPattern("/rpc/path/{a}/query/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcBodyRpc_6(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcBodyStream_6(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1657,23 +2474,26 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcBodyRpc_6(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_RpcBodyStream_6(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathSingleNestedRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathSingleNestedStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathSingleNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested/{a.str}/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathSingleNestedStream", runtime.WithHTTPPathPattern("/rpc/path-nested/{a.str}/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcPathSingleNestedRpc_0(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcPathSingleNestedStream_0(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1681,23 +2501,26 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcPathSingleNestedRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_RpcPathSingleNestedStream_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathSingleNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested/{a.str}/{b}/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream", runtime.WithHTTPPathPattern("/rpc/path-nested/{a.str}/{b}/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcPathNestedRpc_0(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcPathNestedStream_0(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1705,23 +2528,26 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcPathNestedRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_RpcPathNestedStream_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested1/{a.str}/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream", runtime.WithHTTPPathPattern("/rpc/path-nested1/{a.str}/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcPathNestedRpc_1(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcPathNestedStream_1(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1729,23 +2555,26 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcPathNestedRpc_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_RpcPathNestedStream_1(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
+
+	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc", runtime.WithHTTPPathPattern("/rpc/path-nested2/{a.str}/rpc"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream", runtime.WithHTTPPathPattern("/rpc/path-nested2/{a.str}/stream"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-		resp, md, err := local_request_FlowCombination_RpcPathNestedRpc_2(ctx, inboundMarshaler, server, req, pathParams)
+		resp, md, err := local_request_FlowCombination_RpcPathNestedStream_2(ctx, inboundMarshaler, server, req, pathParams)
 		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
 		ctx = runtime.NewServerMetadataContext(ctx, md)
 		if err != nil {
@@ -1753,99 +2582,104 @@ func RegisterFlowCombinationHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
-		forward_FlowCombination_RpcPathNestedRpc_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 
-	})
+		forward_FlowCombination_RpcPathNestedStream_2(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+	return nil
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+// local_FlowCombination_RpcEmptyStreamServer implements FlowCombination_RpcEmptyStreamServer over a runtime.InProcessStream.
+type local_FlowCombination_RpcEmptyStreamServer struct {
+	grpc.ServerStream
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+func (x *local_FlowCombination_RpcEmptyStreamServer) Send(m *EmptyProto) error {
+	return x.ServerStream.SendMsg(m)
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+// local_FlowCombination_StreamEmptyRpcServer implements FlowCombination_StreamEmptyRpcServer over a runtime.InProcessStream.
+type local_FlowCombination_StreamEmptyRpcServer struct {
+	grpc.ServerStream
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+func (x *local_FlowCombination_StreamEmptyRpcServer) SendAndClose(m *EmptyProto) error {
+	return x.ServerStream.SendMsg(m)
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+func (x *local_FlowCombination_StreamEmptyRpcServer) Recv() (*EmptyProto, error) {
+	m := new(EmptyProto)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathSingleNestedStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+// local_FlowCombination_StreamEmptyStreamServer implements FlowCombination_StreamEmptyStreamServer over a runtime.InProcessStream.
+type local_FlowCombination_StreamEmptyStreamServer struct {
+	grpc.ServerStream
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+func (x *local_FlowCombination_StreamEmptyStreamServer) Send(m *EmptyProto) error {
+	return x.ServerStream.SendMsg(m)
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+func (x *local_FlowCombination_StreamEmptyStreamServer) Recv() (*EmptyProto, error) {
+	m := new(EmptyProto)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
 
-	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+// local_FlowCombination_RpcBodyStreamServer implements FlowCombination_RpcBodyStreamServer over a runtime.InProcessStream.
+type local_FlowCombination_RpcBodyStreamServer struct {
+	grpc.ServerStream
+}
 
-	return nil
+func (x *local_FlowCombination_RpcBodyStreamServer) Send(m *EmptyProto) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// local_FlowCombination_RpcPathSingleNestedStreamServer implements FlowCombination_RpcPathSingleNestedStreamServer over a runtime.InProcessStream.
+type local_FlowCombination_RpcPathSingleNestedStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *local_FlowCombination_RpcPathSingleNestedStreamServer) Send(m *EmptyProto) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// local_FlowCombination_RpcPathNestedStreamServer implements FlowCombination_RpcPathNestedStreamServer over a runtime.InProcessStream.
+type local_FlowCombination_RpcPathNestedStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *local_FlowCombination_RpcPathNestedStreamServer) Send(m *EmptyProto) error {
+	return x.ServerStream.SendMsg(m)
 }
 
 // RegisterFlowCombinationHandlerFromEndpoint is same as RegisterFlowCombinationHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterFlowCombinationHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterFlowCombinationHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterFlowCombinationHandlerFromEndpointWithOptions is same as RegisterFlowCombinationHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterFlowCombinationHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -1853,19 +2687,26 @@ func RegisterFlowCombinationHandlerFromEndpoint(ctx context.Context, mux *runtim
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterFlowCombinationHandler(ctx, mux, conn)
+	return RegisterFlowCombinationHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterFlowCombinationHandler registers the http handlers for service FlowCombination to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterFlowCombinationHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterFlowCombinationHandlerClient(ctx, mux, NewFlowCombinationClient(conn))
+	return RegisterFlowCombinationHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterFlowCombinationHandlerWithOptions is same as RegisterFlowCombinationHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterFlowCombinationHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterFlowCombinationHandlerClientWithOptions(ctx, mux, NewFlowCombinationClient(conn), handleOpts...)
 }
 
 // RegisterFlowCombinationHandlerClient registers the http handlers for service FlowCombination
@@ -1874,6 +2715,12 @@ func RegisterFlowCombinationHandler(ctx context.Context, mux *runtime.ServeMux,
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "FlowCombinationClient" to call the correct interceptors.
 func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.ServeMux, client FlowCombinationClient) error {
+	return RegisterFlowCombinationHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterFlowCombinationHandlerClientWithOptions is same as RegisterFlowCombinationHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterFlowCombinationHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client FlowCombinationClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_FlowCombination_RpcEmptyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1894,7 +2741,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcEmptyRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcEmptyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcEmptyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1913,9 +2760,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcEmptyStream_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcEmptyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_StreamEmptyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1936,7 +2785,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_StreamEmptyRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/StreamEmptyRpc"), runtime.WithClientStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_StreamEmptyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1955,9 +2804,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_StreamEmptyStream_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/StreamEmptyStream"), runtime.WithClientStreaming(), runtime.WithServerStreaming(), runtime.WithFullDuplex()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1978,7 +2829,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcBodyRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -1999,7 +2850,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcBodyRpc_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2020,7 +2871,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcBodyRpc_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2041,7 +2892,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcBodyRpc_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2062,7 +2913,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcBodyRpc_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2083,7 +2934,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcBodyRpc_5(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyRpc_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2104,7 +2955,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcBodyRpc_6(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathSingleNestedRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2125,7 +2976,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcPathSingleNestedRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathSingleNestedRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2146,7 +2997,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcPathNestedRpc_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2167,7 +3018,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcPathNestedRpc_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedRpc_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2188,7 +3039,7 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_FlowCombination_RpcPathNestedRpc_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedRpc")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2207,9 +3058,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcBodyStream_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2228,9 +3081,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcBodyStream_1(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2249,9 +3104,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcBodyStream_2(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2270,9 +3127,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcBodyStream_3(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2291,9 +3150,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcBodyStream_4(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_5, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2312,9 +3173,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcBodyStream_5(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcBodyStream_6, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2333,9 +3196,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcBodyStream_6(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathSingleNestedStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2354,9 +3219,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcPathSingleNestedStream_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathSingleNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2375,9 +3242,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcPathNestedStream_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2396,9 +3265,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcPathNestedStream_1(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_FlowCombination_RpcPathNestedStream_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -2417,9 +3288,11 @@ func RegisterFlowCombinationHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_FlowCombination_RpcPathNestedStream_2(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.FlowCombination/RpcPathNestedStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	return nil
 }
@@ -2531,3 +3404,445 @@ var (
 
 	forward_FlowCombination_RpcPathNestedStream_2 = runtime.ForwardResponseStream
 )
+
+// FlowCombinationRpcEmptyRpcURL returns the path of the "POST /rpc/empty/rpc" binding of FlowCombination.RpcEmptyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcEmptyRpcURL(protoReq *EmptyProto) (string, error) {
+	return FlowCombinationRpcEmptyRpcURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcEmptyRpcURLWithMode is same as FlowCombinationRpcEmptyRpcURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcEmptyRpcURLWithMode(protoReq *EmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcEmptyRpc_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcEmptyStreamURL returns the path of the "POST /rpc/empty/stream" binding of FlowCombination.RpcEmptyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcEmptyStreamURL(protoReq *EmptyProto) (string, error) {
+	return FlowCombinationRpcEmptyStreamURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcEmptyStreamURLWithMode is same as FlowCombinationRpcEmptyStreamURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcEmptyStreamURLWithMode(protoReq *EmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcEmptyStream_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationStreamEmptyRpcURL returns the path of the "POST /stream/empty/rpc" binding of FlowCombination.StreamEmptyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationStreamEmptyRpcURL(protoReq *EmptyProto) (string, error) {
+	return FlowCombinationStreamEmptyRpcURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationStreamEmptyRpcURLWithMode is same as FlowCombinationStreamEmptyRpcURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationStreamEmptyRpcURLWithMode(protoReq *EmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_StreamEmptyRpc_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationStreamEmptyStreamURL returns the path of the "POST /stream/empty/stream" binding of FlowCombination.StreamEmptyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationStreamEmptyStreamURL(protoReq *EmptyProto) (string, error) {
+	return FlowCombinationStreamEmptyStreamURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationStreamEmptyStreamURLWithMode is same as FlowCombinationStreamEmptyStreamURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationStreamEmptyStreamURLWithMode(protoReq *EmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_StreamEmptyStream_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcBodyRpcURL returns the path of the "POST /rpc/body/rpc" binding of FlowCombination.RpcBodyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyRpcURL(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyRpcURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyRpcURLWithMode is same as FlowCombinationRpcBodyRpcURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyRpcURLWithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcBodyRpc_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcBodyRpcURL1 returns the path of the "POST /rpc/path/{a}/{b}/{c}/rpc" binding of FlowCombination.RpcBodyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyRpcURL1(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyRpcURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyRpcURL1WithMode is same as FlowCombinationRpcBodyRpcURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyRpcURL1WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	if params["b"], err = runtime.FormatFieldFromPath(protoReq, "b", ","); err != nil {
+		return "", err
+	}
+	if params["c"], err = runtime.FormatFieldFromPath(protoReq, "c", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyRpc_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcBodyRpcURL2 returns the path of the "POST /rpc/query/rpc" binding of FlowCombination.RpcBodyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyRpcURL2(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyRpcURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyRpcURL2WithMode is same as FlowCombinationRpcBodyRpcURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyRpcURL2WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcBodyRpc_2.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcBodyRpcURL3 returns the path of the "POST /rpc/body/path/{a}/{b}/rpc" binding of FlowCombination.RpcBodyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyRpcURL3(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyRpcURL3WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyRpcURL3WithMode is same as FlowCombinationRpcBodyRpcURL3 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyRpcURL3WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	if params["b"], err = runtime.FormatFieldFromPath(protoReq, "b", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyRpc_3.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcBodyRpcURL4 returns the path of the "POST /rpc/body/query/rpc" binding of FlowCombination.RpcBodyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyRpcURL4(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyRpcURL4WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyRpcURL4WithMode is same as FlowCombinationRpcBodyRpcURL4 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyRpcURL4WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcBodyRpc_4.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcBodyRpcURL5 returns the path of the "POST /rpc/body/path/{a}/query/rpc" binding of FlowCombination.RpcBodyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyRpcURL5(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyRpcURL5WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyRpcURL5WithMode is same as FlowCombinationRpcBodyRpcURL5 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyRpcURL5WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyRpc_5.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcBodyRpcURL6 returns the path of the "POST /rpc/path/{a}/query/rpc" binding of FlowCombination.RpcBodyRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyRpcURL6(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyRpcURL6WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyRpcURL6WithMode is same as FlowCombinationRpcBodyRpcURL6 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyRpcURL6WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyRpc_6.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathSingleNestedRpcURL returns the path of the "POST /rpc/path-nested/{a.str}/rpc" binding of FlowCombination.RpcPathSingleNestedRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathSingleNestedRpcURL(protoReq *SingleNestedProto) (string, error) {
+	return FlowCombinationRpcPathSingleNestedRpcURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathSingleNestedRpcURLWithMode is same as FlowCombinationRpcPathSingleNestedRpcURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathSingleNestedRpcURLWithMode(protoReq *SingleNestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathSingleNestedRpc_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathNestedRpcURL returns the path of the "POST /rpc/path-nested/{a.str}/{b}/rpc" binding of FlowCombination.RpcPathNestedRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathNestedRpcURL(protoReq *NestedProto) (string, error) {
+	return FlowCombinationRpcPathNestedRpcURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathNestedRpcURLWithMode is same as FlowCombinationRpcPathNestedRpcURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathNestedRpcURLWithMode(protoReq *NestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	if params["b"], err = runtime.FormatFieldFromPath(protoReq, "b", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathNestedRpc_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathNestedRpcURL1 returns the path of the "POST /rpc/path-nested1/{a.str}/rpc" binding of FlowCombination.RpcPathNestedRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathNestedRpcURL1(protoReq *NestedProto) (string, error) {
+	return FlowCombinationRpcPathNestedRpcURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathNestedRpcURL1WithMode is same as FlowCombinationRpcPathNestedRpcURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathNestedRpcURL1WithMode(protoReq *NestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathNestedRpc_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathNestedRpcURL2 returns the path of the "POST /rpc/path-nested2/{a.str}/rpc" binding of FlowCombination.RpcPathNestedRpc,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathNestedRpcURL2(protoReq *NestedProto) (string, error) {
+	return FlowCombinationRpcPathNestedRpcURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathNestedRpcURL2WithMode is same as FlowCombinationRpcPathNestedRpcURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathNestedRpcURL2WithMode(protoReq *NestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathNestedRpc_2.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcBodyStreamURL returns the path of the "POST /rpc/body/stream" binding of FlowCombination.RpcBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyStreamURL(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyStreamURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyStreamURLWithMode is same as FlowCombinationRpcBodyStreamURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyStreamURLWithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcBodyStream_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcBodyStreamURL1 returns the path of the "POST /rpc/path/{a}/{b}/{c}/stream" binding of FlowCombination.RpcBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyStreamURL1(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyStreamURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyStreamURL1WithMode is same as FlowCombinationRpcBodyStreamURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyStreamURL1WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	if params["b"], err = runtime.FormatFieldFromPath(protoReq, "b", ","); err != nil {
+		return "", err
+	}
+	if params["c"], err = runtime.FormatFieldFromPath(protoReq, "c", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyStream_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcBodyStreamURL2 returns the path of the "POST /rpc/query/stream" binding of FlowCombination.RpcBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyStreamURL2(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyStreamURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyStreamURL2WithMode is same as FlowCombinationRpcBodyStreamURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyStreamURL2WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcBodyStream_2.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcBodyStreamURL3 returns the path of the "POST /rpc/body/path/{a}/{b}/stream" binding of FlowCombination.RpcBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyStreamURL3(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyStreamURL3WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyStreamURL3WithMode is same as FlowCombinationRpcBodyStreamURL3 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyStreamURL3WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	if params["b"], err = runtime.FormatFieldFromPath(protoReq, "b", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyStream_3.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcBodyStreamURL4 returns the path of the "POST /rpc/body/query/stream" binding of FlowCombination.RpcBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyStreamURL4(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyStreamURL4WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyStreamURL4WithMode is same as FlowCombinationRpcBodyStreamURL4 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyStreamURL4WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_FlowCombination_RpcBodyStream_4.ExpandAndEscape(nil, unescapingMode)
+}
+
+// FlowCombinationRpcBodyStreamURL5 returns the path of the "POST /rpc/body/path/{a}/query/stream" binding of FlowCombination.RpcBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyStreamURL5(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyStreamURL5WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyStreamURL5WithMode is same as FlowCombinationRpcBodyStreamURL5 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyStreamURL5WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyStream_5.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcBodyStreamURL6 returns the path of the "POST /rpc/path/{a}/query/stream" binding of FlowCombination.RpcBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcBodyStreamURL6(protoReq *NonEmptyProto) (string, error) {
+	return FlowCombinationRpcBodyStreamURL6WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcBodyStreamURL6WithMode is same as FlowCombinationRpcBodyStreamURL6 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcBodyStreamURL6WithMode(protoReq *NonEmptyProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a"], err = runtime.FormatFieldFromPath(protoReq, "a", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcBodyStream_6.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathSingleNestedStreamURL returns the path of the "POST /rpc/path-nested/{a.str}/stream" binding of FlowCombination.RpcPathSingleNestedStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathSingleNestedStreamURL(protoReq *SingleNestedProto) (string, error) {
+	return FlowCombinationRpcPathSingleNestedStreamURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathSingleNestedStreamURLWithMode is same as FlowCombinationRpcPathSingleNestedStreamURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathSingleNestedStreamURLWithMode(protoReq *SingleNestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathSingleNestedStream_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathNestedStreamURL returns the path of the "POST /rpc/path-nested/{a.str}/{b}/stream" binding of FlowCombination.RpcPathNestedStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathNestedStreamURL(protoReq *NestedProto) (string, error) {
+	return FlowCombinationRpcPathNestedStreamURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathNestedStreamURLWithMode is same as FlowCombinationRpcPathNestedStreamURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathNestedStreamURLWithMode(protoReq *NestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	if params["b"], err = runtime.FormatFieldFromPath(protoReq, "b", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathNestedStream_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathNestedStreamURL1 returns the path of the "POST /rpc/path-nested1/{a.str}/stream" binding of FlowCombination.RpcPathNestedStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathNestedStreamURL1(protoReq *NestedProto) (string, error) {
+	return FlowCombinationRpcPathNestedStreamURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathNestedStreamURL1WithMode is same as FlowCombinationRpcPathNestedStreamURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathNestedStreamURL1WithMode(protoReq *NestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathNestedStream_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// FlowCombinationRpcPathNestedStreamURL2 returns the path of the "POST /rpc/path-nested2/{a.str}/stream" binding of FlowCombination.RpcPathNestedStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func FlowCombinationRpcPathNestedStreamURL2(protoReq *NestedProto) (string, error) {
+	return FlowCombinationRpcPathNestedStreamURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// FlowCombinationRpcPathNestedStreamURL2WithMode is same as FlowCombinationRpcPathNestedStreamURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func FlowCombinationRpcPathNestedStreamURL2WithMode(protoReq *NestedProto, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["a.str"], err = runtime.FormatFieldFromPath(protoReq, "a.str", ","); err != nil {
+		return "", err
+	}
+	return pattern_FlowCombination_RpcPathNestedStream_2.ExpandAndEscape(params, unescapingMode)
+}
@@ -135,9 +135,15 @@ func local_request_GenerateUnboundMethodsEchoService_EchoDelete_0(ctx context.Co
 
 // RegisterGenerateUnboundMethodsEchoServiceHandlerServer registers the http handlers for service GenerateUnboundMethodsEchoService to "mux".
 // UnaryRPC     :call GenerateUnboundMethodsEchoServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call GenerateUnboundMethodsEchoServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpoint instead.
 func RegisterGenerateUnboundMethodsEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server GenerateUnboundMethodsEchoServiceServer) error {
+	return RegisterGenerateUnboundMethodsEchoServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterGenerateUnboundMethodsEchoServiceHandlerServerWithOptions is same as RegisterGenerateUnboundMethodsEchoServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGenerateUnboundMethodsEchoServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server GenerateUnboundMethodsEchoServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_GenerateUnboundMethodsEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -161,7 +167,7 @@ func RegisterGenerateUnboundMethodsEchoServiceHandlerServer(ctx context.Context,
 
 		forward_GenerateUnboundMethodsEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_GenerateUnboundMethodsEchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -185,7 +191,7 @@ func RegisterGenerateUnboundMethodsEchoServiceHandlerServer(ctx context.Context,
 
 		forward_GenerateUnboundMethodsEchoService_EchoBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/EchoBody")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_GenerateUnboundMethodsEchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -209,20 +215,29 @@ func RegisterGenerateUnboundMethodsEchoServiceHandlerServer(ctx context.Context,
 
 		forward_GenerateUnboundMethodsEchoService_EchoDelete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/EchoDelete")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpoint is same as RegisterGenerateUnboundMethodsEchoServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpointWithOptions is same as RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -230,19 +245,26 @@ func RegisterGenerateUnboundMethodsEchoServiceHandlerFromEndpoint(ctx context.Co
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterGenerateUnboundMethodsEchoServiceHandler(ctx, mux, conn)
+	return RegisterGenerateUnboundMethodsEchoServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterGenerateUnboundMethodsEchoServiceHandler registers the http handlers for service GenerateUnboundMethodsEchoService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterGenerateUnboundMethodsEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterGenerateUnboundMethodsEchoServiceHandlerClient(ctx, mux, NewGenerateUnboundMethodsEchoServiceClient(conn))
+	return RegisterGenerateUnboundMethodsEchoServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterGenerateUnboundMethodsEchoServiceHandlerWithOptions is same as RegisterGenerateUnboundMethodsEchoServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGenerateUnboundMethodsEchoServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterGenerateUnboundMethodsEchoServiceHandlerClientWithOptions(ctx, mux, NewGenerateUnboundMethodsEchoServiceClient(conn), handleOpts...)
 }
 
 // RegisterGenerateUnboundMethodsEchoServiceHandlerClient registers the http handlers for service GenerateUnboundMethodsEchoService
@@ -251,6 +273,12 @@ func RegisterGenerateUnboundMethodsEchoServiceHandler(ctx context.Context, mux *
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "GenerateUnboundMethodsEchoServiceClient" to call the correct interceptors.
 func RegisterGenerateUnboundMethodsEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client GenerateUnboundMethodsEchoServiceClient) error {
+	return RegisterGenerateUnboundMethodsEchoServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterGenerateUnboundMethodsEchoServiceHandlerClientWithOptions is same as RegisterGenerateUnboundMethodsEchoServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterGenerateUnboundMethodsEchoServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client GenerateUnboundMethodsEchoServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_GenerateUnboundMethodsEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -271,7 +299,7 @@ func RegisterGenerateUnboundMethodsEchoServiceHandlerClient(ctx context.Context,
 
 		forward_GenerateUnboundMethodsEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_GenerateUnboundMethodsEchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -292,7 +320,7 @@ func RegisterGenerateUnboundMethodsEchoServiceHandlerClient(ctx context.Context,
 
 		forward_GenerateUnboundMethodsEchoService_EchoBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/EchoBody")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_GenerateUnboundMethodsEchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -313,7 +341,7 @@ func RegisterGenerateUnboundMethodsEchoServiceHandlerClient(ctx context.Context,
 
 		forward_GenerateUnboundMethodsEchoService_EchoDelete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/EchoDelete")}, handleOpts...)...)
 
 	return nil
 }
@@ -333,3 +361,42 @@ var (
 
 	forward_GenerateUnboundMethodsEchoService_EchoDelete_0 = runtime.ForwardResponseMessage
 )
+
+// GenerateUnboundMethodsEchoServiceEchoURL returns the path of the "POST /grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/Echo" binding of GenerateUnboundMethodsEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GenerateUnboundMethodsEchoServiceEchoURL(protoReq *GenerateUnboundMethodsSimpleMessage) (string, error) {
+	return GenerateUnboundMethodsEchoServiceEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GenerateUnboundMethodsEchoServiceEchoURLWithMode is same as GenerateUnboundMethodsEchoServiceEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GenerateUnboundMethodsEchoServiceEchoURLWithMode(protoReq *GenerateUnboundMethodsSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_GenerateUnboundMethodsEchoService_Echo_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// GenerateUnboundMethodsEchoServiceEchoBodyURL returns the path of the "POST /grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/EchoBody" binding of GenerateUnboundMethodsEchoService.EchoBody,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GenerateUnboundMethodsEchoServiceEchoBodyURL(protoReq *GenerateUnboundMethodsSimpleMessage) (string, error) {
+	return GenerateUnboundMethodsEchoServiceEchoBodyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GenerateUnboundMethodsEchoServiceEchoBodyURLWithMode is same as GenerateUnboundMethodsEchoServiceEchoBodyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GenerateUnboundMethodsEchoServiceEchoBodyURLWithMode(protoReq *GenerateUnboundMethodsSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_GenerateUnboundMethodsEchoService_EchoBody_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// GenerateUnboundMethodsEchoServiceEchoDeleteURL returns the path of the "POST /grpc.gateway.examples.internal.proto.examplepb.GenerateUnboundMethodsEchoService/EchoDelete" binding of GenerateUnboundMethodsEchoService.EchoDelete,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func GenerateUnboundMethodsEchoServiceEchoDeleteURL(protoReq *GenerateUnboundMethodsSimpleMessage) (string, error) {
+	return GenerateUnboundMethodsEchoServiceEchoDeleteURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// GenerateUnboundMethodsEchoServiceEchoDeleteURLWithMode is same as GenerateUnboundMethodsEchoServiceEchoDeleteURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func GenerateUnboundMethodsEchoServiceEchoDeleteURLWithMode(protoReq *GenerateUnboundMethodsSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_GenerateUnboundMethodsEchoService_EchoDelete_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -165,9 +165,15 @@ func local_request_NonStandardService_UpdateWithJSONNames_0(ctx context.Context,
 
 // RegisterNonStandardServiceHandlerServer registers the http handlers for service NonStandardService to "mux".
 // UnaryRPC     :call NonStandardServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call NonStandardServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterNonStandardServiceHandlerFromEndpoint instead.
 func RegisterNonStandardServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server NonStandardServiceServer) error {
+	return RegisterNonStandardServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterNonStandardServiceHandlerServerWithOptions is same as RegisterNonStandardServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterNonStandardServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server NonStandardServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("PATCH", pattern_NonStandardService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -191,7 +197,7 @@ func RegisterNonStandardServiceHandlerServer(ctx context.Context, mux *runtime.S
 
 		forward_NonStandardService_Update_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.NonStandardService/Update")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_NonStandardService_UpdateWithJSONNames_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -215,20 +221,29 @@ func RegisterNonStandardServiceHandlerServer(ctx context.Context, mux *runtime.S
 
 		forward_NonStandardService_UpdateWithJSONNames_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.NonStandardService/UpdateWithJSONNames")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterNonStandardServiceHandlerFromEndpoint is same as RegisterNonStandardServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterNonStandardServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterNonStandardServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterNonStandardServiceHandlerFromEndpointWithOptions is same as RegisterNonStandardServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterNonStandardServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -236,19 +251,26 @@ func RegisterNonStandardServiceHandlerFromEndpoint(ctx context.Context, mux *run
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterNonStandardServiceHandler(ctx, mux, conn)
+	return RegisterNonStandardServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterNonStandardServiceHandler registers the http handlers for service NonStandardService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterNonStandardServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterNonStandardServiceHandlerClient(ctx, mux, NewNonStandardServiceClient(conn))
+	return RegisterNonStandardServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterNonStandardServiceHandlerWithOptions is same as RegisterNonStandardServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterNonStandardServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterNonStandardServiceHandlerClientWithOptions(ctx, mux, NewNonStandardServiceClient(conn), handleOpts...)
 }
 
 // RegisterNonStandardServiceHandlerClient registers the http handlers for service NonStandardService
@@ -257,6 +279,12 @@ func RegisterNonStandardServiceHandler(ctx context.Context, mux *runtime.ServeMu
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "NonStandardServiceClient" to call the correct interceptors.
 func RegisterNonStandardServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client NonStandardServiceClient) error {
+	return RegisterNonStandardServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterNonStandardServiceHandlerClientWithOptions is same as RegisterNonStandardServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterNonStandardServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client NonStandardServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("PATCH", pattern_NonStandardService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -277,7 +305,7 @@ func RegisterNonStandardServiceHandlerClient(ctx context.Context, mux *runtime.S
 
 		forward_NonStandardService_Update_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.NonStandardService/Update")}, handleOpts...)...)
 
 	mux.Handle("PATCH", pattern_NonStandardService_UpdateWithJSONNames_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -298,7 +326,7 @@ func RegisterNonStandardServiceHandlerClient(ctx context.Context, mux *runtime.S
 
 		forward_NonStandardService_UpdateWithJSONNames_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.NonStandardService/UpdateWithJSONNames")}, handleOpts...)...)
 
 	return nil
 }
@@ -314,3 +342,29 @@ var (
 
 	forward_NonStandardService_UpdateWithJSONNames_0 = runtime.ForwardResponseMessage
 )
+
+// NonStandardServiceUpdateURL returns the path of the "PATCH /v1/example/non_standard/update" binding of NonStandardService.Update,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func NonStandardServiceUpdateURL(protoReq *NonStandardUpdateRequest) (string, error) {
+	return NonStandardServiceUpdateURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// NonStandardServiceUpdateURLWithMode is same as NonStandardServiceUpdateURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func NonStandardServiceUpdateURLWithMode(protoReq *NonStandardUpdateRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_NonStandardService_Update_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// NonStandardServiceUpdateWithJSONNamesURL returns the path of the "PATCH /v1/example/non_standard/update_with_json_names" binding of NonStandardService.UpdateWithJSONNames,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func NonStandardServiceUpdateWithJSONNamesURL(protoReq *NonStandardWithJSONNamesUpdateRequest) (string, error) {
+	return NonStandardServiceUpdateWithJSONNamesURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// NonStandardServiceUpdateWithJSONNamesURLWithMode is same as NonStandardServiceUpdateWithJSONNamesURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func NonStandardServiceUpdateWithJSONNamesURLWithMode(protoReq *NonStandardWithJSONNamesUpdateRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_NonStandardService_UpdateWithJSONNames_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -169,9 +169,15 @@ func local_request_ServiceC_MethodTwo_0(ctx context.Context, marshaler runtime.M
 
 // RegisterServiceAHandlerServer registers the http handlers for service ServiceA to "mux".
 // UnaryRPC     :call ServiceAServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call ServiceAServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterServiceAHandlerFromEndpoint instead.
 func RegisterServiceAHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ServiceAServer) error {
+	return RegisterServiceAHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterServiceAHandlerServerWithOptions is same as RegisterServiceAHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceAHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server ServiceAServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ServiceA_MethodOne_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -195,7 +201,7 @@ func RegisterServiceAHandlerServer(ctx context.Context, mux *runtime.ServeMux, s
 
 		forward_ServiceA_MethodOne_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceA/MethodOne")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ServiceA_MethodTwo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -219,16 +225,22 @@ func RegisterServiceAHandlerServer(ctx context.Context, mux *runtime.ServeMux, s
 
 		forward_ServiceA_MethodTwo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceA/MethodTwo")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterServiceCHandlerServer registers the http handlers for service ServiceC to "mux".
 // UnaryRPC     :call ServiceCServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call ServiceCServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterServiceCHandlerFromEndpoint instead.
 func RegisterServiceCHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ServiceCServer) error {
+	return RegisterServiceCHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterServiceCHandlerServerWithOptions is same as RegisterServiceCHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceCHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server ServiceCServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ServiceC_MethodOne_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -252,7 +264,7 @@ func RegisterServiceCHandlerServer(ctx context.Context, mux *runtime.ServeMux, s
 
 		forward_ServiceC_MethodOne_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceC/MethodOne")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ServiceC_MethodTwo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -276,20 +288,29 @@ func RegisterServiceCHandlerServer(ctx context.Context, mux *runtime.ServeMux, s
 
 		forward_ServiceC_MethodTwo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceC/MethodTwo")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterServiceAHandlerFromEndpoint is same as RegisterServiceAHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterServiceAHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterServiceAHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterServiceAHandlerFromEndpointWithOptions is same as RegisterServiceAHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceAHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -297,19 +318,26 @@ func RegisterServiceAHandlerFromEndpoint(ctx context.Context, mux *runtime.Serve
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterServiceAHandler(ctx, mux, conn)
+	return RegisterServiceAHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterServiceAHandler registers the http handlers for service ServiceA to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterServiceAHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterServiceAHandlerClient(ctx, mux, NewServiceAClient(conn))
+	return RegisterServiceAHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterServiceAHandlerWithOptions is same as RegisterServiceAHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceAHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterServiceAHandlerClientWithOptions(ctx, mux, NewServiceAClient(conn), handleOpts...)
 }
 
 // RegisterServiceAHandlerClient registers the http handlers for service ServiceA
@@ -318,6 +346,12 @@ func RegisterServiceAHandler(ctx context.Context, mux *runtime.ServeMux, conn *g
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ServiceAClient" to call the correct interceptors.
 func RegisterServiceAHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ServiceAClient) error {
+	return RegisterServiceAHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterServiceAHandlerClientWithOptions is same as RegisterServiceAHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceAHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client ServiceAClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ServiceA_MethodOne_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -338,7 +372,7 @@ func RegisterServiceAHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 
 		forward_ServiceA_MethodOne_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceA/MethodOne")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ServiceA_MethodTwo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -359,7 +393,7 @@ func RegisterServiceAHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 
 		forward_ServiceA_MethodTwo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceA/MethodTwo")}, handleOpts...)...)
 
 	return nil
 }
@@ -376,15 +410,50 @@ var (
 	forward_ServiceA_MethodTwo_0 = runtime.ForwardResponseMessage
 )
 
+// ServiceAMethodOneURL returns the path of the "POST /v1/example/a/1" binding of ServiceA.MethodOne,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ServiceAMethodOneURL(protoReq *InMessageA) (string, error) {
+	return ServiceAMethodOneURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ServiceAMethodOneURLWithMode is same as ServiceAMethodOneURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ServiceAMethodOneURLWithMode(protoReq *InMessageA, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ServiceA_MethodOne_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ServiceAMethodTwoURL returns the path of the "POST /v1/example/a/2" binding of ServiceA.MethodTwo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ServiceAMethodTwoURL(protoReq *OutMessageA) (string, error) {
+	return ServiceAMethodTwoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ServiceAMethodTwoURLWithMode is same as ServiceAMethodTwoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ServiceAMethodTwoURLWithMode(protoReq *OutMessageA, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ServiceA_MethodTwo_0.ExpandAndEscape(nil, unescapingMode)
+}
+
 // RegisterServiceCHandlerFromEndpoint is same as RegisterServiceCHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterServiceCHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterServiceCHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterServiceCHandlerFromEndpointWithOptions is same as RegisterServiceCHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceCHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -392,19 +461,26 @@ func RegisterServiceCHandlerFromEndpoint(ctx context.Context, mux *runtime.Serve
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterServiceCHandler(ctx, mux, conn)
+	return RegisterServiceCHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterServiceCHandler registers the http handlers for service ServiceC to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterServiceCHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterServiceCHandlerClient(ctx, mux, NewServiceCClient(conn))
+	return RegisterServiceCHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterServiceCHandlerWithOptions is same as RegisterServiceCHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceCHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterServiceCHandlerClientWithOptions(ctx, mux, NewServiceCClient(conn), handleOpts...)
 }
 
 // RegisterServiceCHandlerClient registers the http handlers for service ServiceC
@@ -413,6 +489,12 @@ func RegisterServiceCHandler(ctx context.Context, mux *runtime.ServeMux, conn *g
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ServiceCClient" to call the correct interceptors.
 func RegisterServiceCHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ServiceCClient) error {
+	return RegisterServiceCHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterServiceCHandlerClientWithOptions is same as RegisterServiceCHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceCHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client ServiceCClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ServiceC_MethodOne_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -433,7 +515,7 @@ func RegisterServiceCHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 
 		forward_ServiceC_MethodOne_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceC/MethodOne")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ServiceC_MethodTwo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -454,7 +536,7 @@ func RegisterServiceCHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 
 		forward_ServiceC_MethodTwo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceC/MethodTwo")}, handleOpts...)...)
 
 	return nil
 }
@@ -470,3 +552,29 @@ var (
 
 	forward_ServiceC_MethodTwo_0 = runtime.ForwardResponseMessage
 )
+
+// ServiceCMethodOneURL returns the path of the "POST /v1/example/c/1" binding of ServiceC.MethodOne,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ServiceCMethodOneURL(protoReq *InMessageA) (string, error) {
+	return ServiceCMethodOneURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ServiceCMethodOneURLWithMode is same as ServiceCMethodOneURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ServiceCMethodOneURLWithMode(protoReq *InMessageA, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ServiceC_MethodOne_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ServiceCMethodTwoURL returns the path of the "POST /v1/example/c/2" binding of ServiceC.MethodTwo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ServiceCMethodTwoURL(protoReq *OutMessageA) (string, error) {
+	return ServiceCMethodTwoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ServiceCMethodTwoURLWithMode is same as ServiceCMethodTwoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ServiceCMethodTwoURLWithMode(protoReq *OutMessageA, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ServiceC_MethodTwo_0.ExpandAndEscape(nil, unescapingMode)
+}
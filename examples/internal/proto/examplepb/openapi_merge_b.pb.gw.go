@@ -101,9 +101,15 @@ func local_request_ServiceB_MethodTwo_0(ctx context.Context, marshaler runtime.M
 
 // RegisterServiceBHandlerServer registers the http handlers for service ServiceB to "mux".
 // UnaryRPC     :call ServiceBServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call ServiceBServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterServiceBHandlerFromEndpoint instead.
 func RegisterServiceBHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ServiceBServer) error {
+	return RegisterServiceBHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterServiceBHandlerServerWithOptions is same as RegisterServiceBHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceBHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server ServiceBServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ServiceB_MethodOne_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -127,7 +133,7 @@ func RegisterServiceBHandlerServer(ctx context.Context, mux *runtime.ServeMux, s
 
 		forward_ServiceB_MethodOne_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceB/MethodOne")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ServiceB_MethodTwo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -151,20 +157,29 @@ func RegisterServiceBHandlerServer(ctx context.Context, mux *runtime.ServeMux, s
 
 		forward_ServiceB_MethodTwo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceB/MethodTwo")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterServiceBHandlerFromEndpoint is same as RegisterServiceBHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterServiceBHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterServiceBHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterServiceBHandlerFromEndpointWithOptions is same as RegisterServiceBHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceBHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -172,19 +187,26 @@ func RegisterServiceBHandlerFromEndpoint(ctx context.Context, mux *runtime.Serve
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterServiceBHandler(ctx, mux, conn)
+	return RegisterServiceBHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterServiceBHandler registers the http handlers for service ServiceB to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterServiceBHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterServiceBHandlerClient(ctx, mux, NewServiceBClient(conn))
+	return RegisterServiceBHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterServiceBHandlerWithOptions is same as RegisterServiceBHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceBHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterServiceBHandlerClientWithOptions(ctx, mux, NewServiceBClient(conn), handleOpts...)
 }
 
 // RegisterServiceBHandlerClient registers the http handlers for service ServiceB
@@ -193,6 +215,12 @@ func RegisterServiceBHandler(ctx context.Context, mux *runtime.ServeMux, conn *g
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ServiceBClient" to call the correct interceptors.
 func RegisterServiceBHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ServiceBClient) error {
+	return RegisterServiceBHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterServiceBHandlerClientWithOptions is same as RegisterServiceBHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterServiceBHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client ServiceBClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_ServiceB_MethodOne_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -213,7 +241,7 @@ func RegisterServiceBHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 
 		forward_ServiceB_MethodOne_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceB/MethodOne")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_ServiceB_MethodTwo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -234,7 +262,7 @@ func RegisterServiceBHandlerClient(ctx context.Context, mux *runtime.ServeMux, c
 
 		forward_ServiceB_MethodTwo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.examplepb.ServiceB/MethodTwo")}, handleOpts...)...)
 
 	return nil
 }
@@ -250,3 +278,29 @@ var (
 
 	forward_ServiceB_MethodTwo_0 = runtime.ForwardResponseMessage
 )
+
+// ServiceBMethodOneURL returns the path of the "POST /v1/example/b/1" binding of ServiceB.MethodOne,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ServiceBMethodOneURL(protoReq *InMessageB) (string, error) {
+	return ServiceBMethodOneURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ServiceBMethodOneURLWithMode is same as ServiceBMethodOneURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ServiceBMethodOneURLWithMode(protoReq *InMessageB, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ServiceB_MethodOne_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// ServiceBMethodTwoURL returns the path of the "POST /v1/example/b/2" binding of ServiceB.MethodTwo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ServiceBMethodTwoURL(protoReq *OutMessageB) (string, error) {
+	return ServiceBMethodTwoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ServiceBMethodTwoURLWithMode is same as ServiceBMethodTwoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ServiceBMethodTwoURLWithMode(protoReq *OutMessageB, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_ServiceB_MethodTwo_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -221,11 +221,47 @@ func request_ResponseBodyService_GetResponseBodyStream_0(ctx context.Context, ma
 
 }
 
+func local_request_ResponseBodyService_GetResponseBodyStream_0(ctx context.Context, marshaler runtime.Marshaler, server ResponseBodyServiceServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq ResponseBodyIn
+	var metadata runtime.ServerMetadata
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["data"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "data")
+	}
+
+	protoReq.Data, err = runtime.String(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "data", err)
+	}
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.GetResponseBodyStream(&protoReq, &local_ResponseBodyService_GetResponseBodyStreamServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 // RegisterResponseBodyServiceHandlerServer registers the http handlers for service ResponseBodyService to "mux".
 // UnaryRPC     :call ResponseBodyServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call ResponseBodyServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterResponseBodyServiceHandlerFromEndpoint instead.
 func RegisterResponseBodyServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ResponseBodyServiceServer) error {
+	return RegisterResponseBodyServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterResponseBodyServiceHandlerServerWithOptions is same as RegisterResponseBodyServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterResponseBodyServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server ResponseBodyServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("GET", pattern_ResponseBodyService_GetResponseBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -249,7 +285,7 @@ func RegisterResponseBodyServiceHandlerServer(ctx context.Context, mux *runtime.
 
 		forward_ResponseBodyService_GetResponseBody_0(ctx, mux, outboundMarshaler, w, req, response_ResponseBodyService_GetResponseBody_0{resp}, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/GetResponseBody")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ResponseBodyService_ListResponseBodies_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -273,7 +309,7 @@ func RegisterResponseBodyServiceHandlerServer(ctx context.Context, mux *runtime.
 
 		forward_ResponseBodyService_ListResponseBodies_0(ctx, mux, outboundMarshaler, w, req, response_ResponseBodyService_ListResponseBodies_0{resp}, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/ListResponseBodies")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ResponseBodyService_ListResponseStrings_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -297,27 +333,68 @@ func RegisterResponseBodyServiceHandlerServer(ctx context.Context, mux *runtime.
 
 		forward_ResponseBodyService_ListResponseStrings_0(ctx, mux, outboundMarshaler, w, req, response_ResponseBodyService_ListResponseStrings_0{resp}, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/ListResponseStrings")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ResponseBodyService_GetResponseBodyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/GetResponseBodyStream", runtime.WithHTTPPathPattern("/responsebody/stream/{data}"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_ResponseBodyService_GetResponseBodyStream_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_ResponseBodyService_GetResponseBodyStream_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
+			res, err := resp.Recv()
+			return response_ResponseBodyService_GetResponseBodyStream_0{res}, err
+		}, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/GetResponseBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	return nil
 }
 
+// local_ResponseBodyService_GetResponseBodyStreamServer implements ResponseBodyService_GetResponseBodyStreamServer over a runtime.InProcessStream.
+type local_ResponseBodyService_GetResponseBodyStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *local_ResponseBodyService_GetResponseBodyStreamServer) Send(m *ResponseBodyOut) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // RegisterResponseBodyServiceHandlerFromEndpoint is same as RegisterResponseBodyServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterResponseBodyServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterResponseBodyServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterResponseBodyServiceHandlerFromEndpointWithOptions is same as RegisterResponseBodyServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterResponseBodyServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -325,19 +402,26 @@ func RegisterResponseBodyServiceHandlerFromEndpoint(ctx context.Context, mux *ru
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterResponseBodyServiceHandler(ctx, mux, conn)
+	return RegisterResponseBodyServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterResponseBodyServiceHandler registers the http handlers for service ResponseBodyService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterResponseBodyServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterResponseBodyServiceHandlerClient(ctx, mux, NewResponseBodyServiceClient(conn))
+	return RegisterResponseBodyServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterResponseBodyServiceHandlerWithOptions is same as RegisterResponseBodyServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterResponseBodyServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterResponseBodyServiceHandlerClientWithOptions(ctx, mux, NewResponseBodyServiceClient(conn), handleOpts...)
 }
 
 // RegisterResponseBodyServiceHandlerClient registers the http handlers for service ResponseBodyService
@@ -346,6 +430,12 @@ func RegisterResponseBodyServiceHandler(ctx context.Context, mux *runtime.ServeM
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ResponseBodyServiceClient" to call the correct interceptors.
 func RegisterResponseBodyServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ResponseBodyServiceClient) error {
+	return RegisterResponseBodyServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterResponseBodyServiceHandlerClientWithOptions is same as RegisterResponseBodyServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterResponseBodyServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client ResponseBodyServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("GET", pattern_ResponseBodyService_GetResponseBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -366,7 +456,7 @@ func RegisterResponseBodyServiceHandlerClient(ctx context.Context, mux *runtime.
 
 		forward_ResponseBodyService_GetResponseBody_0(ctx, mux, outboundMarshaler, w, req, response_ResponseBodyService_GetResponseBody_0{resp}, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/GetResponseBody")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ResponseBodyService_ListResponseBodies_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -387,7 +477,7 @@ func RegisterResponseBodyServiceHandlerClient(ctx context.Context, mux *runtime.
 
 		forward_ResponseBodyService_ListResponseBodies_0(ctx, mux, outboundMarshaler, w, req, response_ResponseBodyService_ListResponseBodies_0{resp}, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/ListResponseBodies")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ResponseBodyService_ListResponseStrings_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -408,7 +498,7 @@ func RegisterResponseBodyServiceHandlerClient(ctx context.Context, mux *runtime.
 
 		forward_ResponseBodyService_ListResponseStrings_0(ctx, mux, outboundMarshaler, w, req, response_ResponseBodyService_ListResponseStrings_0{resp}, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/ListResponseStrings")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_ResponseBodyService_GetResponseBodyStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -427,12 +517,14 @@ func RegisterResponseBodyServiceHandlerClient(ctx context.Context, mux *runtime.
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_ResponseBodyService_GetResponseBodyStream_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
 			res, err := resp.Recv()
 			return response_ResponseBodyService_GetResponseBodyStream_0{res}, err
 		}, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.ResponseBodyService/GetResponseBodyStream"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	return nil
 }
@@ -492,3 +584,75 @@ var (
 
 	forward_ResponseBodyService_GetResponseBodyStream_0 = runtime.ForwardResponseStream
 )
+
+// ResponseBodyServiceGetResponseBodyURL returns the path of the "GET /responsebody/{data}" binding of ResponseBodyService.GetResponseBody,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ResponseBodyServiceGetResponseBodyURL(protoReq *ResponseBodyIn) (string, error) {
+	return ResponseBodyServiceGetResponseBodyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ResponseBodyServiceGetResponseBodyURLWithMode is same as ResponseBodyServiceGetResponseBodyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ResponseBodyServiceGetResponseBodyURLWithMode(protoReq *ResponseBodyIn, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["data"], err = runtime.FormatFieldFromPath(protoReq, "data", ","); err != nil {
+		return "", err
+	}
+	return pattern_ResponseBodyService_GetResponseBody_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ResponseBodyServiceListResponseBodiesURL returns the path of the "GET /responsebodies/{data}" binding of ResponseBodyService.ListResponseBodies,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ResponseBodyServiceListResponseBodiesURL(protoReq *ResponseBodyIn) (string, error) {
+	return ResponseBodyServiceListResponseBodiesURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ResponseBodyServiceListResponseBodiesURLWithMode is same as ResponseBodyServiceListResponseBodiesURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ResponseBodyServiceListResponseBodiesURLWithMode(protoReq *ResponseBodyIn, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["data"], err = runtime.FormatFieldFromPath(protoReq, "data", ","); err != nil {
+		return "", err
+	}
+	return pattern_ResponseBodyService_ListResponseBodies_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ResponseBodyServiceListResponseStringsURL returns the path of the "GET /responsestrings/{data}" binding of ResponseBodyService.ListResponseStrings,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ResponseBodyServiceListResponseStringsURL(protoReq *ResponseBodyIn) (string, error) {
+	return ResponseBodyServiceListResponseStringsURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ResponseBodyServiceListResponseStringsURLWithMode is same as ResponseBodyServiceListResponseStringsURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ResponseBodyServiceListResponseStringsURLWithMode(protoReq *ResponseBodyIn, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["data"], err = runtime.FormatFieldFromPath(protoReq, "data", ","); err != nil {
+		return "", err
+	}
+	return pattern_ResponseBodyService_ListResponseStrings_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// ResponseBodyServiceGetResponseBodyStreamURL returns the path of the "GET /responsebody/stream/{data}" binding of ResponseBodyService.GetResponseBodyStream,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func ResponseBodyServiceGetResponseBodyStreamURL(protoReq *ResponseBodyIn) (string, error) {
+	return ResponseBodyServiceGetResponseBodyStreamURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// ResponseBodyServiceGetResponseBodyStreamURLWithMode is same as ResponseBodyServiceGetResponseBodyStreamURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func ResponseBodyServiceGetResponseBodyStreamURLWithMode(protoReq *ResponseBodyIn, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["data"], err = runtime.FormatFieldFromPath(protoReq, "data", ","); err != nil {
+		return "", err
+	}
+	return pattern_ResponseBodyService_GetResponseBodyStream_0.ExpandAndEscape(params, unescapingMode)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/examples/internal/proto/sub"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
@@ -77,6 +78,21 @@ func request_StreamService_BulkCreate_0(ctx context.Context, marshaler runtime.M
 
 }
 
+func local_request_StreamService_BulkCreate_0(ctx context.Context, marshaler runtime.Marshaler, server StreamServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	stream := runtime.NewInProcessStream(ctx, marshaler.NewDecoder(req.Body))
+	stream.Start(func() error {
+		return server.BulkCreate(&local_StreamService_BulkCreateServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+
+	defer stream.Close()
+	msg, err := stream.Response()
+	metadata.TrailerMD = stream.Trailer()
+	return msg, metadata, err
+
+}
+
 func request_StreamService_List_0(ctx context.Context, marshaler runtime.Marshaler, client StreamServiceClient, req *http.Request, pathParams map[string]string) (StreamService_ListClient, runtime.ServerMetadata, error) {
 	var protoReq emptypb.Empty
 	var metadata runtime.ServerMetadata
@@ -94,14 +110,31 @@ func request_StreamService_List_0(ctx context.Context, marshaler runtime.Marshal
 
 }
 
+func local_request_StreamService_List_0(ctx context.Context, marshaler runtime.Marshaler, server StreamServiceServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq emptypb.Empty
+	var metadata runtime.ServerMetadata
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.List(&protoReq, &local_StreamService_ListServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 func request_StreamService_BulkEcho_0(ctx context.Context, marshaler runtime.Marshaler, client StreamServiceClient, req *http.Request, pathParams map[string]string) (StreamService_BulkEchoClient, runtime.ServerMetadata, error) {
 	var metadata runtime.ServerMetadata
+	body, err := runtime.FullDuplexRequestBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
 	stream, err := client.BulkEcho(ctx)
 	if err != nil {
 		grpclog.Infof("Failed to start streaming: %v", err)
 		return nil, metadata, err
 	}
-	dec := marshaler.NewDecoder(req.Body)
+	dec := marshaler.NewDecoder(body)
 	handleSend := func() error {
 		var protoReq sub.StringMessage
 		err := dec.Decode(&protoReq)
@@ -118,7 +151,7 @@ func request_StreamService_BulkEcho_0(ctx context.Context, marshaler runtime.Mar
 		}
 		return nil
 	}
-	go func() {
+	sendAll := func() {
 		for {
 			if err := handleSend(); err != nil {
 				break
@@ -127,7 +160,8 @@ func request_StreamService_BulkEcho_0(ctx context.Context, marshaler runtime.Mar
 		if err := stream.CloseSend(); err != nil {
 			grpclog.Infof("Failed to terminate client stream: %v", err)
 		}
-	}()
+	}
+	go sendAll()
 	header, err := stream.Header()
 	if err != nil {
 		grpclog.Infof("Failed to get header from client: %v", err)
@@ -137,6 +171,22 @@ func request_StreamService_BulkEcho_0(ctx context.Context, marshaler runtime.Mar
 	return stream, metadata, nil
 }
 
+func local_request_StreamService_BulkEcho_0(ctx context.Context, marshaler runtime.Marshaler, server StreamServiceServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var metadata runtime.ServerMetadata
+	body, err := runtime.FullDuplexRequestBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
+	stream := runtime.NewInProcessStream(ctx, marshaler.NewDecoder(body))
+	stream.Start(func() error {
+		return server.BulkEcho(&local_StreamService_BulkEchoServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+
+	return stream, metadata, nil
+
+}
+
 func request_StreamService_Download_0(ctx context.Context, marshaler runtime.Marshaler, client StreamServiceClient, req *http.Request, pathParams map[string]string) (StreamService_DownloadClient, runtime.ServerMetadata, error) {
 	var protoReq emptypb.Empty
 	var metadata runtime.ServerMetadata
@@ -154,52 +204,209 @@ func request_StreamService_Download_0(ctx context.Context, marshaler runtime.Mar
 
 }
 
+func local_request_StreamService_Download_0(ctx context.Context, marshaler runtime.Marshaler, server StreamServiceServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {
+	var protoReq emptypb.Empty
+	var metadata runtime.ServerMetadata
+
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.Download(&protoReq, &local_StreamService_DownloadServer{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
+
+}
+
 // RegisterStreamServiceHandlerServer registers the http handlers for service StreamService to "mux".
 // UnaryRPC     :call StreamServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call StreamServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterStreamServiceHandlerFromEndpoint instead.
 func RegisterStreamServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server StreamServiceServer) error {
+	return RegisterStreamServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterStreamServiceHandlerServerWithOptions is same as RegisterStreamServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterStreamServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server StreamServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_StreamService_BulkCreate_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.StreamService/BulkCreate", runtime.WithHTTPPathPattern("/v1/example/a_bit_of_everything/bulk"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_StreamService_BulkCreate_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_StreamService_BulkCreate_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/BulkCreate"), runtime.WithClientStreaming()}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_StreamService_List_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.StreamService/List", runtime.WithHTTPPathPattern("/v1/example/a_bit_of_everything"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_StreamService_List_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_StreamService_List_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/List"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_StreamService_BulkEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.StreamService/BulkEcho", runtime.WithHTTPPathPattern("/v1/example/a_bit_of_everything/echo"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_StreamService_BulkEcho_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_StreamService_BulkEcho_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/BulkEcho"), runtime.WithClientStreaming(), runtime.WithServerStreaming(), runtime.WithFullDuplex()}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_StreamService_Download_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		var err error
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/grpc.gateway.examples.internal.proto.examplepb.StreamService/Download", runtime.WithHTTPPathPattern("/v1/example/download"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_StreamService_Download_0(ctx, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
+		forward_StreamService_Download_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/Download"), runtime.WithServerStreaming(), runtime.WithHTTPBodyResponse()}, handleOpts...)...)
 
 	return nil
 }
 
+// local_StreamService_BulkCreateServer implements StreamService_BulkCreateServer over a runtime.InProcessStream.
+type local_StreamService_BulkCreateServer struct {
+	grpc.ServerStream
+}
+
+func (x *local_StreamService_BulkCreateServer) SendAndClose(m *emptypb.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *local_StreamService_BulkCreateServer) Recv() (*ABitOfEverything, error) {
+	m := new(ABitOfEverything)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// local_StreamService_ListServer implements StreamService_ListServer over a runtime.InProcessStream.
+type local_StreamService_ListServer struct {
+	grpc.ServerStream
+}
+
+func (x *local_StreamService_ListServer) Send(m *ABitOfEverything) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// local_StreamService_BulkEchoServer implements StreamService_BulkEchoServer over a runtime.InProcessStream.
+type local_StreamService_BulkEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *local_StreamService_BulkEchoServer) Send(m *sub.StringMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *local_StreamService_BulkEchoServer) Recv() (*sub.StringMessage, error) {
+	m := new(sub.StringMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// local_StreamService_DownloadServer implements StreamService_DownloadServer over a runtime.InProcessStream.
+type local_StreamService_DownloadServer struct {
+	grpc.ServerStream
+}
+
+func (x *local_StreamService_DownloadServer) Send(m *httpbody.HttpBody) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // RegisterStreamServiceHandlerFromEndpoint is same as RegisterStreamServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterStreamServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterStreamServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterStreamServiceHandlerFromEndpointWithOptions is same as RegisterStreamServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterStreamServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -207,19 +414,26 @@ func RegisterStreamServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterStreamServiceHandler(ctx, mux, conn)
+	return RegisterStreamServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterStreamServiceHandler registers the http handlers for service StreamService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterStreamServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterStreamServiceHandlerClient(ctx, mux, NewStreamServiceClient(conn))
+	return RegisterStreamServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterStreamServiceHandlerWithOptions is same as RegisterStreamServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterStreamServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterStreamServiceHandlerClientWithOptions(ctx, mux, NewStreamServiceClient(conn), handleOpts...)
 }
 
 // RegisterStreamServiceHandlerClient registers the http handlers for service StreamService
@@ -228,6 +442,12 @@ func RegisterStreamServiceHandler(ctx context.Context, mux *runtime.ServeMux, co
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "StreamServiceClient" to call the correct interceptors.
 func RegisterStreamServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client StreamServiceClient) error {
+	return RegisterStreamServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterStreamServiceHandlerClientWithOptions is same as RegisterStreamServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterStreamServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client StreamServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_StreamService_BulkCreate_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -248,7 +468,7 @@ func RegisterStreamServiceHandlerClient(ctx context.Context, mux *runtime.ServeM
 
 		forward_StreamService_BulkCreate_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/BulkCreate"), runtime.WithClientStreaming()}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_StreamService_List_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -267,9 +487,11 @@ func RegisterStreamServiceHandlerClient(ctx context.Context, mux *runtime.ServeM
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_StreamService_List_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/List"), runtime.WithServerStreaming()}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_StreamService_BulkEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -288,9 +510,11 @@ func RegisterStreamServiceHandlerClient(ctx context.Context, mux *runtime.ServeM
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_StreamService_BulkEcho_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/BulkEcho"), runtime.WithClientStreaming(), runtime.WithServerStreaming(), runtime.WithFullDuplex()}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_StreamService_Download_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -309,9 +533,11 @@ func RegisterStreamServiceHandlerClient(ctx context.Context, mux *runtime.ServeM
 			return
 		}
 
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+
 		forward_StreamService_Download_0(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.StreamService/Download"), runtime.WithServerStreaming(), runtime.WithHTTPBodyResponse()}, handleOpts...)...)
 
 	return nil
 }
@@ -335,3 +561,55 @@ var (
 
 	forward_StreamService_Download_0 = runtime.ForwardResponseStream
 )
+
+// StreamServiceBulkCreateURL returns the path of the "POST /v1/example/a_bit_of_everything/bulk" binding of StreamService.BulkCreate,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func StreamServiceBulkCreateURL(protoReq *ABitOfEverything) (string, error) {
+	return StreamServiceBulkCreateURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// StreamServiceBulkCreateURLWithMode is same as StreamServiceBulkCreateURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func StreamServiceBulkCreateURLWithMode(protoReq *ABitOfEverything, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_StreamService_BulkCreate_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// StreamServiceListURL returns the path of the "GET /v1/example/a_bit_of_everything" binding of StreamService.List,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func StreamServiceListURL(protoReq *emptypb.Empty) (string, error) {
+	return StreamServiceListURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// StreamServiceListURLWithMode is same as StreamServiceListURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func StreamServiceListURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_StreamService_List_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// StreamServiceBulkEchoURL returns the path of the "POST /v1/example/a_bit_of_everything/echo" binding of StreamService.BulkEcho,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func StreamServiceBulkEchoURL(protoReq *sub.StringMessage) (string, error) {
+	return StreamServiceBulkEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// StreamServiceBulkEchoURLWithMode is same as StreamServiceBulkEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func StreamServiceBulkEchoURLWithMode(protoReq *sub.StringMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_StreamService_BulkEcho_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// StreamServiceDownloadURL returns the path of the "GET /v1/example/download" binding of StreamService.Download,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func StreamServiceDownloadURL(protoReq *emptypb.Empty) (string, error) {
+	return StreamServiceDownloadURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// StreamServiceDownloadURLWithMode is same as StreamServiceDownloadURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func StreamServiceDownloadURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_StreamService_Download_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -101,9 +101,15 @@ func local_request_LoginService_Logout_0(ctx context.Context, marshaler runtime.
 
 // RegisterLoginServiceHandlerServer registers the http handlers for service LoginService to "mux".
 // UnaryRPC     :call LoginServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call LoginServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterLoginServiceHandlerFromEndpoint instead.
 func RegisterLoginServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server LoginServiceServer) error {
+	return RegisterLoginServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterLoginServiceHandlerServerWithOptions is same as RegisterLoginServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterLoginServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server LoginServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_LoginService_Login_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -127,7 +133,7 @@ func RegisterLoginServiceHandlerServer(ctx context.Context, mux *runtime.ServeMu
 
 		forward_LoginService_Login_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.LoginService/Login")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_LoginService_Logout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -151,20 +157,29 @@ func RegisterLoginServiceHandlerServer(ctx context.Context, mux *runtime.ServeMu
 
 		forward_LoginService_Logout_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.LoginService/Logout")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterLoginServiceHandlerFromEndpoint is same as RegisterLoginServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterLoginServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterLoginServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterLoginServiceHandlerFromEndpointWithOptions is same as RegisterLoginServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterLoginServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -172,19 +187,26 @@ func RegisterLoginServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.S
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterLoginServiceHandler(ctx, mux, conn)
+	return RegisterLoginServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterLoginServiceHandler registers the http handlers for service LoginService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterLoginServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterLoginServiceHandlerClient(ctx, mux, NewLoginServiceClient(conn))
+	return RegisterLoginServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterLoginServiceHandlerWithOptions is same as RegisterLoginServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterLoginServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterLoginServiceHandlerClientWithOptions(ctx, mux, NewLoginServiceClient(conn), handleOpts...)
 }
 
 // RegisterLoginServiceHandlerClient registers the http handlers for service LoginService
@@ -193,6 +215,12 @@ func RegisterLoginServiceHandler(ctx context.Context, mux *runtime.ServeMux, con
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "LoginServiceClient" to call the correct interceptors.
 func RegisterLoginServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client LoginServiceClient) error {
+	return RegisterLoginServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterLoginServiceHandlerClientWithOptions is same as RegisterLoginServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterLoginServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client LoginServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_LoginService_Login_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -213,7 +241,7 @@ func RegisterLoginServiceHandlerClient(ctx context.Context, mux *runtime.ServeMu
 
 		forward_LoginService_Login_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.LoginService/Login")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_LoginService_Logout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -234,7 +262,7 @@ func RegisterLoginServiceHandlerClient(ctx context.Context, mux *runtime.ServeMu
 
 		forward_LoginService_Logout_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.LoginService/Logout")}, handleOpts...)...)
 
 	return nil
 }
@@ -250,3 +278,29 @@ var (
 
 	forward_LoginService_Logout_0 = runtime.ForwardResponseMessage
 )
+
+// LoginServiceLoginURL returns the path of the "POST /v1/example/login" binding of LoginService.Login,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func LoginServiceLoginURL(protoReq *LoginRequest) (string, error) {
+	return LoginServiceLoginURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// LoginServiceLoginURLWithMode is same as LoginServiceLoginURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func LoginServiceLoginURLWithMode(protoReq *LoginRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_LoginService_Login_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// LoginServiceLogoutURL returns the path of the "POST /v1/example/logout" binding of LoginService.Logout,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func LoginServiceLogoutURL(protoReq *LogoutRequest) (string, error) {
+	return LoginServiceLogoutURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// LoginServiceLogoutURLWithMode is same as LoginServiceLogoutURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func LoginServiceLogoutURLWithMode(protoReq *LogoutRequest, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_LoginService_Logout_0.ExpandAndEscape(nil, unescapingMode)
+}
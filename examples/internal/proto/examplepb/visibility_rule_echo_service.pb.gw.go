@@ -281,9 +281,15 @@ func local_request_VisibilityRuleInternalEchoService_Echo_0(ctx context.Context,
 
 // RegisterVisibilityRuleEchoServiceHandlerServer registers the http handlers for service VisibilityRuleEchoService to "mux".
 // UnaryRPC     :call VisibilityRuleEchoServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call VisibilityRuleEchoServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterVisibilityRuleEchoServiceHandlerFromEndpoint instead.
 func RegisterVisibilityRuleEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server VisibilityRuleEchoServiceServer) error {
+	return RegisterVisibilityRuleEchoServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterVisibilityRuleEchoServiceHandlerServerWithOptions is same as RegisterVisibilityRuleEchoServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleEchoServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server VisibilityRuleEchoServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_VisibilityRuleEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -307,7 +313,7 @@ func RegisterVisibilityRuleEchoServiceHandlerServer(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_VisibilityRuleEchoService_EchoInternal_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -331,7 +337,7 @@ func RegisterVisibilityRuleEchoServiceHandlerServer(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_EchoInternal_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/EchoInternal")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_VisibilityRuleEchoService_EchoPreview_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -355,7 +361,7 @@ func RegisterVisibilityRuleEchoServiceHandlerServer(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_EchoPreview_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/EchoPreview")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_VisibilityRuleEchoService_EchoInternalAndPreview_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -379,16 +385,22 @@ func RegisterVisibilityRuleEchoServiceHandlerServer(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_EchoInternalAndPreview_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/EchoInternalAndPreview")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterVisibilityRuleInternalEchoServiceHandlerServer registers the http handlers for service VisibilityRuleInternalEchoService to "mux".
 // UnaryRPC     :call VisibilityRuleInternalEchoServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call VisibilityRuleInternalEchoServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpoint instead.
 func RegisterVisibilityRuleInternalEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server VisibilityRuleInternalEchoServiceServer) error {
+	return RegisterVisibilityRuleInternalEchoServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterVisibilityRuleInternalEchoServiceHandlerServerWithOptions is same as RegisterVisibilityRuleInternalEchoServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleInternalEchoServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server VisibilityRuleInternalEchoServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_VisibilityRuleInternalEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -412,20 +424,29 @@ func RegisterVisibilityRuleInternalEchoServiceHandlerServer(ctx context.Context,
 
 		forward_VisibilityRuleInternalEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleInternalEchoService/Echo")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterVisibilityRuleEchoServiceHandlerFromEndpoint is same as RegisterVisibilityRuleEchoServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterVisibilityRuleEchoServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterVisibilityRuleEchoServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterVisibilityRuleEchoServiceHandlerFromEndpointWithOptions is same as RegisterVisibilityRuleEchoServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleEchoServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -433,19 +454,26 @@ func RegisterVisibilityRuleEchoServiceHandlerFromEndpoint(ctx context.Context, m
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterVisibilityRuleEchoServiceHandler(ctx, mux, conn)
+	return RegisterVisibilityRuleEchoServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterVisibilityRuleEchoServiceHandler registers the http handlers for service VisibilityRuleEchoService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterVisibilityRuleEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterVisibilityRuleEchoServiceHandlerClient(ctx, mux, NewVisibilityRuleEchoServiceClient(conn))
+	return RegisterVisibilityRuleEchoServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterVisibilityRuleEchoServiceHandlerWithOptions is same as RegisterVisibilityRuleEchoServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleEchoServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterVisibilityRuleEchoServiceHandlerClientWithOptions(ctx, mux, NewVisibilityRuleEchoServiceClient(conn), handleOpts...)
 }
 
 // RegisterVisibilityRuleEchoServiceHandlerClient registers the http handlers for service VisibilityRuleEchoService
@@ -454,6 +482,12 @@ func RegisterVisibilityRuleEchoServiceHandler(ctx context.Context, mux *runtime.
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "VisibilityRuleEchoServiceClient" to call the correct interceptors.
 func RegisterVisibilityRuleEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client VisibilityRuleEchoServiceClient) error {
+	return RegisterVisibilityRuleEchoServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterVisibilityRuleEchoServiceHandlerClientWithOptions is same as RegisterVisibilityRuleEchoServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleEchoServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client VisibilityRuleEchoServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_VisibilityRuleEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -474,7 +508,7 @@ func RegisterVisibilityRuleEchoServiceHandlerClient(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_VisibilityRuleEchoService_EchoInternal_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -495,7 +529,7 @@ func RegisterVisibilityRuleEchoServiceHandlerClient(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_EchoInternal_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/EchoInternal")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_VisibilityRuleEchoService_EchoPreview_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -516,7 +550,7 @@ func RegisterVisibilityRuleEchoServiceHandlerClient(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_EchoPreview_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/EchoPreview")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_VisibilityRuleEchoService_EchoInternalAndPreview_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -537,7 +571,7 @@ func RegisterVisibilityRuleEchoServiceHandlerClient(ctx context.Context, mux *ru
 
 		forward_VisibilityRuleEchoService_EchoInternalAndPreview_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleEchoService/EchoInternalAndPreview")}, handleOpts...)...)
 
 	return nil
 }
@@ -562,15 +596,81 @@ var (
 	forward_VisibilityRuleEchoService_EchoInternalAndPreview_0 = runtime.ForwardResponseMessage
 )
 
+// VisibilityRuleEchoServiceEchoURL returns the path of the "POST /v1/example/echo/{id}" binding of VisibilityRuleEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func VisibilityRuleEchoServiceEchoURL(protoReq *VisibilityRuleSimpleMessage) (string, error) {
+	return VisibilityRuleEchoServiceEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// VisibilityRuleEchoServiceEchoURLWithMode is same as VisibilityRuleEchoServiceEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func VisibilityRuleEchoServiceEchoURLWithMode(protoReq *VisibilityRuleSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	return pattern_VisibilityRuleEchoService_Echo_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// VisibilityRuleEchoServiceEchoInternalURL returns the path of the "GET /v1/example/echo_internal" binding of VisibilityRuleEchoService.EchoInternal,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func VisibilityRuleEchoServiceEchoInternalURL(protoReq *VisibilityRuleSimpleMessage) (string, error) {
+	return VisibilityRuleEchoServiceEchoInternalURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// VisibilityRuleEchoServiceEchoInternalURLWithMode is same as VisibilityRuleEchoServiceEchoInternalURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func VisibilityRuleEchoServiceEchoInternalURLWithMode(protoReq *VisibilityRuleSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_VisibilityRuleEchoService_EchoInternal_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// VisibilityRuleEchoServiceEchoPreviewURL returns the path of the "GET /v1/example/echo_preview" binding of VisibilityRuleEchoService.EchoPreview,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func VisibilityRuleEchoServiceEchoPreviewURL(protoReq *VisibilityRuleSimpleMessage) (string, error) {
+	return VisibilityRuleEchoServiceEchoPreviewURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// VisibilityRuleEchoServiceEchoPreviewURLWithMode is same as VisibilityRuleEchoServiceEchoPreviewURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func VisibilityRuleEchoServiceEchoPreviewURLWithMode(protoReq *VisibilityRuleSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_VisibilityRuleEchoService_EchoPreview_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// VisibilityRuleEchoServiceEchoInternalAndPreviewURL returns the path of the "GET /v1/example/echo_internal_and_preview" binding of VisibilityRuleEchoService.EchoInternalAndPreview,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func VisibilityRuleEchoServiceEchoInternalAndPreviewURL(protoReq *VisibilityRuleSimpleMessage) (string, error) {
+	return VisibilityRuleEchoServiceEchoInternalAndPreviewURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// VisibilityRuleEchoServiceEchoInternalAndPreviewURLWithMode is same as VisibilityRuleEchoServiceEchoInternalAndPreviewURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func VisibilityRuleEchoServiceEchoInternalAndPreviewURLWithMode(protoReq *VisibilityRuleSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_VisibilityRuleEchoService_EchoInternalAndPreview_0.ExpandAndEscape(nil, unescapingMode)
+}
+
 // RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpoint is same as RegisterVisibilityRuleInternalEchoServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpointWithOptions is same as RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -578,19 +678,26 @@ func RegisterVisibilityRuleInternalEchoServiceHandlerFromEndpoint(ctx context.Co
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterVisibilityRuleInternalEchoServiceHandler(ctx, mux, conn)
+	return RegisterVisibilityRuleInternalEchoServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterVisibilityRuleInternalEchoServiceHandler registers the http handlers for service VisibilityRuleInternalEchoService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterVisibilityRuleInternalEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterVisibilityRuleInternalEchoServiceHandlerClient(ctx, mux, NewVisibilityRuleInternalEchoServiceClient(conn))
+	return RegisterVisibilityRuleInternalEchoServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterVisibilityRuleInternalEchoServiceHandlerWithOptions is same as RegisterVisibilityRuleInternalEchoServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleInternalEchoServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterVisibilityRuleInternalEchoServiceHandlerClientWithOptions(ctx, mux, NewVisibilityRuleInternalEchoServiceClient(conn), handleOpts...)
 }
 
 // RegisterVisibilityRuleInternalEchoServiceHandlerClient registers the http handlers for service VisibilityRuleInternalEchoService
@@ -599,6 +706,12 @@ func RegisterVisibilityRuleInternalEchoServiceHandler(ctx context.Context, mux *
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "VisibilityRuleInternalEchoServiceClient" to call the correct interceptors.
 func RegisterVisibilityRuleInternalEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client VisibilityRuleInternalEchoServiceClient) error {
+	return RegisterVisibilityRuleInternalEchoServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterVisibilityRuleInternalEchoServiceHandlerClientWithOptions is same as RegisterVisibilityRuleInternalEchoServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterVisibilityRuleInternalEchoServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client VisibilityRuleInternalEchoServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_VisibilityRuleInternalEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -619,7 +732,7 @@ func RegisterVisibilityRuleInternalEchoServiceHandlerClient(ctx context.Context,
 
 		forward_VisibilityRuleInternalEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.VisibilityRuleInternalEchoService/Echo")}, handleOpts...)...)
 
 	return nil
 }
@@ -631,3 +744,21 @@ var (
 var (
 	forward_VisibilityRuleInternalEchoService_Echo_0 = runtime.ForwardResponseMessage
 )
+
+// VisibilityRuleInternalEchoServiceEchoURL returns the path of the "POST /v1/example/internal/echo/{id}" binding of VisibilityRuleInternalEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func VisibilityRuleInternalEchoServiceEchoURL(protoReq *VisibilityRuleSimpleMessage) (string, error) {
+	return VisibilityRuleInternalEchoServiceEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// VisibilityRuleInternalEchoServiceEchoURLWithMode is same as VisibilityRuleInternalEchoServiceEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func VisibilityRuleInternalEchoServiceEchoURLWithMode(protoReq *VisibilityRuleSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	return pattern_VisibilityRuleInternalEchoService_Echo_0.ExpandAndEscape(params, unescapingMode)
+}
@@ -409,9 +409,15 @@ func local_request_WrappersService_CreateEmpty_0(ctx context.Context, marshaler
 
 // RegisterWrappersServiceHandlerServer registers the http handlers for service WrappersService to "mux".
 // UnaryRPC     :call WrappersServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call WrappersServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterWrappersServiceHandlerFromEndpoint instead.
 func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server WrappersServiceServer) error {
+	return RegisterWrappersServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterWrappersServiceHandlerServerWithOptions is same as RegisterWrappersServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterWrappersServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server WrappersServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_WrappersService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -435,7 +441,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_Create_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/Create")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateStringValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -459,7 +465,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateStringValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateStringValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -483,7 +489,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateInt32Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt32Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -507,7 +513,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateInt64Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt64Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateFloatValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -531,7 +537,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateFloatValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateFloatValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateDoubleValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -555,7 +561,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateDoubleValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateDoubleValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateBoolValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -579,7 +585,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateBoolValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBoolValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateUInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -603,7 +609,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateUInt32Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt32Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateUInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -627,7 +633,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateUInt64Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt64Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateBytesValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -651,7 +657,7 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateBytesValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBytesValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateEmpty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -675,20 +681,29 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateEmpty_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateEmpty")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterWrappersServiceHandlerFromEndpoint is same as RegisterWrappersServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterWrappersServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterWrappersServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterWrappersServiceHandlerFromEndpointWithOptions is same as RegisterWrappersServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterWrappersServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -696,19 +711,26 @@ func RegisterWrappersServiceHandlerFromEndpoint(ctx context.Context, mux *runtim
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterWrappersServiceHandler(ctx, mux, conn)
+	return RegisterWrappersServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterWrappersServiceHandler registers the http handlers for service WrappersService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterWrappersServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterWrappersServiceHandlerClient(ctx, mux, NewWrappersServiceClient(conn))
+	return RegisterWrappersServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterWrappersServiceHandlerWithOptions is same as RegisterWrappersServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterWrappersServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterWrappersServiceHandlerClientWithOptions(ctx, mux, NewWrappersServiceClient(conn), handleOpts...)
 }
 
 // RegisterWrappersServiceHandlerClient registers the http handlers for service WrappersService
@@ -717,6 +739,12 @@ func RegisterWrappersServiceHandler(ctx context.Context, mux *runtime.ServeMux,
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "WrappersServiceClient" to call the correct interceptors.
 func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client WrappersServiceClient) error {
+	return RegisterWrappersServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterWrappersServiceHandlerClientWithOptions is same as RegisterWrappersServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterWrappersServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client WrappersServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_WrappersService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -737,7 +765,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_Create_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/Create")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateStringValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -758,7 +786,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateStringValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateStringValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -779,7 +807,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateInt32Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt32Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -800,7 +828,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateInt64Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt64Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateFloatValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -821,7 +849,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateFloatValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateFloatValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateDoubleValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -842,7 +870,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateDoubleValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateDoubleValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateBoolValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -863,7 +891,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateBoolValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBoolValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateUInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -884,7 +912,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateUInt32Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt32Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateUInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -905,7 +933,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateUInt64Value_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt64Value")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateBytesValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -926,7 +954,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateBytesValue_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBytesValue")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_WrappersService_CreateEmpty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -947,7 +975,7 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 
 		forward_WrappersService_CreateEmpty_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateEmpty")}, handleOpts...)...)
 
 	return nil
 }
@@ -999,3 +1027,146 @@ var (
 
 	forward_WrappersService_CreateEmpty_0 = runtime.ForwardResponseMessage
 )
+
+// WrappersServiceCreateURL returns the path of the "POST /v1/example/wrappers" binding of WrappersService.Create,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateURL(protoReq *Wrappers) (string, error) {
+	return WrappersServiceCreateURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateURLWithMode is same as WrappersServiceCreateURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateURLWithMode(protoReq *Wrappers, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_Create_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateStringValueURL returns the path of the "POST /v1/testString" binding of WrappersService.CreateStringValue,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateStringValueURL(protoReq *wrapperspb.StringValue) (string, error) {
+	return WrappersServiceCreateStringValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateStringValueURLWithMode is same as WrappersServiceCreateStringValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateStringValueURLWithMode(protoReq *wrapperspb.StringValue, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateStringValue_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateInt32ValueURL returns the path of the "POST /v1/testInt32" binding of WrappersService.CreateInt32Value,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateInt32ValueURL(protoReq *wrapperspb.Int32Value) (string, error) {
+	return WrappersServiceCreateInt32ValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateInt32ValueURLWithMode is same as WrappersServiceCreateInt32ValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateInt32ValueURLWithMode(protoReq *wrapperspb.Int32Value, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateInt32Value_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateInt64ValueURL returns the path of the "POST /v1/testInt64" binding of WrappersService.CreateInt64Value,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateInt64ValueURL(protoReq *wrapperspb.Int64Value) (string, error) {
+	return WrappersServiceCreateInt64ValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateInt64ValueURLWithMode is same as WrappersServiceCreateInt64ValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateInt64ValueURLWithMode(protoReq *wrapperspb.Int64Value, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateInt64Value_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateFloatValueURL returns the path of the "POST /v1/testFloat" binding of WrappersService.CreateFloatValue,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateFloatValueURL(protoReq *wrapperspb.FloatValue) (string, error) {
+	return WrappersServiceCreateFloatValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateFloatValueURLWithMode is same as WrappersServiceCreateFloatValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateFloatValueURLWithMode(protoReq *wrapperspb.FloatValue, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateFloatValue_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateDoubleValueURL returns the path of the "POST /v1/testDouble" binding of WrappersService.CreateDoubleValue,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateDoubleValueURL(protoReq *wrapperspb.DoubleValue) (string, error) {
+	return WrappersServiceCreateDoubleValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateDoubleValueURLWithMode is same as WrappersServiceCreateDoubleValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateDoubleValueURLWithMode(protoReq *wrapperspb.DoubleValue, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateDoubleValue_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateBoolValueURL returns the path of the "POST /v1/testBool" binding of WrappersService.CreateBoolValue,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateBoolValueURL(protoReq *wrapperspb.BoolValue) (string, error) {
+	return WrappersServiceCreateBoolValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateBoolValueURLWithMode is same as WrappersServiceCreateBoolValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateBoolValueURLWithMode(protoReq *wrapperspb.BoolValue, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateBoolValue_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateUInt32ValueURL returns the path of the "POST /v1/testUint32" binding of WrappersService.CreateUInt32Value,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateUInt32ValueURL(protoReq *wrapperspb.UInt32Value) (string, error) {
+	return WrappersServiceCreateUInt32ValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateUInt32ValueURLWithMode is same as WrappersServiceCreateUInt32ValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateUInt32ValueURLWithMode(protoReq *wrapperspb.UInt32Value, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateUInt32Value_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateUInt64ValueURL returns the path of the "POST /v1/testUint64" binding of WrappersService.CreateUInt64Value,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateUInt64ValueURL(protoReq *wrapperspb.UInt64Value) (string, error) {
+	return WrappersServiceCreateUInt64ValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateUInt64ValueURLWithMode is same as WrappersServiceCreateUInt64ValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateUInt64ValueURLWithMode(protoReq *wrapperspb.UInt64Value, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateUInt64Value_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateBytesValueURL returns the path of the "POST /v1/testBytes" binding of WrappersService.CreateBytesValue,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateBytesValueURL(protoReq *wrapperspb.BytesValue) (string, error) {
+	return WrappersServiceCreateBytesValueURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateBytesValueURLWithMode is same as WrappersServiceCreateBytesValueURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateBytesValueURLWithMode(protoReq *wrapperspb.BytesValue, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateBytesValue_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// WrappersServiceCreateEmptyURL returns the path of the "POST /v1/testEmpty" binding of WrappersService.CreateEmpty,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func WrappersServiceCreateEmptyURL(protoReq *emptypb.Empty) (string, error) {
+	return WrappersServiceCreateEmptyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// WrappersServiceCreateEmptyURLWithMode is same as WrappersServiceCreateEmptyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func WrappersServiceCreateEmptyURLWithMode(protoReq *emptypb.Empty, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_WrappersService_CreateEmpty_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -574,9 +574,15 @@ func local_request_UnannotatedEchoService_EchoDelete_0(ctx context.Context, mars
 
 // RegisterUnannotatedEchoServiceHandlerServer registers the http handlers for service UnannotatedEchoService to "mux".
 // UnaryRPC     :call UnannotatedEchoServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call UnannotatedEchoServiceServer directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterUnannotatedEchoServiceHandlerFromEndpoint instead.
 func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server extExamplepb.UnannotatedEchoServiceServer) error {
+	return RegisterUnannotatedEchoServiceHandlerServerWithOptions(ctx, mux, server)
+}
+
+// RegisterUnannotatedEchoServiceHandlerServerWithOptions is same as RegisterUnannotatedEchoServiceHandlerServer but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterUnannotatedEchoServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server extExamplepb.UnannotatedEchoServiceServer, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_UnannotatedEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -600,7 +606,7 @@ func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -624,7 +630,7 @@ func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -648,7 +654,7 @@ func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -672,7 +678,7 @@ func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -696,7 +702,7 @@ func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_UnannotatedEchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -720,7 +726,7 @@ func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_EchoBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/EchoBody")}, handleOpts...)...)
 
 	mux.Handle("DELETE", pattern_UnannotatedEchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -744,20 +750,29 @@ func RegisterUnannotatedEchoServiceHandlerServer(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_EchoDelete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/EchoDelete")}, handleOpts...)...)
 
 	return nil
 }
 
 // RegisterUnannotatedEchoServiceHandlerFromEndpoint is same as RegisterUnannotatedEchoServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func RegisterUnannotatedEchoServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return RegisterUnannotatedEchoServiceHandlerFromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// RegisterUnannotatedEchoServiceHandlerFromEndpointWithOptions is same as RegisterUnannotatedEchoServiceHandlerFromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterUnannotatedEchoServiceHandlerFromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -765,19 +780,26 @@ func RegisterUnannotatedEchoServiceHandlerFromEndpoint(ctx context.Context, mux
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return RegisterUnannotatedEchoServiceHandler(ctx, mux, conn)
+	return RegisterUnannotatedEchoServiceHandlerWithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // RegisterUnannotatedEchoServiceHandler registers the http handlers for service UnannotatedEchoService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func RegisterUnannotatedEchoServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterUnannotatedEchoServiceHandlerClient(ctx, mux, extExamplepb.NewUnannotatedEchoServiceClient(conn))
+	return RegisterUnannotatedEchoServiceHandlerWithOptions(ctx, mux, conn)
+}
+
+// RegisterUnannotatedEchoServiceHandlerWithOptions is same as RegisterUnannotatedEchoServiceHandler but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterUnannotatedEchoServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return RegisterUnannotatedEchoServiceHandlerClientWithOptions(ctx, mux, extExamplepb.NewUnannotatedEchoServiceClient(conn), handleOpts...)
 }
 
 // RegisterUnannotatedEchoServiceHandlerClient registers the http handlers for service UnannotatedEchoService
@@ -786,6 +808,12 @@ func RegisterUnannotatedEchoServiceHandler(ctx context.Context, mux *runtime.Ser
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "extExamplepb.UnannotatedEchoServiceClient" to call the correct interceptors.
 func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client extExamplepb.UnannotatedEchoServiceClient) error {
+	return RegisterUnannotatedEchoServiceHandlerClientWithOptions(ctx, mux, client)
+}
+
+// RegisterUnannotatedEchoServiceHandlerClientWithOptions is same as RegisterUnannotatedEchoServiceHandlerClient but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func RegisterUnannotatedEchoServiceHandlerClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client extExamplepb.UnannotatedEchoServiceClient, handleOpts ...runtime.HandleOption) error {
 
 	mux.Handle("POST", pattern_UnannotatedEchoService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -806,7 +834,7 @@ func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -827,7 +855,7 @@ func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_1(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -848,7 +876,7 @@ func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_2(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_3, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -869,7 +897,7 @@ func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_3(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("GET", pattern_UnannotatedEchoService_Echo_4, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -890,7 +918,7 @@ func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_Echo_4(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/Echo")}, handleOpts...)...)
 
 	mux.Handle("POST", pattern_UnannotatedEchoService_EchoBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -911,7 +939,7 @@ func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_EchoBody_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/EchoBody")}, handleOpts...)...)
 
 	mux.Handle("DELETE", pattern_UnannotatedEchoService_EchoDelete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
@@ -932,7 +960,7 @@ func RegisterUnannotatedEchoServiceHandlerClient(ctx context.Context, mux *runti
 
 		forward_UnannotatedEchoService_EchoDelete_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/grpc.gateway.examples.internal.proto.examplepb.UnannotatedEchoService/EchoDelete")}, handleOpts...)...)
 
 	return nil
 }
@@ -968,3 +996,134 @@ var (
 
 	forward_UnannotatedEchoService_EchoDelete_0 = runtime.ForwardResponseMessage
 )
+
+// UnannotatedEchoServiceEchoURL returns the path of the "POST /v2/example/echo/{id}" binding of UnannotatedEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func UnannotatedEchoServiceEchoURL(protoReq *extExamplepb.UnannotatedSimpleMessage) (string, error) {
+	return UnannotatedEchoServiceEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// UnannotatedEchoServiceEchoURLWithMode is same as UnannotatedEchoServiceEchoURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func UnannotatedEchoServiceEchoURLWithMode(protoReq *extExamplepb.UnannotatedSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	return pattern_UnannotatedEchoService_Echo_0.ExpandAndEscape(params, unescapingMode)
+}
+
+// UnannotatedEchoServiceEchoURL1 returns the path of the "GET /v2/example/echo/{id}/{num}" binding of UnannotatedEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func UnannotatedEchoServiceEchoURL1(protoReq *extExamplepb.UnannotatedSimpleMessage) (string, error) {
+	return UnannotatedEchoServiceEchoURL1WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// UnannotatedEchoServiceEchoURL1WithMode is same as UnannotatedEchoServiceEchoURL1 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func UnannotatedEchoServiceEchoURL1WithMode(protoReq *extExamplepb.UnannotatedSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	if params["num"], err = runtime.FormatFieldFromPath(protoReq, "num", ","); err != nil {
+		return "", err
+	}
+	return pattern_UnannotatedEchoService_Echo_1.ExpandAndEscape(params, unescapingMode)
+}
+
+// UnannotatedEchoServiceEchoURL2 returns the path of the "GET /v2/example/echo/{id}/{num}/{lang}" binding of UnannotatedEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func UnannotatedEchoServiceEchoURL2(protoReq *extExamplepb.UnannotatedSimpleMessage) (string, error) {
+	return UnannotatedEchoServiceEchoURL2WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// UnannotatedEchoServiceEchoURL2WithMode is same as UnannotatedEchoServiceEchoURL2 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func UnannotatedEchoServiceEchoURL2WithMode(protoReq *extExamplepb.UnannotatedSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	if params["num"], err = runtime.FormatFieldFromPath(protoReq, "num", ","); err != nil {
+		return "", err
+	}
+	if params["lang"], err = runtime.FormatFieldFromPath(protoReq, "lang", ","); err != nil {
+		return "", err
+	}
+	return pattern_UnannotatedEchoService_Echo_2.ExpandAndEscape(params, unescapingMode)
+}
+
+// UnannotatedEchoServiceEchoURL3 returns the path of the "GET /v2/example/echo1/{id}/{line_num}/{status.note}" binding of UnannotatedEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func UnannotatedEchoServiceEchoURL3(protoReq *extExamplepb.UnannotatedSimpleMessage) (string, error) {
+	return UnannotatedEchoServiceEchoURL3WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// UnannotatedEchoServiceEchoURL3WithMode is same as UnannotatedEchoServiceEchoURL3 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func UnannotatedEchoServiceEchoURL3WithMode(protoReq *extExamplepb.UnannotatedSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["id"], err = runtime.FormatFieldFromPath(protoReq, "id", ","); err != nil {
+		return "", err
+	}
+	if params["line_num"], err = runtime.FormatFieldFromPath(protoReq, "line_num", ","); err != nil {
+		return "", err
+	}
+	if params["status.note"], err = runtime.FormatFieldFromPath(protoReq, "status.note", ","); err != nil {
+		return "", err
+	}
+	return pattern_UnannotatedEchoService_Echo_3.ExpandAndEscape(params, unescapingMode)
+}
+
+// UnannotatedEchoServiceEchoURL4 returns the path of the "GET /v2/example/echo2/{no.note}" binding of UnannotatedEchoService.Echo,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func UnannotatedEchoServiceEchoURL4(protoReq *extExamplepb.UnannotatedSimpleMessage) (string, error) {
+	return UnannotatedEchoServiceEchoURL4WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// UnannotatedEchoServiceEchoURL4WithMode is same as UnannotatedEchoServiceEchoURL4 but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func UnannotatedEchoServiceEchoURL4WithMode(protoReq *extExamplepb.UnannotatedSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	params := make(map[string]string)
+	var err error
+	if params["no.note"], err = runtime.FormatFieldFromPath(protoReq, "no.note", ","); err != nil {
+		return "", err
+	}
+	return pattern_UnannotatedEchoService_Echo_4.ExpandAndEscape(params, unescapingMode)
+}
+
+// UnannotatedEchoServiceEchoBodyURL returns the path of the "POST /v2/example/echo_body" binding of UnannotatedEchoService.EchoBody,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func UnannotatedEchoServiceEchoBodyURL(protoReq *extExamplepb.UnannotatedSimpleMessage) (string, error) {
+	return UnannotatedEchoServiceEchoBodyURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// UnannotatedEchoServiceEchoBodyURLWithMode is same as UnannotatedEchoServiceEchoBodyURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func UnannotatedEchoServiceEchoBodyURLWithMode(protoReq *extExamplepb.UnannotatedSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_UnannotatedEchoService_EchoBody_0.ExpandAndEscape(nil, unescapingMode)
+}
+
+// UnannotatedEchoServiceEchoDeleteURL returns the path of the "DELETE /v2/example/echo_delete" binding of UnannotatedEchoService.EchoDelete,
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func UnannotatedEchoServiceEchoDeleteURL(protoReq *extExamplepb.UnannotatedSimpleMessage) (string, error) {
+	return UnannotatedEchoServiceEchoDeleteURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// UnannotatedEchoServiceEchoDeleteURLWithMode is same as UnannotatedEchoServiceEchoDeleteURL but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func UnannotatedEchoServiceEchoDeleteURLWithMode(protoReq *extExamplepb.UnannotatedSimpleMessage, unescapingMode runtime.UnescapingMode) (string, error) {
+	return pattern_UnannotatedEchoService_EchoDelete_0.ExpandAndEscape(nil, unescapingMode)
+}
@@ -94,6 +94,10 @@ type Registry struct {
 	// operationIDs. This risks generating duplicate operationIDs.
 	simpleOperationIDs bool
 
+	// pathPrefix is the path prefix under which the gateway serves the
+	// routes, see runtime.WithPathPrefix. It precedes the OpenAPI basePath.
+	pathPrefix string
+
 	standalone bool
 	// warnOnUnboundMethods causes the registry to emit warning logs if an RPC method
 	// has no HttpRule annotation.
@@ -610,6 +614,16 @@ func (r *Registry) GetSimpleOperationIDs() bool {
 	return r.simpleOperationIDs
 }
 
+// SetPathPrefix sets pathPrefix
+func (r *Registry) SetPathPrefix(prefix string) {
+	r.pathPrefix = prefix
+}
+
+// GetPathPrefix returns pathPrefix
+func (r *Registry) GetPathPrefix() string {
+	return r.pathPrefix
+}
+
 // SetWarnOnUnboundMethods sets warnOnUnboundMethods
 func (r *Registry) SetWarnOnUnboundMethods(warn bool) {
 	r.warnOnUnboundMethods = warn
@@ -114,13 +114,21 @@ func (g *generator) generate(file *descriptor.File) (string, error) {
 	for _, svc := range file.Services {
 		for _, m := range svc.Methods {
 			imports = append(imports, g.addEnumPathParamImports(file, m, pkgSeen)...)
-			pkg := m.RequestType.File.GoPkg
-			if len(m.Bindings) == 0 ||
-				pkg == file.GoPkg || pkgSeen[pkg.Path] {
+			if len(m.Bindings) == 0 {
 				continue
 			}
-			pkgSeen[pkg.Path] = true
-			imports = append(imports, pkg)
+			pkgs := []descriptor.GoPackage{m.RequestType.File.GoPkg}
+			if m.GetClientStreaming() || m.GetServerStreaming() {
+				// The in-process stream adapters refer to the response type.
+				pkgs = append(pkgs, m.ResponseType.File.GoPkg)
+			}
+			for _, pkg := range pkgs {
+				if pkg == file.GoPkg || pkgSeen[pkg.Path] {
+					continue
+				}
+				pkgSeen[pkg.Path] = true
+				imports = append(imports, pkg)
+			}
 		}
 	}
 	params := param{
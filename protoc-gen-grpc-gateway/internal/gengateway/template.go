@@ -143,9 +143,10 @@ func (f queryParamFilter) String() string {
 }
 
 type trailerParams struct {
-	Services           []*descriptor.Service
-	UseRequestContext  bool
-	RegisterFuncSuffix string
+	Services                   []*descriptor.Service
+	UseRequestContext          bool
+	RegisterFuncSuffix         string
+	RepeatedPathParamSeparator rune
 }
 
 func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
@@ -202,9 +203,10 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 	}
 
 	tp := trailerParams{
-		Services:           targetServices,
-		UseRequestContext:  p.UseRequestContext,
-		RegisterFuncSuffix: p.RegisterFuncSuffix,
+		Services:                   targetServices,
+		UseRequestContext:          p.UseRequestContext,
+		RegisterFuncSuffix:         p.RegisterFuncSuffix,
+		RepeatedPathParamSeparator: reg.GetRepeatedPathParamSeparator(),
 	}
 	// Local
 	if err := localTrailerTemplate.Execute(w, tp); err != nil {
@@ -417,12 +419,16 @@ var (
 	_ = template.Must(handlerTemplate.New("bidi-streaming-request-func").Parse(`
 {{template "request-func-signature" .}} {
 	var metadata runtime.ServerMetadata
+	body, err := runtime.FullDuplexRequestBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
 	stream, err := client.{{.Method.GetName}}(ctx)
 	if err != nil {
 		grpclog.Infof("Failed to start streaming: %v", err)
 		return nil, metadata, err
 	}
-	dec := marshaler.NewDecoder(req.Body)
+	dec := marshaler.NewDecoder(body)
 	handleSend := func() error {
 		var protoReq {{.Method.RequestType.GoType .Method.Service.File.GoPkg.Path}}
 		err := dec.Decode(&protoReq)
@@ -439,7 +445,7 @@ var (
 		}
 		return nil
 	}
-	go func() {
+	sendAll := func() {
 		for {
 			if err := handleSend(); err != nil {
 				break
@@ -448,7 +454,8 @@ var (
 		if err := stream.CloseSend(); err != nil {
 			grpclog.Infof("Failed to terminate client stream: %v", err)
 		}
-	}()
+	}
+	go sendAll()
 	header, err := stream.Header()
 	if err != nil {
 		grpclog.Infof("Failed to get header from client: %v", err)
@@ -460,9 +467,8 @@ var (
 `))
 
 	localHandlerTemplate = template.Must(template.New("local-handler").Parse(`
-{{if and .Method.GetClientStreaming .Method.GetServerStreaming}}
-{{else if .Method.GetClientStreaming}}
-{{else if .Method.GetServerStreaming}}
+{{if .Method.GetClientStreaming}}
+{{template "local-client-streaming-request-func" .}}
 {{else}}
 {{template "local-client-rpc-request-func" .}}
 {{end}}
@@ -470,10 +476,38 @@ var (
 
 	_ = template.Must(localHandlerTemplate.New("local-request-func-signature").Parse(strings.Replace(`
 {{if .Method.GetServerStreaming}}
+func local_request_{{.Method.Service.GetName}}_{{.Method.GetName}}_{{.Index}}(ctx context.Context, marshaler runtime.Marshaler, server {{.Method.Service.InstanceName}}Server, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error)
 {{else}}
 func local_request_{{.Method.Service.GetName}}_{{.Method.GetName}}_{{.Index}}(ctx context.Context, marshaler runtime.Marshaler, server {{.Method.Service.InstanceName}}Server, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error)
 {{end}}`, "\n", "", -1)))
 
+	_ = template.Must(localHandlerTemplate.New("local-client-streaming-request-func").Parse(`
+{{template "local-request-func-signature" .}} {
+	var metadata runtime.ServerMetadata
+{{- if .Method.GetServerStreaming}}
+	body, err := runtime.FullDuplexRequestBody(req)
+	if err != nil {
+		return nil, metadata, err
+	}
+	stream := runtime.NewInProcessStream(ctx, marshaler.NewDecoder(body))
+{{- else}}
+	stream := runtime.NewInProcessStream(ctx, marshaler.NewDecoder(req.Body))
+{{- end}}
+	stream.Start(func() error {
+		return server.{{.Method.GetName}}(&local_{{.Method.Service.GetName}}_{{.Method.GetName}}Server{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+{{if .Method.GetServerStreaming}}
+	return stream, metadata, nil
+{{else}}
+	defer stream.Close()
+	msg, err := stream.Response()
+	metadata.TrailerMD = stream.Trailer()
+	return msg, metadata, err
+{{end}}
+}
+`))
+
 	_ = template.Must(localHandlerTemplate.New("local-client-rpc-request-func").Parse(`
 {{$AllowPatchFeature := .AllowPatchFeature}}
 {{template "local-request-func-signature" .}} {
@@ -560,7 +594,12 @@ func local_request_{{.Method.Service.GetName}}_{{.Method.GetName}}_{{.Index}}(ct
 	}
 {{end}}
 {{if .Method.GetServerStreaming}}
-	// TODO
+	stream := runtime.NewInProcessStream(ctx, nil)
+	stream.Start(func() error {
+		return server.{{.Method.GetName}}(&protoReq, &local_{{.Method.Service.GetName}}_{{.Method.GetName}}Server{stream})
+	})
+	metadata.HeaderMD = stream.Header()
+	return stream, metadata, nil
 {{else}}
 	msg, err := server.{{.Method.GetName}}(ctx, &protoReq)
 	return msg, metadata, err
@@ -572,19 +611,17 @@ func local_request_{{.Method.Service.GetName}}_{{.Method.GetName}}_{{.Index}}(ct
 {{range $svc := .Services}}
 // Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Server registers the http handlers for service {{$svc.GetName}} to "mux".
 // UnaryRPC     :call {{$svc.GetName}}Server directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+// StreamingRPC :call {{$svc.GetName}}Server directly, streaming the messages over the HTTP request and response.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpoint instead.
 func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Server(ctx context.Context, mux *runtime.ServeMux, server {{$svc.InstanceName}}Server) error {
+	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}ServerWithOptions(ctx, mux, server)
+}
+
+// Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}ServerWithOptions is same as Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Server but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}ServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server {{$svc.InstanceName}}Server, handleOpts ...runtime.HandleOption) error {
 	{{range $m := $svc.Methods}}
 	{{range $b := $m.Bindings}}
-	{{if or $m.GetClientStreaming $m.GetServerStreaming}}
-	mux.Handle({{$b.HTTPMethod | printf "%q"}}, pattern_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
-		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-		return
-	})
-	{{else}}
 	mux.Handle({{$b.HTTPMethod | printf "%q"}}, pattern_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 	{{- if $UseRequestContext }}
 		ctx, cancel := context.WithCancel(req.Context())
@@ -612,18 +649,56 @@ func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Server(ctx context.Context,
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
 		}
-
+		{{if $m.GetServerStreaming}}
+		defer resp.Close()
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
+		{{ if $b.ResponseBody }}
+		forward_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
+			res, err := resp.Recv()
+			return response_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}{res}, err
+		}, mux.GetForwardResponseOptions()...)
+		{{ else }}
+		forward_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)
+		{{end}}
+		{{else}}
 		{{ if $b.ResponseBody }}
 		forward_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}(ctx, mux, outboundMarshaler, w, req, response_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}{resp}, mux.GetForwardResponseOptions()...)
 		{{ else }}
 		forward_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 		{{end}}
-	})
-	{{end}}
+		{{end}}
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/{{$svc.File.GetPackage}}.{{$svc.GetName}}/{{$m.GetName}}"){{if $m.GetClientStreaming}}, runtime.WithClientStreaming(){{end}}{{if $m.GetServerStreaming}}, runtime.WithServerStreaming(){{end}}{{if and $m.GetClientStreaming $m.GetServerStreaming}}, runtime.WithFullDuplex(){{end}}{{if eq $m.ResponseType.FQMN ".google.api.HttpBody"}}, runtime.WithHTTPBodyResponse(){{end}}}, handleOpts...)...)
 	{{end}}
 	{{end}}
 	return nil
 }
+
+{{range $m := $svc.Methods}}
+{{if or $m.GetClientStreaming $m.GetServerStreaming}}
+// local_{{$svc.GetName}}_{{$m.GetName}}Server implements {{$svc.InstanceName}}_{{$m.GetName}}Server over a runtime.InProcessStream.
+type local_{{$svc.GetName}}_{{$m.GetName}}Server struct {
+	grpc.ServerStream
+}
+{{if $m.GetServerStreaming}}
+func (x *local_{{$svc.GetName}}_{{$m.GetName}}Server) Send(m *{{$m.ResponseType.GoType $m.Service.File.GoPkg.Path}}) error {
+	return x.ServerStream.SendMsg(m)
+}
+{{else}}
+func (x *local_{{$svc.GetName}}_{{$m.GetName}}Server) SendAndClose(m *{{$m.ResponseType.GoType $m.Service.File.GoPkg.Path}}) error {
+	return x.ServerStream.SendMsg(m)
+}
+{{end}}
+{{if $m.GetClientStreaming}}
+func (x *local_{{$svc.GetName}}_{{$m.GetName}}Server) Recv() (*{{$m.RequestType.GoType $m.Service.File.GoPkg.Path}}, error) {
+	m := new({{$m.RequestType.GoType $m.Service.File.GoPkg.Path}})
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+{{end}}
+{{end}}
+{{end}}
 {{end}}`))
 
 	trailerTemplate = template.Must(template.New("trailer").Parse(`
@@ -631,13 +706,22 @@ func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Server(ctx context.Context,
 {{range $svc := .Services}}
 // Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpoint is same as Register{{$svc.GetName}}{{$.RegisterFuncSuffix}} but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+// The handlers are unregistered from "mux" before the connection is closed.
 func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpointWithOptions(ctx, mux, endpoint, opts)
+}
+
+// Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpointWithOptions is same as Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpoint but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpointWithOptions(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, handleOpts ...runtime.HandleOption) (err error) {
 	conn, err := grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
+	var reg runtime.Registration
 	defer func() {
 		if err != nil {
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
@@ -645,19 +729,26 @@ func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}FromEndpoint(ctx context.Co
 		}
 		go func() {
 			<-ctx.Done()
+			reg.Close()
 			if cerr := conn.Close(); cerr != nil {
 				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
 			}
 		}()
 	}()
 
-	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}(ctx, mux, conn)
+	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}WithOptions(ctx, mux, conn, append([]runtime.HandleOption{runtime.WithRegistration(&reg)}, handleOpts...)...)
 }
 
 // Register{{$svc.GetName}}{{$.RegisterFuncSuffix}} registers the http handlers for service {{$svc.GetName}} to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
 func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Client(ctx, mux, {{$svc.ClientConstructorName}}(conn))
+	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}WithOptions(ctx, mux, conn)
+}
+
+// Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}WithOptions is same as Register{{$svc.GetName}}{{$.RegisterFuncSuffix}} but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}WithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {
+	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}ClientWithOptions(ctx, mux, {{$svc.ClientConstructorName}}(conn), handleOpts...)
 }
 
 // Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Client registers the http handlers for service {{$svc.GetName}}
@@ -666,6 +757,12 @@ func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}(ctx context.Context, mux *
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "{{$svc.InstanceName}}Client" to call the correct interceptors.
 func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Client(ctx context.Context, mux *runtime.ServeMux, client {{$svc.InstanceName}}Client) error {
+	return Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}ClientWithOptions(ctx, mux, client)
+}
+
+// Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}ClientWithOptions is same as Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Client but
+// gives "handleOpts" to every registered handler, e.g. to constrain them with runtime.WithHost.
+func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}ClientWithOptions(ctx context.Context, mux *runtime.ServeMux, client {{$svc.InstanceName}}Client, handleOpts ...runtime.HandleOption) error {
 	{{range $m := $svc.Methods}}
 	{{range $b := $m.Bindings}}
 	mux.Handle({{$b.HTTPMethod | printf "%q"}}, pattern_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
@@ -693,6 +790,7 @@ func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Client(ctx context.Context,
 			return
 		}
 		{{if $m.GetServerStreaming}}
+		ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)
 		{{ if $b.ResponseBody }}
 		forward_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}(ctx, mux, outboundMarshaler, w, req, func() (proto.Message, error) {
 			res, err := resp.Recv()
@@ -708,7 +806,7 @@ func Register{{$svc.GetName}}{{$.RegisterFuncSuffix}}Client(ctx context.Context,
 		forward_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 		{{end}}
 		{{end}}
-	})
+	}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/{{$svc.File.GetPackage}}.{{$svc.GetName}}/{{$m.GetName}}"){{if $m.GetClientStreaming}}, runtime.WithClientStreaming(){{end}}{{if $m.GetServerStreaming}}, runtime.WithServerStreaming(){{end}}{{if and $m.GetClientStreaming $m.GetServerStreaming}}, runtime.WithFullDuplex(){{end}}{{if eq $m.ResponseType.FQMN ".google.api.HttpBody"}}, runtime.WithHTTPBodyResponse(){{end}}}, handleOpts...)...)
 	{{end}}
 	{{end}}
 	return nil
@@ -744,5 +842,33 @@ var (
 	{{end}}
 	{{end}}
 )
+
+{{range $m := $svc.Methods}}
+{{range $b := $m.Bindings}}
+// {{$svc.GetName}}{{$m.GetName}}URL{{if $b.Index}}{{$b.Index}}{{end}} returns the path of the "{{$b.HTTPMethod}} {{$b.PathTmpl.Template}}" binding of {{$svc.GetName}}.{{$m.GetName}},
+// with the path parameters taken from "protoReq" and escaped for a ServeMux using runtime.UnescapingModeDefault.
+// It does not include the path prefix of the ServeMux.
+func {{$svc.GetName}}{{$m.GetName}}URL{{if $b.Index}}{{$b.Index}}{{end}}(protoReq *{{$m.RequestType.GoType $m.Service.File.GoPkg.Path}}) (string, error) {
+	return {{$svc.GetName}}{{$m.GetName}}URL{{if $b.Index}}{{$b.Index}}{{end}}WithMode(protoReq, runtime.UnescapingModeDefault)
+}
+
+// {{$svc.GetName}}{{$m.GetName}}URL{{if $b.Index}}{{$b.Index}}{{end}}WithMode is same as {{$svc.GetName}}{{$m.GetName}}URL{{if $b.Index}}{{$b.Index}}{{end}} but
+// escapes the path parameters for a ServeMux using "unescapingMode".
+func {{$svc.GetName}}{{$m.GetName}}URL{{if $b.Index}}{{$b.Index}}{{end}}WithMode(protoReq *{{$m.RequestType.GoType $m.Service.File.GoPkg.Path}}, unescapingMode runtime.UnescapingMode) (string, error) {
+{{- if $b.PathParams}}
+	params := make(map[string]string)
+	var err error
+	{{- range $param := $b.PathParams}}
+	if params[{{$param | printf "%q"}}], err = runtime.FormatFieldFromPath(protoReq, {{$param | printf "%q"}}, {{$.RepeatedPathParamSeparator | printf "%c" | printf "%q"}}); err != nil {
+		return "", err
+	}
+	{{- end}}
+	return pattern_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}.ExpandAndEscape(params, unescapingMode)
+{{- else}}
+	return pattern_{{$svc.GetName}}_{{$m.GetName}}_{{$b.Index}}.ExpandAndEscape(nil, unescapingMode)
+{{- end}}
+}
+{{end}}
+{{end}}
 {{end}}`))
 )
@@ -138,14 +138,17 @@ func TestApplyTemplateRequestWithoutClientStreaming(t *testing.T) {
 	for _, spec := range []struct {
 		serverStreaming bool
 		sigWant         string
+		optsWant        string
 	}{
 		{
 			serverStreaming: false,
 			sigWant:         `func request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, client ExampleServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {`,
+			optsWant:        `}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/example.ExampleService/Echo")}, handleOpts...)...)`,
 		},
 		{
 			serverStreaming: true,
 			sigWant:         `func request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, client ExampleServiceClient, req *http.Request, pathParams map[string]string) (ExampleService_EchoClient, runtime.ServerMetadata, error) {`,
+			optsWant:        `}, append([]runtime.HandleOption{runtime.WithRPCMethodName("/example.ExampleService/Echo"), runtime.WithServerStreaming()}, handleOpts...)...)`,
 		},
 	} {
 		meth.ServerStreaming = proto.Bool(spec.serverStreaming)
@@ -251,12 +254,32 @@ func TestApplyTemplateRequestWithoutClientStreaming(t *testing.T) {
 		if want := `func RegisterExampleServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
+		if want := `func RegisterExampleServiceHandlerWithOptions(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, handleOpts ...runtime.HandleOption) error {`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
 		if want := `pattern_ExampleService_Echo_0 = runtime.MustPattern(runtime.NewPattern(1, []int{0, 0}, []string(nil), ""))`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 		if want := `ctx, err = runtime.AnnotateContext(ctx, mux, req, "/example.ExampleService/Echo", runtime.WithHTTPPathPattern("/v1"))`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
+		if want := `if params["nested.int32"], err = runtime.FormatFieldFromPath(protoReq, "nested.int32", ","); err != nil {`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := `return pattern_ExampleService_Echo_0.ExpandAndEscape(params, unescapingMode)`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := `func ExampleServiceEchoURL(protoReq *ExampleMessage) (string, error) {
+	return ExampleServiceEchoURLWithMode(protoReq, runtime.UnescapingModeDefault)
+}`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := `func ExampleServiceEchoURLWithMode(protoReq *ExampleMessage, unescapingMode runtime.UnescapingMode) (string, error) {`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := spec.optsWant; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
 	}
 }
 
@@ -469,21 +492,37 @@ func TestApplyTemplateInProcess(t *testing.T) {
 			clientStreaming: true,
 			serverStreaming: true,
 			sigWant: []string{
-				`err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")`,
+				`func local_request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, server ExampleServiceServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {`,
+				`return server.Echo(&local_ExampleService_EchoServer{stream})`,
+				`body, err := runtime.FullDuplexRequestBody(req)`,
+				`dec := marshaler.NewDecoder(body)`,
+				`go sendAll()`,
+				`runtime.WithClientStreaming(), runtime.WithServerStreaming(), runtime.WithFullDuplex()}, handleOpts...)...)`,
+				`ctx = runtime.NewStreamTrailerContext(ctx, resp.Trailer)`,
+				`func (x *local_ExampleService_EchoServer) Send(m *ExampleMessage) error {`,
+				`func (x *local_ExampleService_EchoServer) Recv() (*ExampleMessage, error) {`,
+				`forward_ExampleService_Echo_0(ctx, mux, outboundMarshaler, w, req, resp.Recv, mux.GetForwardResponseOptions()...)`,
 			},
 		},
 		{
 			clientStreaming: true,
 			serverStreaming: false,
 			sigWant: []string{
-				`err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")`,
+				`func local_request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, server ExampleServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {`,
+				`msg, err := stream.Response()`,
+				`runtime.WithClientStreaming()}, handleOpts...)...)`,
+				`func (x *local_ExampleService_EchoServer) SendAndClose(m *ExampleMessage) error {`,
+				`func (x *local_ExampleService_EchoServer) Recv() (*ExampleMessage, error) {`,
 			},
 		},
 		{
 			clientStreaming: false,
 			serverStreaming: true,
 			sigWant: []string{
-				`err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")`,
+				`func local_request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, server ExampleServiceServer, req *http.Request, pathParams map[string]string) (*runtime.InProcessStream, runtime.ServerMetadata, error) {`,
+				`return server.Echo(&protoReq, &local_ExampleService_EchoServer{stream})`,
+				`runtime.WithServerStreaming()}, handleOpts...)...)`,
+				`func (x *local_ExampleService_EchoServer) Send(m *ExampleMessage) error {`,
 			},
 		},
 	} {
@@ -585,6 +624,9 @@ func TestApplyTemplateInProcess(t *testing.T) {
 		if want := `func RegisterExampleServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ExampleServiceServer) error {`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
+		if want := `func RegisterExampleServiceHandlerServerWithOptions(ctx context.Context, mux *runtime.ServeMux, server ExampleServiceServer, handleOpts ...runtime.HandleOption) error {`; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
 	}
 }
 
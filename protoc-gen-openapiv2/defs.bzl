@@ -65,6 +65,7 @@ def _run_proto_gen_openapi(
         omit_enum_default_value,
         output_format,
         simple_operation_ids,
+        path_prefix,
         proto3_optional_nullable,
         openapi_configuration,
         generate_unbound_methods,
@@ -100,6 +101,9 @@ def _run_proto_gen_openapi(
     if simple_operation_ids:
         args.add("--openapiv2_opt", "simple_operation_ids=true")
 
+    if path_prefix:
+        args.add("--openapiv2_opt", "path_prefix=%s" % path_prefix)
+
     if allow_delete_body:
         args.add("--openapiv2_opt", "allow_delete_body=true")
 
@@ -224,6 +228,7 @@ def _proto_gen_openapi_impl(ctx):
                     omit_enum_default_value = ctx.attr.omit_enum_default_value,
                     output_format = ctx.attr.output_format,
                     simple_operation_ids = ctx.attr.simple_operation_ids,
+                    path_prefix = ctx.attr.path_prefix,
                     proto3_optional_nullable = ctx.attr.proto3_optional_nullable,
                     openapi_configuration = ctx.file.openapi_configuration,
                     generate_unbound_methods = ctx.attr.generate_unbound_methods,
@@ -323,6 +328,12 @@ protoc_gen_openapiv2 = rule(
             doc = "whether to remove the service prefix in the operationID" +
                   " generation. Can introduce duplicate operationIDs, use with caution.",
         ),
+        "path_prefix": attr.string(
+            default = "",
+            mandatory = False,
+            doc = "path prefix under which the gateway serves the routes," +
+                  " as given to `runtime.WithPathPrefix`. It is prepended to the `basePath`",
+        ),
         "proto3_optional_nullable": attr.bool(
             default = False,
             mandatory = False,
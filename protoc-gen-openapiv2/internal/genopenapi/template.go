@@ -8,6 +8,7 @@ import (
 	"math"
 	"net/textproto"
 	"os"
+	"path"
 	"reflect"
 	"regexp"
 	"sort"
@@ -1808,6 +1809,12 @@ func applyTemplate(p param) (*openapiSwaggerObject, error) {
 		// should be added here, once supported in the proto.
 	}
 
+	// The gateway serves the paths under its path prefix, which
+	// therefore precedes the base path of the file.
+	if prefix := p.reg.GetPathPrefix(); prefix != "" {
+		s.BasePath = path.Join("/", prefix, s.BasePath)
+	}
+
 	// Finally add any references added by users that aren't
 	// otherwise rendered.
 	if err := addCustomRefs(s.Definitions, p.reg, customRefs); err != nil {
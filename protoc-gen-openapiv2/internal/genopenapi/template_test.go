@@ -1226,6 +1226,93 @@ func TestApplyTemplateSimple(t *testing.T) {
 	}
 }
 
+func TestApplyTemplatePathPrefix(t *testing.T) {
+	for _, spec := range []struct {
+		pathPrefix string
+		basePath   string
+		want       string
+	}{
+		{pathPrefix: "", basePath: "/v1", want: "/v1"},
+		{pathPrefix: "/api/v2", basePath: "", want: "/api/v2"},
+		{pathPrefix: "/api/v2/", basePath: "/", want: "/api/v2"},
+		{pathPrefix: "api", basePath: "/v1", want: "/api/v1"},
+	} {
+		t.Run(spec.pathPrefix+spec.basePath, func(t *testing.T) {
+			msgdesc := &descriptorpb.DescriptorProto{
+				Name: proto.String("ExampleMessage"),
+			}
+			meth := &descriptorpb.MethodDescriptorProto{
+				Name:       proto.String("Example"),
+				InputType:  proto.String("ExampleMessage"),
+				OutputType: proto.String("ExampleMessage"),
+			}
+			svc := &descriptorpb.ServiceDescriptorProto{
+				Name:   proto.String("ExampleService"),
+				Method: []*descriptorpb.MethodDescriptorProto{meth},
+			}
+			msg := &descriptor.Message{
+				DescriptorProto: msgdesc,
+			}
+			file := descriptor.File{
+				FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+					SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+					Name:           proto.String("example.proto"),
+					Package:        proto.String("example"),
+					MessageType:    []*descriptorpb.DescriptorProto{msgdesc},
+					Service:        []*descriptorpb.ServiceDescriptorProto{svc},
+					Options: &descriptorpb.FileOptions{
+						GoPackage: proto.String("github.com/grpc-ecosystem/grpc-gateway/runtime/internal/examplepb;example"),
+					},
+				},
+				GoPkg: descriptor.GoPackage{
+					Path: "example.com/path/to/example/example.pb",
+					Name: "example_pb",
+				},
+				Messages: []*descriptor.Message{msg},
+				Services: []*descriptor.Service{
+					{
+						ServiceDescriptorProto: svc,
+						Methods: []*descriptor.Method{
+							{
+								MethodDescriptorProto: meth,
+								RequestType:           msg,
+								ResponseType:          msg,
+								Bindings: []*descriptor.Binding{
+									{
+										HTTPMethod: "GET",
+										Body:       &descriptor.Body{FieldPath: nil},
+										PathTmpl: httprule.Template{
+											Version:  1,
+											OpCodes:  []int{0, 0},
+											Template: "/echo",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			swagger := openapi_options.Swagger{BasePath: spec.basePath}
+			proto.SetExtension(proto.Message(file.FileDescriptorProto.Options), openapi_options.E_Openapiv2Swagger, &swagger)
+
+			reg := descriptor.NewRegistry()
+			reg.SetPathPrefix(spec.pathPrefix)
+			fileCL := crossLinkFixture(&file)
+			if err := reg.Load(reqFromFile(fileCL)); err != nil {
+				t.Fatalf("reg.Load(%#v) failed with %v; want success", file, err)
+			}
+			result, err := applyTemplate(param{File: fileCL, reg: reg})
+			if err != nil {
+				t.Fatalf("applyTemplate(%#v) failed with %v; want success", file, err)
+			}
+			if got := result.BasePath; got != spec.want {
+				t.Errorf("applyTemplate(%#v).BasePath = %s want to be %s", file, got, spec.want)
+			}
+		})
+	}
+}
+
 func TestApplyTemplateMultiService(t *testing.T) {
 	msgdesc := &descriptorpb.DescriptorProto{
 		Name: proto.String("ExampleMessage"),
@@ -0,0 +1,389 @@
+package runtime
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// Compressor compresses and decompresses HTTP message bodies with a content coding.
+type Compressor interface {
+	// NewWriter returns a CompressWriter compressing the data written to it into "w".
+	NewWriter(w io.Writer) (CompressWriter, error)
+	// NewReader returns a reader decompressing the data read from "r".
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// CompressWriter is a writer compressing the data written to it.
+// Flush writes the data compressed so far to the underlying writer.
+// Close writes the end of the compressed data, without closing the underlying writer.
+type CompressWriter interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// GzipCompressor is a Compressor for the "gzip" content coding.
+type GzipCompressor struct {
+	// Level is the compression level, see compress/gzip.
+	// Zero means gzip.DefaultCompression.
+	Level int
+}
+
+// NewWriter implements Compressor.
+func (c GzipCompressor) NewWriter(w io.Writer) (CompressWriter, error) {
+	level := c.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	return gzip.NewWriterLevel(w, level)
+}
+
+// NewReader implements Compressor.
+func (GzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// DeflateCompressor is a Compressor for the "deflate" content coding,
+// which is the zlib format of RFC 1950.
+type DeflateCompressor struct {
+	// Level is the compression level, see compress/zlib.
+	// Zero means zlib.DefaultCompression.
+	Level int
+}
+
+// NewWriter implements Compressor.
+func (c DeflateCompressor) NewWriter(w io.Writer) (CompressWriter, error) {
+	level := c.Level
+	if level == 0 {
+		level = zlib.DefaultCompression
+	}
+	return zlib.NewWriterLevel(w, level)
+}
+
+// NewReader implements Compressor.
+func (DeflateCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+type namedCompressor struct {
+	encoding string
+	c        Compressor
+}
+
+// WithCompressor returns a ServeMuxOption which registers "c" for the content coding "encoding",
+// e.g. "gzip", "deflate" or "zstd".
+//
+// Request bodies encoded with a registered content coding are decompressed before being decoded,
+// and requests using any other content coding are rejected with http.StatusUnsupportedMediaType.
+// Responses forwarded by ForwardResponseMessage and ForwardResponseStream are compressed with the
+// content coding preferred by the Accept-Encoding header of the request. When the request accepts
+// several content codings equally, the one registered first is used. Streamed responses are still
+// flushed after every message.
+//
+// The decompressed request bodies may take up to 32 MiB, see WithMaxDecompressedRequestSize.
+//
+// Registering a Compressor again for the same content coding replaces it.
+func WithCompressor(encoding string, c Compressor) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		encoding = strings.ToLower(encoding)
+		for i := range serveMux.compressors {
+			if serveMux.compressors[i].encoding == encoding {
+				serveMux.compressors[i].c = c
+				return
+			}
+		}
+		serveMux.compressors = append(serveMux.compressors, namedCompressor{encoding: encoding, c: c})
+	}
+}
+
+// defaultMaxDecompressedRequestSize is the default maximum size of the decompressed request bodies.
+const defaultMaxDecompressedRequestSize = 32 << 20
+
+// WithMaxDecompressedRequestSize returns a ServeMuxOption which sets the maximum size of the request
+// bodies once decompressed, 32 MiB if not given. It protects the handlers from small bodies which
+// decompress to huge ones. Reading further fails, and HTTPError answers the request with
+// http.StatusRequestEntityTooLarge.
+func WithMaxDecompressedRequestSize(size int64) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.maxDecompressedSize = size
+	}
+}
+
+func (s *ServeMux) compressor(encoding string) (Compressor, bool) {
+	for _, nc := range s.compressors {
+		if nc.encoding == encoding {
+			return nc.c, true
+		}
+	}
+	return nil, false
+}
+
+// compressionEncodings returns the registered content codings, in order of registration.
+func (s *ServeMux) compressionEncodings() []string {
+	encodings := make([]string, 0, len(s.compressors))
+	for _, nc := range s.compressors {
+		encodings = append(encodings, nc.encoding)
+	}
+	return encodings
+}
+
+// negotiateEncoding returns the registered content coding preferred by the Accept-Encoding
+// header values "accept", or false if none is acceptable.
+func (s *ServeMux) negotiateEncoding(accept []string) (namedCompressor, bool) {
+	qualities := make(map[string]float64)
+	for _, v := range accept {
+		for _, coding := range strings.Split(v, ",") {
+			params := strings.Split(coding, ";")
+			name := strings.ToLower(strings.TrimSpace(params[0]))
+			if name == "" {
+				continue
+			}
+			if name == "x-gzip" {
+				name = "gzip"
+			}
+			q := 1.0
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				if len(param) > 2 && strings.EqualFold(param[:2], "q=") {
+					if f, err := strconv.ParseFloat(param[2:], 64); err == nil {
+						q = f
+					}
+				}
+			}
+			qualities[name] = q
+		}
+	}
+
+	var (
+		best  namedCompressor
+		bestQ float64
+	)
+	for _, nc := range s.compressors {
+		q, ok := qualities[nc.encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = nc, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// compressResponse returns the writer to write the response to "r" to, compressing the body with
+// the content coding preferred by "r", and a function to call once the response is written.
+func (s *ServeMux) compressResponse(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	if len(s.compressors) == 0 || r == nil {
+		return w, func() {}
+	}
+	if _, ok := w.(*webSocketResponseWriter); ok {
+		return w, func() {}
+	}
+	w.Header().Add("Vary", "Accept-Encoding")
+	nc, ok := s.negotiateEncoding(r.Header.Values("Accept-Encoding"))
+	if !ok {
+		return w, func() {}
+	}
+	cw := &compressResponseWriter{ResponseWriter: w, encoding: nc.encoding, c: nc.c}
+	return cw, cw.finish
+}
+
+// compressResponseWriter compresses the response body, unless the response has no body or
+// already has a content coding.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding    string
+	c           Compressor
+	cw          CompressWriter
+	wroteHeader bool
+	compress    bool
+}
+
+func (w *compressResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader || code < http.StatusOK {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.wroteHeader = true
+	h := w.Header()
+	if code != http.StatusNoContent && code != http.StatusNotModified && h.Get("Content-Encoding") == "" {
+		w.compress = true
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.compress {
+		return w.ResponseWriter.Write(p)
+	}
+	if w.cw == nil {
+		cw, err := w.c.NewWriter(w.ResponseWriter)
+		if err != nil {
+			return 0, err
+		}
+		w.cw = cw
+	}
+	return w.cw.Write(p)
+}
+
+// Flush writes the data compressed so far to the client.
+func (w *compressResponseWriter) Flush() {
+	if w.cw != nil {
+		if err := w.cw.Flush(); err != nil {
+			grpclog.Infof("Failed to flush compressed response: %v", err)
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// FlushError is like Flush, but returns the error of the flush, see http.ResponseController.
+func (w *compressResponseWriter) FlushError() error {
+	if w.cw != nil {
+		if err := w.cw.Flush(); err != nil {
+			return err
+		}
+	}
+	return flushError(w.ResponseWriter)
+}
+
+// Unwrap returns the underlying http.ResponseWriter, see http.ResponseController.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressResponseWriter) finish() {
+	if w.cw == nil {
+		return
+	}
+	if err := w.cw.Close(); err != nil {
+		grpclog.Infof("Failed to finish compressed response: %v", err)
+	}
+}
+
+// decompressRequest replaces the body of "r" with a reader decompressing it according to its
+// Content-Encoding header, up to the maximum size of the ServeMux. It returns http.StatusUnsupportedMediaType if a content coding has no
+// registered Compressor, and http.StatusBadRequest if the body cannot be decompressed.
+func (s *ServeMux) decompressRequest(r *http.Request) (*http.Request, int) {
+	if len(s.compressors) == 0 {
+		return r, 0
+	}
+	var encodings []string
+	for _, v := range r.Header.Values("Content-Encoding") {
+		for _, encoding := range strings.Split(v, ",") {
+			encoding = strings.ToLower(strings.TrimSpace(encoding))
+			if encoding != "" && encoding != "identity" {
+				encodings = append(encodings, encoding)
+			}
+		}
+	}
+	if len(encodings) == 0 {
+		return r, 0
+	}
+
+	maxSize := s.maxDecompressedSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxDecompressedRequestSize
+	}
+	body := &decompressedBody{Reader: r.Body, closers: []io.Closer{r.Body}, maxSize: maxSize, remaining: maxSize}
+	// The content codings are listed in the order in which they were applied.
+	for i := len(encodings) - 1; i >= 0; i-- {
+		encoding := encodings[i]
+		if encoding == "x-gzip" {
+			encoding = "gzip"
+		}
+		c, ok := s.compressor(encoding)
+		if !ok {
+			return r, http.StatusUnsupportedMediaType
+		}
+		rc, err := c.NewReader(body.Reader)
+		if err != nil {
+			grpclog.Infof("Failed to decompress request body: %v", err)
+			return r, http.StatusBadRequest
+		}
+		body.Reader = rc
+		body.closers = append(body.closers, rc)
+	}
+
+	r = r.Clone(r.Context())
+	r.Body = body
+	r.ContentLength = -1
+	r.Header.Del("Content-Encoding")
+	r.Header.Del("Content-Length")
+	return r, 0
+}
+
+// errDecompressedBodyTooLarge is returned when reading a decompressed request body beyond its maximum size.
+var errDecompressedBodyTooLarge = errors.New("the decompressed request body is too large")
+
+// decompressedBody is a request body decompressing the original one, up to "maxSize" bytes.
+type decompressedBody struct {
+	io.Reader
+	closers   []io.Closer
+	maxSize   int64
+	remaining int64
+	// tooLarge is set to 1 once the body exceeded "maxSize". It is read by decompressedBodyError,
+	// which may run concurrently with the goroutine reading the body.
+	tooLarge int32
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	if atomic.LoadInt32(&b.tooLarge) != 0 {
+		return 0, errDecompressedBodyTooLarge
+	}
+	// Read one byte more than remains, to tell a body of the maximum size from a larger one.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.Reader.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		atomic.StoreInt32(&b.tooLarge, 1)
+		return n, errDecompressedBodyTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *decompressedBody) Close() error {
+	var err error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if cerr := b.closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// decompressedBodyError returns the error to reply to "r" with instead of "err": whatever "err",
+// a request whose decompressed body exceeded its maximum size is answered with
+// http.StatusRequestEntityTooLarge.
+func decompressedBodyError(r *http.Request, err error) error {
+	if r == nil {
+		return err
+	}
+	b, ok := r.Body.(*decompressedBody)
+	if !ok || atomic.LoadInt32(&b.tooLarge) == 0 {
+		return err
+	}
+	return &HTTPStatusError{
+		HTTPStatus: http.StatusRequestEntityTooLarge,
+		Err:        status.Errorf(codes.InvalidArgument, "the decompressed request body exceeds %d bytes", b.maxSize),
+	}
+}
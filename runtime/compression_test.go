@@ -0,0 +1,259 @@
+package runtime_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/proto"
+)
+
+func gzipBytes(t *testing.T, b []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		t.Fatalf("zw.Write failed with %v; want success", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zw.Close failed with %v; want success", err)
+	}
+	return buf.Bytes()
+}
+
+func decompress(t *testing.T, encoding string, b []byte) []byte {
+	t.Helper()
+	var (
+		r   io.Reader
+		err error
+	)
+	switch encoding {
+	case "gzip":
+		r, err = gzip.NewReader(bytes.NewReader(b))
+	case "deflate":
+		r, err = zlib.NewReader(bytes.NewReader(b))
+	default:
+		return b
+	}
+	if err != nil {
+		t.Fatalf("failed to decompress %q response: %v", encoding, err)
+	}
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to decompress %q response: %v", encoding, err)
+	}
+	return out
+}
+
+func TestCompression(t *testing.T) {
+	for _, spec := range []struct {
+		name            string
+		accept          string
+		contentEncoding string
+		body            []byte
+		maxSize         int64
+		wantStatus      int
+		wantEncoding    string
+	}{
+		{
+			name:       "identity",
+			body:       []byte(`{"id":"foo"}`),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "gzip",
+			accept:       "gzip",
+			body:         []byte(`{"id":"foo"}`),
+			wantStatus:   http.StatusOK,
+			wantEncoding: "gzip",
+		},
+		{
+			name:         "preferred by client",
+			accept:       "gzip;q=0.5, deflate",
+			body:         []byte(`{"id":"foo"}`),
+			wantStatus:   http.StatusOK,
+			wantEncoding: "deflate",
+		},
+		{
+			name:         "preferred by server",
+			accept:       "deflate, gzip",
+			body:         []byte(`{"id":"foo"}`),
+			wantStatus:   http.StatusOK,
+			wantEncoding: "gzip",
+		},
+		{
+			name:         "wildcard",
+			accept:       "*, gzip;q=0",
+			body:         []byte(`{"id":"foo"}`),
+			wantStatus:   http.StatusOK,
+			wantEncoding: "deflate",
+		},
+		{
+			name:       "not acceptable",
+			accept:     "br, gzip;q=0",
+			body:       []byte(`{"id":"foo"}`),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:            "gzip request",
+			accept:          "gzip",
+			contentEncoding: "gzip",
+			body:            gzipBytes(t, []byte(`{"id":"foo"}`)),
+			wantStatus:      http.StatusOK,
+			wantEncoding:    "gzip",
+		},
+		{
+			name:            "unsupported request encoding",
+			contentEncoding: "br",
+			body:            []byte(`{"id":"foo"}`),
+			wantStatus:      http.StatusUnsupportedMediaType,
+		},
+		{
+			name:            "corrupted request",
+			contentEncoding: "gzip",
+			body:            []byte(`{"id":"foo"}`),
+			wantStatus:      http.StatusBadRequest,
+		},
+		{
+			name:            "request of the maximum size",
+			contentEncoding: "gzip",
+			body:            gzipBytes(t, []byte(`{"id":"foo"}`)),
+			maxSize:         12,
+			wantStatus:      http.StatusOK,
+		},
+		{
+			name:            "too large request",
+			contentEncoding: "gzip",
+			body:            gzipBytes(t, []byte(`{"id":"`+strings.Repeat("x", 1<<20)+`"}`)),
+			maxSize:         1 << 10,
+			wantStatus:      http.StatusRequestEntityTooLarge,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			opts := []runtime.ServeMuxOption{
+				runtime.WithCompressor("gzip", runtime.GzipCompressor{}),
+				runtime.WithCompressor("deflate", runtime.DeflateCompressor{}),
+			}
+			if spec.maxSize != 0 {
+				opts = append(opts, runtime.WithMaxDecompressedRequestSize(spec.maxSize))
+			}
+			mux := runtime.NewServeMux(opts...)
+			err := mux.HandlePath(http.MethodPost, "/v1/echo", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				marshaler := &runtime.JSONPb{}
+				var msg pb.SimpleMessage
+				if err := marshaler.NewDecoder(r.Body).Decode(&msg); err != nil {
+					runtime.HTTPError(r.Context(), mux, marshaler, w, r, err)
+					return
+				}
+				runtime.ForwardResponseMessage(r.Context(), mux, marshaler, w, r, &msg)
+			})
+			if err != nil {
+				t.Fatalf("mux.HandlePath failed with %v; want success", err)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/v1/echo", bytes.NewReader(spec.body))
+			if spec.accept != "" {
+				r.Header.Set("Accept-Encoding", spec.accept)
+			}
+			if spec.contentEncoding != "" {
+				r.Header.Set("Content-Encoding", spec.contentEncoding)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			if got, want := w.Code, spec.wantStatus; got != want {
+				t.Fatalf("w.Code = %d; want %d; body: %s", got, want, w.Body.Bytes())
+			}
+			if spec.wantStatus == http.StatusUnsupportedMediaType {
+				if got, want := w.Header().Get("Accept-Encoding"), "gzip, deflate"; got != want {
+					t.Errorf(`w.Header().Get("Accept-Encoding") = %q; want %q`, got, want)
+				}
+			}
+			if spec.wantStatus != http.StatusOK {
+				return
+			}
+			if got, want := w.Header().Get("Content-Encoding"), spec.wantEncoding; got != want {
+				t.Errorf(`w.Header().Get("Content-Encoding") = %q; want %q`, got, want)
+			}
+			if got, want := w.Header().Get("Vary"), "Accept-Encoding"; got != want {
+				t.Errorf(`w.Header().Get("Vary") = %q; want %q`, got, want)
+			}
+			var msg pb.SimpleMessage
+			if err := (&runtime.JSONPb{}).Unmarshal(decompress(t, spec.wantEncoding, w.Body.Bytes()), &msg); err != nil {
+				t.Fatalf("failed to unmarshal response %q: %v", w.Body.Bytes(), err)
+			}
+			if got, want := msg.Id, "foo"; got != want {
+				t.Errorf("msg.Id = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCompressionDisabled(t *testing.T) {
+	mux := runtime.NewServeMux()
+	err := mux.HandlePath(http.MethodPost, "/v1/echo", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		runtime.ForwardResponseMessage(r.Context(), mux, &runtime.JSONPb{}, w, r, &pb.SimpleMessage{Id: "foo"})
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath failed with %v; want success", err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/v1/echo", strings.NewReader("{}"))
+	r.Header.Set("Accept-Encoding", "gzip")
+	r.Header.Set("Content-Encoding", "br")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Errorf("w.Code = %d; want %d", got, want)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf(`w.Header().Get("Content-Encoding") = %q; want ""`, got)
+	}
+}
+
+func TestCompressionStream(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithCompressor("gzip", runtime.GzipCompressor{}))
+	r := httptest.NewRequest(http.MethodGet, "/v1/stream", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+
+	msgs := []string{"One", "Two"}
+	var count int
+	recv := func() (proto.Message, error) {
+		if count > 0 {
+			// The previous message must have been flushed to the client.
+			zr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+			if err != nil {
+				t.Fatalf("gzip.NewReader failed with %v; want success", err)
+			}
+			got, _ := ioutil.ReadAll(zr)
+			if want := msgs[count-1]; !bytes.Contains(got, []byte(want)) {
+				t.Errorf("flushed response = %q; want to contain %q", got, want)
+			}
+		}
+		if count == len(msgs) {
+			return nil, io.EOF
+		}
+		count++
+		return &pb.SimpleMessage{Id: msgs[count-1]}, nil
+	}
+	runtime.ForwardResponseStream(ctx, mux, &runtime.JSONPb{}, w, r, recv)
+
+	if got, want := w.Header().Get("Content-Encoding"), "gzip"; got != want {
+		t.Errorf(`w.Header().Get("Content-Encoding") = %q; want %q`, got, want)
+	}
+	got := decompress(t, "gzip", w.Body.Bytes())
+	if want := `{"result":{"id":"One"}}` + "\n" + `{"result":{"id":"Two"}}` + "\n"; string(got) != want {
+		t.Errorf("response = %q; want %q", got, want)
+	}
+}
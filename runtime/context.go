@@ -1,9 +1,12 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/textproto"
@@ -50,6 +53,13 @@ var malformedHTTPHeaders = map[string]struct{}{
 type (
 	rpcMethodKey       struct{}
 	httpPathPatternKey struct{}
+	httpPatternKey     struct{}
+	pathParamsKey      struct{}
+	allowedMethodsKey  struct{}
+	fullDuplexKey      struct{}
+	clientStreamingKey struct{}
+	maxBodySizeKey     struct{}
+	streamTrailerKey   struct{}
 
 	AnnotateContextOption func(ctx context.Context) context.Context
 )
@@ -106,6 +116,11 @@ func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 	for _, o := range options {
 		ctx = o(ctx)
 	}
+	if mux.pathPrefix != "" {
+		if pattern, ok := HTTPPathPattern(ctx); ok {
+			ctx = withHTTPPathPattern(ctx, mux.pathPrefix+pattern)
+		}
+	}
 	var pairs []string
 	timeout := DefaultContextTimeout
 	if tm := req.Header.Get(metadataGrpcTimeout); tm != "" {
@@ -116,25 +131,31 @@ func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 		}
 	}
 
-	for key, vals := range req.Header {
-		key = textproto.CanonicalMIMEHeaderKey(key)
-		for _, val := range vals {
-			// For backwards-compatibility, pass through 'authorization' header with no prefix.
-			if key == "Authorization" {
-				pairs = append(pairs, "authorization", val)
-			}
-			if h, ok := mux.incomingHeaderMatcher(key); ok {
-				// Handles "-bin" metadata in grpc, since grpc will do another base64
-				// encode before sending to server, we need to decode it first.
-				if strings.HasSuffix(key, metadataHeaderBinarySuffix) {
-					b, err := decodeBinHeader(val)
-					if err != nil {
-						return nil, nil, status.Errorf(codes.InvalidArgument, "invalid binary header %s: %s", key, err)
+	trailer, err := readRequestTrailer(ctx, mux, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, header := range []http.Header{req.Header, trailer} {
+		for key, vals := range header {
+			key = textproto.CanonicalMIMEHeaderKey(key)
+			for _, val := range vals {
+				// For backwards-compatibility, pass through 'authorization' header with no prefix.
+				if key == "Authorization" {
+					pairs = append(pairs, "authorization", val)
+				}
+				if h, ok := mux.incomingHeaderMatcher(key); ok {
+					// Handles "-bin" metadata in grpc, since grpc will do another base64
+					// encode before sending to server, we need to decode it first.
+					if strings.HasSuffix(key, metadataHeaderBinarySuffix) {
+						b, err := decodeBinHeader(val)
+						if err != nil {
+							return nil, nil, status.Errorf(codes.InvalidArgument, "invalid binary header %s: %s", key, err)
+						}
+
+						val = string(b)
 					}
-
-					val = string(b)
+					pairs = append(pairs, h, val)
 				}
-				pairs = append(pairs, h, val)
 			}
 		}
 	}
@@ -187,6 +208,66 @@ func ServerMetadataFromContext(ctx context.Context) (md ServerMetadata, ok bool)
 	return
 }
 
+// defaultMaxRequestTrailerBodySize is the default maximum size of the request bodies read into memory,
+// see WithMaxRequestTrailerBodySize.
+const defaultMaxRequestTrailerBodySize = 4 << 20
+
+// maxRequestBodySize returns the maximum size of the request bodies read into memory by "mux".
+func (s *ServeMux) maxRequestBodySize() int64 {
+	if s.maxRequestTrailerBodySize <= 0 {
+		return defaultMaxRequestTrailerBodySize
+	}
+	return s.maxRequestTrailerBodySize
+}
+
+// readRequestBody reads the body of "req" into memory, failing with http.StatusRequestEntityTooLarge
+// if it exceeds "maxSize" bytes.
+func readRequestBody(req *http.Request, maxSize int64) ([]byte, error) {
+	buf, err := ioutil.ReadAll(io.LimitReader(req.Body, maxSize+1))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read the request body: %v", err)
+	}
+	if int64(len(buf)) > maxSize {
+		return nil, &HTTPStatusError{
+			HTTPStatus: http.StatusRequestEntityTooLarge,
+			Err:        status.Errorf(codes.InvalidArgument, "the request body exceeds %d bytes", maxSize),
+		}
+	}
+	return buf, nil
+}
+
+// readRequestTrailer returns the trailers of "req", if it declares any and is the request of a client
+// streaming method which is not full-duplex. As they are only received after the body, which the call
+// streams, and the metadata of a gRPC call is sent first, the body is read into memory first, up to
+// the maximum size of the ServeMux. The trailers of the other requests are not forwarded: a
+// full-duplex call cannot wait for the end of its body.
+func readRequestTrailer(ctx context.Context, mux *ServeMux, req *http.Request) (http.Header, error) {
+	if len(req.Trailer) == 0 || req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if !clientStreaming(ctx) || FullDuplex(ctx) {
+		return nil, nil
+	}
+	buf, err := readRequestBody(req, mux.maxRequestBodySize())
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(buf))
+	return req.Trailer, nil
+}
+
+// NewStreamTrailerContext returns a context carrying "trailer", which returns the trailer metadata
+// of a server stream once it ended, for ForwardResponseStream to forward it in the response trailers.
+// It should only be used by the generated files.
+func NewStreamTrailerContext(ctx context.Context, trailer func() metadata.MD) context.Context {
+	return context.WithValue(ctx, streamTrailerKey{}, trailer)
+}
+
+func streamTrailerFromContext(ctx context.Context) (func() metadata.MD, bool) {
+	trailer, ok := ctx.Value(streamTrailerKey{}).(func() metadata.MD)
+	return trailer, ok
+}
+
 // ServerTransportStream implements grpc.ServerTransportStream.
 // It should only be used by the generated files to support grpc.SendHeader
 // outside of gRPC server use.
@@ -356,3 +437,69 @@ func HTTPPathPattern(ctx context.Context) (string, bool) {
 func withHTTPPathPattern(ctx context.Context, httpPathPattern string) context.Context {
 	return context.WithValue(ctx, httpPathPatternKey{}, httpPathPattern)
 }
+
+// HTTPPattern returns the Pattern which matched the request, if the request was routed by a ServeMux.
+func HTTPPattern(ctx context.Context) (Pattern, bool) {
+	v, ok := ctx.Value(httpPatternKey{}).(Pattern)
+	return v, ok
+}
+
+func withHTTPPattern(ctx context.Context, httpPattern Pattern) context.Context {
+	return context.WithValue(ctx, httpPatternKey{}, httpPattern)
+}
+
+// PathParams returns the path parameters captured by the Pattern which matched the request,
+// if the request was routed by a ServeMux.
+func PathParams(ctx context.Context) (map[string]string, bool) {
+	v, ok := ctx.Value(pathParamsKey{}).(map[string]string)
+	return v, ok
+}
+
+func withPathParams(ctx context.Context, pathParams map[string]string) context.Context {
+	return context.WithValue(ctx, pathParamsKey{}, pathParams)
+}
+
+// AllowedMethods returns the HTTP methods registered for the request path, sorted.
+// It is set by ServeMux before calling the RoutingErrorHandlerFunc with http.StatusMethodNotAllowed.
+func AllowedMethods(ctx context.Context) ([]string, bool) {
+	v, ok := ctx.Value(allowedMethodsKey{}).([]string)
+	return v, ok
+}
+
+func withAllowedMethods(ctx context.Context, methods []string) context.Context {
+	return context.WithValue(ctx, allowedMethodsKey{}, methods)
+}
+
+// FullDuplex reports whether the request body can be read while writing the response.
+// It is only set by ServeMux for the handlers registered with WithFullDuplex; the other
+// handlers must read the request body before writing the response.
+func FullDuplex(ctx context.Context) bool {
+	v, _ := ctx.Value(fullDuplexKey{}).(bool)
+	return v
+}
+
+func withFullDuplex(ctx context.Context, fullDuplex bool) context.Context {
+	return context.WithValue(ctx, fullDuplexKey{}, fullDuplex)
+}
+
+// clientStreaming reports whether the request is served by a handler registered with WithClientStreaming.
+func clientStreaming(ctx context.Context) bool {
+	v, _ := ctx.Value(clientStreamingKey{}).(bool)
+	return v
+}
+
+func withClientStreaming(ctx context.Context) context.Context {
+	return context.WithValue(ctx, clientStreamingKey{}, true)
+}
+
+// maxBodySize returns the maximum size of the request body to read into memory, see FullDuplexRequestBody.
+func maxBodySize(ctx context.Context) int64 {
+	if v, ok := ctx.Value(maxBodySizeKey{}).(int64); ok {
+		return v
+	}
+	return defaultMaxRequestTrailerBodySize
+}
+
+func withMaxBodySize(ctx context.Context, size int64) context.Context {
+	return context.WithValue(ctx, maxBodySizeKey{}, size)
+}
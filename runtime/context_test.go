@@ -3,13 +3,21 @@ package runtime_test
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -497,3 +505,102 @@ func TestAnnotateIncomingContext_SupportsCustomAnnotators(t *testing.T) {
 		t.Errorf("runtime.RPCMethod(annotated) failed with %s; want %s", m, expectedRPCName)
 	}
 }
+
+func TestAnnotateIncomingContext_ForwardsRequestTrailers(t *testing.T) {
+	for _, spec := range []struct {
+		name       string
+		opts       []runtime.HandleOption
+		maxSize    int64
+		want       []string
+		wantStatus int
+	}{
+		{
+			name: "client streaming",
+			opts: []runtime.HandleOption{runtime.WithClientStreaming()},
+			want: []string{"bar"},
+		},
+		{
+			name: "not client streaming",
+		},
+		{
+			name:       "body too large",
+			opts:       []runtime.HandleOption{runtime.WithClientStreaming()},
+			maxSize:    4,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+	} {
+		for _, http2 := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/http2=%t", spec.name, http2), func(t *testing.T) {
+				type result struct {
+					md   metadata.MD
+					body string
+					err  error
+				}
+				results := make(chan result, 1)
+				mux := runtime.NewServeMux(runtime.WithMaxRequestTrailerBodySize(spec.maxSize))
+				pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"echo"}, "")
+				if err != nil {
+					t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+				}
+				mux.Handle("POST", pat, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+					ctx, err := runtime.AnnotateIncomingContext(r.Context(), mux, r, "/example.Example/Example")
+					if err != nil {
+						results <- result{err: err}
+						return
+					}
+					md, _ := metadata.FromIncomingContext(ctx)
+					body, err := ioutil.ReadAll(r.Body)
+					results <- result{md: md, body: string(body), err: err}
+				}, spec.opts...)
+				s := httptest.NewUnstartedServer(mux)
+				if http2 {
+					s.EnableHTTP2 = true
+					s.StartTLS()
+				} else {
+					s.Start()
+				}
+				defer s.Close()
+
+				pr, pw := io.Pipe()
+				req, err := http.NewRequest("POST", s.URL+"/echo", pr)
+				if err != nil {
+					t.Fatalf("http.NewRequest failed with %v; want success", err)
+				}
+				req.Trailer = http.Header{"Grpc-Metadata-Foo": nil}
+				go func() {
+					if _, err := io.WriteString(pw, `{"id":"One"}`); err != nil && spec.wantStatus == 0 {
+						t.Errorf("pw.Write failed with %v; want success", err)
+					}
+					req.Trailer.Set("Grpc-Metadata-Foo", "bar")
+					pw.Close()
+				}()
+				resp, err := s.Client().Do(req)
+				if err != nil {
+					t.Fatalf("s.Client().Do failed with %v; want success", err)
+				}
+				resp.Body.Close()
+
+				got := <-results
+				if spec.wantStatus != 0 {
+					var httpErr *runtime.HTTPStatusError
+					if !errors.As(got.err, &httpErr) || httpErr.HTTPStatus != spec.wantStatus {
+						t.Fatalf("the handler failed with %v; want HTTP status %d", got.err, spec.wantStatus)
+					}
+					if got, want := status.Code(httpErr.Err), codes.InvalidArgument; got != want {
+						t.Errorf("status.Code(err) = %v; want %v", got, want)
+					}
+					return
+				}
+				if got.err != nil {
+					t.Fatalf("the handler failed with %v; want success", got.err)
+				}
+				if got := got.md.Get("foo"); !reflect.DeepEqual(got, spec.want) {
+					t.Errorf(`md.Get("foo") = %q; want %q`, got, spec.want)
+				}
+				if want := `{"id":"One"}`; got.body != want {
+					t.Errorf("r.Body = %q; want %q", got.body, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package runtime
+
+import (
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures how a ServeMux handles Cross-Origin Resource Sharing requests.
+// See https://fetch.spec.whatwg.org/#http-cors-protocol.
+type CORSOptions struct {
+	// AllowedOrigins lists the origins which may make cross-origin requests,
+	// e.g. "https://example.com". "*" allows any origin, without credentials.
+	AllowedOrigins []string
+	// AllowedHeaders lists the request headers which cross-origin requests may use.
+	// If empty, the headers accepted by the incoming header matcher are allowed,
+	// see WithIncomingHeaderMatcher.
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers which browsers may expose to scripts.
+	ExposedHeaders []string
+	// MaxAge is how long the result of a preflight request may be cached.
+	// The Access-Control-Max-Age header is omitted if it is zero.
+	MaxAge time.Duration
+	// AllowCredentials allows cross-origin requests to include credentials such as cookies.
+	// It only applies to the origins listed explicitly in AllowedOrigins: the origins matched
+	// by "*" get a literal "*" as Access-Control-Allow-Origin and no credentials.
+	AllowCredentials bool
+}
+
+// WithCORS returns a ServeMuxOption which enables the handling of Cross-Origin Resource Sharing.
+//
+// The ServeMux answers preflight requests itself, unless an OPTIONS handler was registered for the
+// requested path. The allowed methods are the ones registered for the patterns matching the path.
+// Other requests from an allowed origin get the CORS response headers added before being handled.
+func WithCORS(opts CORSOptions) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.cors = &opts
+	}
+}
+
+// isPreflight reports whether "r" is a CORS preflight request.
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get("Origin") != "" && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+func (o *CORSOptions) allowsOrigin(origin string) bool {
+	explicit, wildcard := o.matchOrigin(origin)
+	return explicit || wildcard
+}
+
+// matchOrigin reports whether "origin" is listed in AllowedOrigins, and otherwise whether it is
+// allowed by "*".
+func (o *CORSOptions) matchOrigin(origin string) (explicit, wildcard bool) {
+	for _, allowed := range o.AllowedOrigins {
+		if strings.EqualFold(allowed, origin) {
+			return true, false
+		}
+		if allowed == "*" {
+			wildcard = true
+		}
+	}
+	return false, wildcard
+}
+
+// setOriginHeaders sets the headers shared by preflight and actual cross-origin responses.
+// It reports whether the request origin is allowed.
+func (o *CORSOptions) setOriginHeaders(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	w.Header().Add("Vary", "Origin")
+	if origin == "" {
+		return false
+	}
+	explicit, wildcard := o.matchOrigin(origin)
+	switch {
+	case explicit:
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if o.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+	case wildcard:
+		// Echoing any origin with credentials would let every site make credentialed requests.
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	default:
+		return false
+	}
+	return true
+}
+
+// handleActual adds the CORS headers to the response of a cross-origin request which is not a preflight.
+func (o *CORSOptions) handleActual(w http.ResponseWriter, r *http.Request) {
+	if !o.setOriginHeaders(w, r) {
+		return
+	}
+	if len(o.ExposedHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(o.ExposedHeaders, ", "))
+	}
+}
+
+// handlePreflight answers a preflight request for a path served by "methods".
+func (o *CORSOptions) handlePreflight(w http.ResponseWriter, r *http.Request, mux *ServeMux, methods []string) {
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+	if o.setOriginHeaders(w, r) {
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if headers := o.allowedHeaders(mux, r.Header.Get("Access-Control-Request-Headers")); len(headers) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		}
+		if o.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(o.MaxAge/time.Second)))
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowedHeaders returns the subset of the comma separated "requested" headers which are allowed.
+func (o *CORSOptions) allowedHeaders(mux *ServeMux, requested string) []string {
+	var headers []string
+	for _, h := range strings.Split(requested, ",") {
+		h = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(h))
+		if h != "" && o.allowsHeader(mux, h) {
+			headers = append(headers, h)
+		}
+	}
+	return headers
+}
+
+func (o *CORSOptions) allowsHeader(mux *ServeMux, key string) bool {
+	if len(o.AllowedHeaders) > 0 {
+		for _, allowed := range o.AllowedHeaders {
+			if allowed == "*" || strings.EqualFold(allowed, key) {
+				return true
+			}
+		}
+		return false
+	}
+	switch key {
+	// Content-Type selects the marshaler and Authorization is always forwarded.
+	case "Accept", "Accept-Language", "Content-Language", "Content-Type", "Authorization":
+		return true
+	}
+	_, ok := mux.incomingHeaderMatcher(key)
+	return ok
+}
@@ -0,0 +1,162 @@
+package runtime_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestWithCORS(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		opts    runtime.CORSOptions
+		method  string
+		path    string
+		headers map[string]string
+
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:   "preflight",
+			opts:   runtime.CORSOptions{AllowedOrigins: []string{"https://example.com"}, MaxAge: time.Hour},
+			method: "OPTIONS",
+			path:   "/v1/items/foo",
+			headers: map[string]string{
+				"Origin":                         "https://example.com",
+				"Access-Control-Request-Method":  "DELETE",
+				"Access-Control-Request-Headers": "content-type, grpc-metadata-foo, x-unknown",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Methods":     "DELETE, GET",
+				"Access-Control-Allow-Headers":     "Content-Type, Grpc-Metadata-Foo",
+				"Access-Control-Max-Age":           "3600",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:   "preflight with explicit headers and credentials",
+			opts:   runtime.CORSOptions{AllowedOrigins: []string{"https://example.com"}, AllowedHeaders: []string{"X-Unknown"}, AllowCredentials: true},
+			method: "OPTIONS",
+			path:   "/v1/items",
+			headers: map[string]string{
+				"Origin":                         "https://example.com",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "content-type, x-unknown",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Methods":     "POST",
+				"Access-Control-Allow-Headers":     "X-Unknown",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Max-Age":           "",
+			},
+		},
+		{
+			name:   "preflight from wildcard origin with credentials",
+			opts:   runtime.CORSOptions{AllowedOrigins: []string{"https://example.org", "*"}, AllowCredentials: true},
+			method: "OPTIONS",
+			path:   "/v1/items",
+			headers: map[string]string{
+				"Origin":                        "https://example.com",
+				"Access-Control-Request-Method": "POST",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Methods":     "POST",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:   "actual request from wildcard origin with credentials",
+			opts:   runtime.CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			method: "GET",
+			path:   "/v1/items/foo",
+			headers: map[string]string{
+				"Origin": "https://example.com",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:   "preflight from disallowed origin",
+			opts:   runtime.CORSOptions{AllowedOrigins: []string{"https://example.com"}},
+			method: "OPTIONS",
+			path:   "/v1/items",
+			headers: map[string]string{
+				"Origin":                        "https://example.org",
+				"Access-Control-Request-Method": "POST",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		{
+			name:   "preflight for unknown path",
+			opts:   runtime.CORSOptions{AllowedOrigins: []string{"*"}},
+			method: "OPTIONS",
+			path:   "/v1/unknown",
+			headers: map[string]string{
+				"Origin":                        "https://example.com",
+				"Access-Control-Request-Method": "POST",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "actual request",
+			opts:   runtime.CORSOptions{AllowedOrigins: []string{"https://example.com"}, ExposedHeaders: []string{"Grpc-Metadata-Foo"}},
+			method: "GET",
+			path:   "/v1/items/foo",
+			headers: map[string]string{
+				"Origin": "https://example.com",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "https://example.com",
+				"Access-Control-Expose-Headers": "Grpc-Metadata-Foo",
+				"Vary":                          "Origin",
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(runtime.WithCORS(spec.opts))
+			for _, route := range []struct{ method, path string }{
+				{"GET", "/v1/items/{id}"},
+				{"DELETE", "/v1/items/{id}"},
+				{"POST", "/v1/items"},
+			} {
+				if err := mux.HandlePath(route.method, route.path, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {}); err != nil {
+					t.Fatalf("mux.HandlePath(%q, %q) failed with %v; want success", route.method, route.path, err)
+				}
+			}
+
+			r := httptest.NewRequest(spec.method, spec.path, nil)
+			for k, v := range spec.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			if got, want := w.Code, spec.wantStatus; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+			for k, want := range spec.wantHeaders {
+				if got := w.Header().Get(k); got != want {
+					t.Errorf("w.Header().Get(%q) = %q; want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
@@ -2,10 +2,15 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/status"
@@ -18,6 +23,8 @@ type ErrorHandlerFunc func(context.Context, *ServeMux, Marshaler, http.ResponseW
 type StreamErrorHandlerFunc func(context.Context, error) *status.Status
 
 // RoutingErrorHandlerFunc is the signature used to configure error handling for routing errors.
+// For http.StatusMethodNotAllowed, the methods registered for the request path can be
+// retrieved from the context with AllowedMethods.
 type RoutingErrorHandlerFunc func(context.Context, *ServeMux, Marshaler, http.ResponseWriter, *http.Request, int)
 
 // HTTPStatusError is the error to use when needing to provide a different HTTP status code for an error
@@ -76,20 +83,46 @@ func HTTPStatusFromCode(code codes.Code) int {
 	return http.StatusInternalServerError
 }
 
+// HTTPStatusFromCode converts a gRPC error code into the HTTP response status configured for the
+// RPC method of "ctx" with WithMethodHTTPStatusMapping, or for all methods with WithHTTPStatusMapping.
+// It falls back to the package level HTTPStatusFromCode.
+func (s *ServeMux) HTTPStatusFromCode(ctx context.Context, code codes.Code) int {
+	if rpcMethod, ok := RPCMethod(ctx); ok {
+		if st, ok := s.methodHTTPStatusMapping[rpcMethod][code]; ok {
+			return st
+		}
+	}
+	if st, ok := s.httpStatusMapping[code]; ok {
+		return st
+	}
+	return HTTPStatusFromCode(code)
+}
+
 // HTTPError uses the mux-configured error handler.
+// The status of the errors of WebSocket connections is sent in their Close frame instead, see WithWebSocket.
 func HTTPError(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, err error) {
-	mux.errorHandler(ctx, mux, marshaler, w, r, err)
+	if ww, ok := w.(*webSocketResponseWriter); ok {
+		if md, ok := ServerMetadataFromContext(ctx); ok {
+			ww.setHeaderMetadata(mux, md)
+			ww.setTrailerMetadata(ctx, md)
+		}
+		ww.setError(err)
+		return
+	}
+	mux.errorHandler(ctx, mux, marshaler, w, r, decompressedBodyError(r, err))
 }
 
 // DefaultHTTPErrorHandler is the default error handler.
-// If "err" is a gRPC Status, the function replies with the status code mapped by HTTPStatusFromCode.
+// If "err" is a gRPC Status, the function replies with the status code mapped by ServeMux.HTTPStatusFromCode.
 // If "err" is a HTTPStatusError, the function replies with the status code provide by that struct. This is
 // intended to allow passing through of specific statuses via the function set via WithRoutingErrorHandler
 // for the ServeMux constructor to handle edge cases which the standard mappings in HTTPStatusFromCode
 // are insufficient for.
 // If otherwise, it replies with http.StatusInternalServerError.
 //
-// The response body written by this function is a Status message marshaled by the Marshaler.
+// The response body written by this function is a Status message marshaled by the Marshaler, or a
+// Problem Details document (RFC 7807) if the ServeMux was created with WithProblemDetails or the request
+// accepts application/problem+json, see NewProblemDetails.
 func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 	// return Internal when Marshal failed
 	const fallback = `{"code": 13, "message": "failed to marshal error message"}`
@@ -105,14 +138,34 @@ func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marsh
 	w.Header().Del("Trailer")
 	w.Header().Del("Transfer-Encoding")
 
-	contentType := marshaler.ContentType(pb)
+	st := mux.HTTPStatusFromCode(ctx, s.Code())
+	if mux.errorDetailsMapping {
+		st = handleErrorDetails(w, s, st)
+	}
+	if customStatus != nil {
+		st = customStatus.HTTPStatus
+	}
+
+	var (
+		contentType string
+		buf         []byte
+		merr        error
+	)
+	if mux.problemDetails || acceptsProblemDetails(r) {
+		contentType = MIMEProblemJSON
+		buf, merr = json.Marshal(NewProblemDetails(r, st, s))
+	} else {
+		contentType = marshaler.ContentType(pb)
+		if buf, merr = marshaler.Marshal(pb); merr == nil {
+			buf = frameRecord(marshaler, buf)
+		}
+	}
 	w.Header().Set("Content-Type", contentType)
 
 	if s.Code() == codes.Unauthenticated {
 		w.Header().Set("WWW-Authenticate", s.Message())
 	}
 
-	buf, merr := marshaler.Marshal(pb)
 	if merr != nil {
 		grpclog.Infof("Failed to marshal error message %q: %v", s, merr)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -141,11 +194,6 @@ func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marsh
 		w.Header().Set("Transfer-Encoding", "chunked")
 	}
 
-	st := HTTPStatusFromCode(s.Code())
-	if customStatus != nil {
-		st = customStatus.HTTPStatus
-	}
-
 	w.WriteHeader(st)
 	if _, err := w.Write(buf); err != nil {
 		grpclog.Infof("Failed to write response: %v", err)
@@ -156,6 +204,51 @@ func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marsh
 	}
 }
 
+// handleErrorDetails sets the response headers derived from the details of "s" and returns the
+// status code to reply with instead of "httpStatus", see WithErrorDetailsMapping.
+func handleErrorDetails(w http.ResponseWriter, s *status.Status, httpStatus int) int {
+	var (
+		retryDelay          time.Duration
+		hasRetryDelay       bool
+		quotaFailure        bool
+		preconditionFailure bool
+	)
+	for _, d := range s.Details() {
+		switch d := d.(type) {
+		case *errdetails.RetryInfo:
+			if delay := d.GetRetryDelay(); delay.IsValid() {
+				retryDelay, hasRetryDelay = delay.AsDuration(), true
+			}
+		case *errdetails.QuotaFailure:
+			quotaFailure = true
+		case *errdetails.PreconditionFailure:
+			preconditionFailure = true
+		}
+	}
+	if preconditionFailure {
+		httpStatus = http.StatusPreconditionFailed
+	}
+
+	var retryAfter string
+	if hasRetryDelay {
+		if retryDelay < 0 {
+			retryDelay = 0
+		}
+		// Retry-After is a number of seconds, round up so that clients do not retry too early.
+		retryAfter = strconv.FormatInt(int64((retryDelay+time.Second-1)/time.Second), 10)
+		w.Header().Set("Retry-After", retryAfter)
+	}
+	if quotaFailure {
+		httpStatus = http.StatusTooManyRequests
+		// See https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/.
+		w.Header().Set("RateLimit-Remaining", "0")
+		if hasRetryDelay {
+			w.Header().Set("RateLimit-Reset", retryAfter)
+		}
+	}
+	return httpStatus
+}
+
 func DefaultStreamErrorHandler(_ context.Context, err error) *status.Status {
 	return status.Convert(err)
 }
@@ -164,17 +257,30 @@ func DefaultStreamErrorHandler(_ context.Context, err error) *status.Status {
 // By default http error codes mapped on the following error codes:
 //   NotFound -> grpc.NotFound
 //   StatusBadRequest -> grpc.InvalidArgument
-//   MethodNotAllowed -> grpc.Unimplemented
+//   MethodNotAllowed -> grpc.Unimplemented, responded with 405 and an Allow header
+//   NotAcceptable -> grpc.Unimplemented, responded with 406
+//   UnsupportedMediaType -> grpc.Unimplemented, responded with 415
 //   Other -> grpc.Internal, method is not expecting to be called for anything else
 func DefaultRoutingErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
-	sterr := status.Error(codes.Internal, "Unexpected routing error")
+	var sterr error = status.Error(codes.Internal, "Unexpected routing error")
 	switch httpStatus {
 	case http.StatusBadRequest:
 		sterr = status.Error(codes.InvalidArgument, http.StatusText(httpStatus))
 	case http.StatusMethodNotAllowed:
-		sterr = status.Error(codes.Unimplemented, http.StatusText(httpStatus))
+		if methods, ok := AllowedMethods(ctx); ok {
+			w.Header().Set("Allow", strings.Join(methods, ", "))
+		}
+		sterr = &HTTPStatusError{
+			HTTPStatus: httpStatus,
+			Err:        status.Error(codes.Unimplemented, http.StatusText(httpStatus)),
+		}
 	case http.StatusNotFound:
 		sterr = status.Error(codes.NotFound, http.StatusText(httpStatus))
+	case http.StatusNotAcceptable, http.StatusUnsupportedMediaType:
+		sterr = &HTTPStatusError{
+			HTTPStatus: httpStatus,
+			Err:        status.Error(codes.Unimplemented, http.StatusText(httpStatus)),
+		}
 	}
 	mux.errorHandler(ctx, mux, marshaler, w, r, sterr)
 }
@@ -8,12 +8,15 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	statuspb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestDefaultHTTPError(t *testing.T) {
@@ -107,3 +110,176 @@ func TestDefaultHTTPError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorDetailsMapping(t *testing.T) {
+	withDetails := func(code codes.Code, details ...protoiface.MessageV1) error {
+		s, err := status.New(code, "error").WithDetails(details...)
+		if err != nil {
+			t.Fatalf("status.WithDetails failed with %v; want success", err)
+		}
+		return s.Err()
+	}
+
+	for _, spec := range []struct {
+		name        string
+		err         error
+		disabled    bool
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:       "retry info",
+			err:        withDetails(codes.Unavailable, &errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)}),
+			wantStatus: http.StatusServiceUnavailable,
+			wantHeaders: map[string]string{
+				"Retry-After":         "2",
+				"RateLimit-Remaining": "",
+			},
+		},
+		{
+			name: "quota failure",
+			err: withDetails(codes.ResourceExhausted,
+				&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{Subject: "project:1"}}},
+				&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Second)},
+			),
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"Retry-After":         "30",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "30",
+			},
+		},
+		{
+			name:       "quota failure without retry info",
+			err:        withDetails(codes.PermissionDenied, &errdetails.QuotaFailure{}),
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"Retry-After":         "",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "",
+			},
+		},
+		{
+			name:       "precondition failure",
+			err:        withDetails(codes.FailedPrecondition, &errdetails.PreconditionFailure{}),
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "quota failure wins over precondition failure",
+			err:        withDetails(codes.FailedPrecondition, &errdetails.PreconditionFailure{}, &errdetails.QuotaFailure{}),
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "http status error",
+			err: &runtime.HTTPStatusError{
+				HTTPStatus: http.StatusConflict,
+				Err:        withDetails(codes.FailedPrecondition, &errdetails.PreconditionFailure{}),
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "disabled",
+			err:        withDetails(codes.FailedPrecondition, &errdetails.PreconditionFailure{}, &errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)}),
+			disabled:   true,
+			wantStatus: http.StatusBadRequest,
+			wantHeaders: map[string]string{
+				"Retry-After": "",
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var opts []runtime.ServeMuxOption
+			if !spec.disabled {
+				opts = append(opts, runtime.WithErrorDetailsMapping())
+			}
+			mux := runtime.NewServeMux(opts...)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			runtime.HTTPError(context.Background(), mux, &runtime.JSONPb{}, w, r, spec.err)
+
+			if got, want := w.Code, spec.wantStatus; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+			for k, want := range spec.wantHeaders {
+				if got := w.Header().Get(k); got != want {
+					t.Errorf("w.Header().Get(%q) = %q; want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPStatusMapping(t *testing.T) {
+	mux := runtime.NewServeMux(
+		runtime.WithHTTPStatusMapping(map[codes.Code]int{
+			codes.FailedPrecondition: http.StatusConflict,
+			codes.Aborted:            http.StatusServiceUnavailable,
+		}),
+		runtime.WithMethodHTTPStatusMapping("/example.Library/CreateBook", map[codes.Code]int{
+			codes.FailedPrecondition: http.StatusUnprocessableEntity,
+		}),
+	)
+
+	for _, spec := range []struct {
+		name       string
+		rpcMethod  string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "mux mapping",
+			rpcMethod:  "/example.Library/GetBook",
+			err:        status.Error(codes.FailedPrecondition, "error"),
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "method mapping",
+			rpcMethod:  "/example.Library/CreateBook",
+			err:        status.Error(codes.FailedPrecondition, "error"),
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "method falls back to mux mapping",
+			rpcMethod:  "/example.Library/CreateBook",
+			err:        status.Error(codes.Aborted, "error"),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "default mapping",
+			rpcMethod:  "/example.Library/CreateBook",
+			err:        status.Error(codes.NotFound, "error"),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "no method",
+			err:        status.Error(codes.FailedPrecondition, "error"),
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:      "http status error",
+			rpcMethod: "/example.Library/CreateBook",
+			err: &runtime.HTTPStatusError{
+				HTTPStatus: http.StatusMethodNotAllowed,
+				Err:        status.Error(codes.FailedPrecondition, "error"),
+			},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/books", nil)
+			ctx := context.Background()
+			if spec.rpcMethod != "" {
+				var err error
+				ctx, err = runtime.AnnotateContext(ctx, mux, r, spec.rpcMethod)
+				if err != nil {
+					t.Fatalf("runtime.AnnotateContext failed with %v; want success", err)
+				}
+			}
+			w := httptest.NewRecorder()
+			runtime.HTTPError(ctx, mux, &runtime.JSONPb{}, w, r, spec.err)
+			if got, want := w.Code, spec.wantStatus; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+		})
+	}
+}
@@ -7,16 +7,23 @@ import (
 	"net/http"
 	"net/textproto"
 	"strings"
+	"time"
 
 	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
 // ForwardResponseStream forwards the stream from gRPC server to REST client.
 func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, req *http.Request, recv func() (proto.Message, error), opts ...func(context.Context, http.ResponseWriter, proto.Message) error) {
+	if ww, ok := w.(*webSocketResponseWriter); ok {
+		forwardResponseWebSocket(ctx, mux, marshaler, ww, recv, opts)
+		return
+	}
+
 	f, ok := w.(http.Flusher)
 	if !ok {
 		grpclog.Infof("Flush not supported in %T", w)
@@ -32,6 +39,15 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 	}
 	handleForwardResponseServerMetadata(w, mux, md)
 
+	w, finish := mux.compressResponse(w, req)
+	defer finish()
+	f = w.(http.Flusher)
+
+	if !mux.disableServerSentEvents && acceptsEventStream(req) {
+		forwardResponseEventStream(ctx, mux, marshaler, w, f, req, recv, opts)
+		return
+	}
+
 	w.Header().Set("Transfer-Encoding", "chunked")
 	if err := handleForwardResponseOptions(ctx, w, nil, opts); err != nil {
 		HTTPError(ctx, mux, marshaler, w, req, err)
@@ -39,24 +55,68 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 	}
 
 	var delimiter []byte
+	_, lengthDelimited := marshaler.(LengthDelimited)
 	if d, ok := marshaler.(Delimited); ok {
 		delimiter = d.Delimiter()
-	} else {
+	} else if !lengthDelimited {
 		delimiter = []byte("\n")
 	}
 
-	var wroteHeader bool
+	sf := &streamFraming{marshaler: marshaler, envelope: mux.streamEnvelopeFor(marshaler), delimiter: delimiter}
+
+	keepAlive := streamKeepAliveChunk(marshaler, delimiter)
+	var keepAliveInterval time.Duration
+	if keepAlive != nil {
+		keepAliveInterval = mux.streamKeepAlive
+	}
+	sc := mux.newStreamControl(w, recv, keepAliveInterval)
+	defer sc.stop()
+
+	// RFC 7230 https://tools.ietf.org/html/rfc7230#section-4.1.2
+	// The trailer metadata of a stream is only known once the stream ended, after
+	// the header was written, so only the trailers known so far are declared.
+	if requestAcceptsTrailers(req) {
+		handleForwardResponseTrailerHeader(w, md)
+		defer func() {
+			if sc.ended {
+				handleForwardResponseStreamTrailer(ctx, w, md)
+			}
+		}()
+	}
+
+	var wroteHeader, httpBodyStream bool
 	for {
-		resp, err := recv()
+		resp, err := sc.recv()
 		if err == io.EOF {
+			end := sf.envelope.End(marshaler, sf.chunks)
+			if end == nil || httpBodyStream {
+				return
+			}
+			if !wroteHeader {
+				w.Header().Set("Content-Type", marshaler.ContentType(nil))
+			}
+			if err := sc.write(frameRecord(marshaler, end), delimiter); err != nil {
+				grpclog.Infof("Failed to send the end of the stream: %v", err)
+			}
 			return
 		}
+		if err == errStreamKeepAlive {
+			if !wroteHeader {
+				w.Header().Set("Content-Type", marshaler.ContentType(nil))
+			}
+			wroteHeader = true
+			if err := sc.write(keepAlive); err != nil {
+				handleForwardResponseStreamWriteError(ctx, sf, sc, req, mux, err)
+				return
+			}
+			continue
+		}
 		if err != nil {
-			handleForwardResponseStreamError(ctx, wroteHeader, marshaler, w, req, mux, err, delimiter)
+			handleForwardResponseStreamError(ctx, wroteHeader, sf, sc, req, mux, err)
 			return
 		}
 		if err := handleForwardResponseOptions(ctx, w, resp, opts); err != nil {
-			handleForwardResponseStreamError(ctx, wroteHeader, marshaler, w, req, mux, err, delimiter)
+			handleForwardResponseStreamError(ctx, wroteHeader, sf, sc, req, mux, err)
 			return
 		}
 
@@ -68,36 +128,43 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 		httpBody, isHTTPBody := resp.(*httpbody.HttpBody)
 		switch {
 		case resp == nil:
-			buf, err = marshaler.Marshal(errorChunk(status.New(codes.Internal, "empty response")))
+			buf, err = sf.envelope.Error(marshaler, sf.chunks, status.New(codes.Internal, "empty response"))
 		case isHTTPBody:
 			buf = httpBody.GetData()
+			httpBodyStream = true
 		default:
-			result := map[string]interface{}{"result": resp}
+			var result interface{} = resp
 			if rb, ok := resp.(responseBody); ok {
-				result["result"] = rb.XXX_ResponseBody()
+				result = rb.XXX_ResponseBody()
 			}
-
-			buf, err = marshaler.Marshal(result)
+			buf, err = sf.envelope.Message(marshaler, sf.chunks, result)
 		}
 
 		if err != nil {
 			grpclog.Infof("Failed to marshal response chunk: %v", err)
-			handleForwardResponseStreamError(ctx, wroteHeader, marshaler, w, req, mux, err, delimiter)
+			handleForwardResponseStreamError(ctx, wroteHeader, sf, sc, req, mux, err)
 			return
 		}
-		if _, err = w.Write(buf); err != nil {
-			grpclog.Infof("Failed to send response chunk: %v", err)
-			return
+		if !isHTTPBody {
+			sf.chunks++
 		}
 		wroteHeader = true
-		if _, err = w.Write(delimiter); err != nil {
-			grpclog.Infof("Failed to send delimiter chunk: %v", err)
+		if err := sc.write(frameRecord(marshaler, buf), delimiter); err != nil {
+			handleForwardResponseStreamWriteError(ctx, sf, sc, req, mux, err)
 			return
 		}
-		f.Flush()
 	}
 }
 
+// streamFraming frames the chunks of a stream forwarded by ForwardResponseStream.
+type streamFraming struct {
+	marshaler Marshaler
+	envelope  StreamEnvelope
+	delimiter []byte
+	// chunks is the number of chunks framed by envelope so far.
+	chunks int
+}
+
 func handleForwardResponseServerMetadata(w http.ResponseWriter, mux *ServeMux, md ServerMetadata) {
 	for k, vs := range md.HeaderMD {
 		if h, ok := mux.outgoingHeaderMatcher(k); ok {
@@ -124,6 +191,24 @@ func handleForwardResponseTrailer(w http.ResponseWriter, md ServerMetadata) {
 	}
 }
 
+// handleForwardResponseStreamTrailer adds the trailer metadata of a stream which ended to the
+// trailers of the response, those which were not declared with the http.TrailerPrefix.
+func handleForwardResponseStreamTrailer(ctx context.Context, w http.ResponseWriter, md ServerMetadata) {
+	trailer := md.TrailerMD
+	if f, ok := streamTrailerFromContext(ctx); ok {
+		trailer = metadata.Join(trailer, f())
+	}
+	for k, vs := range trailer {
+		tKey := textproto.CanonicalMIMEHeaderKey(MetadataTrailerPrefix + k)
+		if _, declared := md.TrailerMD[k]; !declared {
+			tKey = http.TrailerPrefix + tKey
+		}
+		for _, v := range vs {
+			w.Header().Add(tKey, v)
+		}
+	}
+}
+
 // responseBody interface contains method for getting field for marshaling to the response body
 // this method is generated for response struct from the value of `response_body` in the `google.api.HttpRule`
 type responseBody interface {
@@ -136,9 +221,16 @@ func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marsha
 	if !ok {
 		grpclog.Infof("Failed to extract ServerMetadata from context")
 	}
+	if ww, ok := w.(*webSocketResponseWriter); ok {
+		forwardResponseMessageWebSocket(ctx, mux, marshaler, ww, resp, md, opts)
+		return
+	}
 
 	handleForwardResponseServerMetadata(w, mux, md)
 
+	w, finish := mux.compressResponse(w, req)
+	defer finish()
+
 	// RFC 7230 https://tools.ietf.org/html/rfc7230#section-4.1.2
 	// Unless the request includes a TE header field indicating "trailers"
 	// is acceptable, as described in Section 4.3, a server SHOULD NOT
@@ -151,8 +243,6 @@ func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marsha
 		w.Header().Set("Transfer-Encoding", "chunked")
 	}
 
-	handleForwardResponseTrailerHeader(w, md)
-
 	contentType := marshaler.ContentType(resp)
 	w.Header().Set("Content-Type", contentType)
 
@@ -173,7 +263,7 @@ func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marsha
 		return
 	}
 
-	if _, err = w.Write(buf); err != nil {
+	if _, err = w.Write(frameRecord(marshaler, buf)); err != nil {
 		grpclog.Infof("Failed to write response: %v", err)
 	}
 
@@ -200,26 +290,57 @@ func handleForwardResponseOptions(ctx context.Context, w http.ResponseWriter, re
 	return nil
 }
 
-func handleForwardResponseStreamError(ctx context.Context, wroteHeader bool, marshaler Marshaler, w http.ResponseWriter, req *http.Request, mux *ServeMux, err error, delimiter []byte) {
+func handleForwardResponseStreamError(ctx context.Context, wroteHeader bool, sf *streamFraming, sc *streamControl, req *http.Request, mux *ServeMux, err error) {
 	st := mux.streamErrorHandler(ctx, err)
-	msg := errorChunk(st)
 	if !wroteHeader {
-		w.Header().Set("Content-Type", marshaler.ContentType(msg))
-		w.WriteHeader(HTTPStatusFromCode(st.Code()))
+		sc.w.Header().Set("Content-Type", sf.marshaler.ContentType(st.Proto()))
+		sc.w.WriteHeader(mux.HTTPStatusFromCode(ctx, st.Code()))
 	}
-	buf, merr := marshaler.Marshal(msg)
+	buf, merr := sf.envelope.Error(sf.marshaler, sf.chunks, st)
 	if merr != nil {
 		grpclog.Infof("Failed to marshal an error: %v", merr)
 		return
 	}
-	if _, werr := w.Write(buf); werr != nil {
+	sf.chunks++
+	chunks := [][]byte{frameRecord(sf.marshaler, buf), sf.delimiter}
+	if end := sf.envelope.End(sf.marshaler, sf.chunks); end != nil {
+		chunks = append(chunks, frameRecord(sf.marshaler, end), sf.delimiter)
+	}
+	if werr := sc.writeError(chunks...); werr != nil {
 		grpclog.Infof("Failed to notify error to client: %v", werr)
-		return
 	}
-	if _, derr := w.Write(delimiter); derr != nil {
-		grpclog.Infof("Failed to send delimiter chunk: %v", err)
-		return
+}
+
+// handleForwardResponseStreamWriteError handles the error of writing a chunk of a stream.
+// The stream ends with an error chunk if the write timeout expired, see streamControl.writeError.
+func handleForwardResponseStreamWriteError(ctx context.Context, sf *streamFraming, sc *streamControl, req *http.Request, mux *ServeMux, err error) {
+	grpclog.Infof("Failed to send response chunk: %v", err)
+	if err == errStreamWriteTimeout {
+		handleForwardResponseStreamError(ctx, true, sf, sc, req, mux, err)
+	}
+}
+
+// marshalStreamMessage marshals a message of a stream without the {"result": ...} wrapper,
+// for the transports delimiting the messages themselves.
+func marshalStreamMessage(marshaler Marshaler, resp proto.Message) ([]byte, error) {
+	switch resp := resp.(type) {
+	case *httpbody.HttpBody:
+		return resp.GetData(), nil
+	case responseBody:
+		return marshaler.Marshal(resp.XXX_ResponseBody())
+	default:
+		return marshaler.Marshal(resp)
+	}
+}
+
+// frameRecord returns "record" preceded by its length prefix if "marshaler" is LengthDelimited,
+// "record" itself otherwise.
+func frameRecord(marshaler Marshaler, record []byte) []byte {
+	ld, ok := marshaler.(LengthDelimited)
+	if !ok {
+		return record
 	}
+	return append(ld.AppendLengthPrefix(nil, len(record)), record...)
 }
 
 func errorChunk(st *status.Status) map[string]proto.Message {
@@ -2,15 +2,18 @@ package runtime_test
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
@@ -319,3 +322,109 @@ func TestForwardResponseMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestForwardResponseStreamHTTPStatusMapping(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithMethodHTTPStatusMapping("/example.Library/ListBooks", map[codes.Code]int{
+		codes.OutOfRange: http.StatusRequestedRangeNotSatisfiable,
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+	ctx, err := runtime.AnnotateContext(context.Background(), mux, req, "/example.Library/ListBooks")
+	if err != nil {
+		t.Fatalf("runtime.AnnotateContext failed with %v; want success", err)
+	}
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+	recv := func() (proto.Message, error) {
+		return nil, status.Error(codes.OutOfRange, "out of range")
+	}
+	resp := httptest.NewRecorder()
+	runtime.ForwardResponseStream(ctx, mux, &runtime.JSONPb{}, resp, req, recv)
+	if got, want := resp.Code, http.StatusRequestedRangeNotSatisfiable; got != want {
+		t.Errorf("resp.Code = %d; want %d", got, want)
+	}
+}
+
+func TestForwardResponseStreamTrailers(t *testing.T) {
+	for _, spec := range []struct {
+		name        string
+		te          string
+		md          runtime.ServerMetadata
+		wantDeclare []string
+		want        http.Header
+	}{
+		{
+			name: "stream trailer",
+			te:   "trailers",
+			want: http.Header{"Grpc-Trailer-Foo": {"bar"}},
+		},
+		{
+			name:        "declared trailer",
+			te:          "trailers",
+			md:          runtime.ServerMetadata{TrailerMD: metadata.Pairs("baz", "qux")},
+			wantDeclare: []string{"Grpc-Trailer-Baz"},
+			want:        http.Header{"Grpc-Trailer-Foo": {"bar"}, "Grpc-Trailer-Baz": {"qux"}},
+		},
+		{
+			name: "trailers not accepted",
+			md:   runtime.ServerMetadata{TrailerMD: metadata.Pairs("baz", "qux")},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var sent bool
+			recv := func() (proto.Message, error) {
+				if sent {
+					return nil, io.EOF
+				}
+				sent = true
+				return &pb.SimpleMessage{Id: "One"}, nil
+			}
+			req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+			if spec.te != "" {
+				req.Header.Set("TE", spec.te)
+			}
+			resp := httptest.NewRecorder()
+			ctx := runtime.NewServerMetadataContext(context.Background(), spec.md)
+			ctx = runtime.NewStreamTrailerContext(ctx, func() metadata.MD { return metadata.Pairs("foo", "bar") })
+			runtime.ForwardResponseStream(ctx, runtime.NewServeMux(), &runtime.JSONPb{}, resp, req, recv)
+
+			w := resp.Result()
+			if got := w.Header.Values("Trailer"); !reflect.DeepEqual(got, spec.wantDeclare) {
+				t.Errorf(`w.Header.Values("Trailer") = %q; want %q`, got, spec.wantDeclare)
+			}
+			if len(w.Trailer) != len(spec.want) {
+				t.Errorf("w.Trailer = %v; want %v", w.Trailer, spec.want)
+			}
+			for k, v := range spec.want {
+				if got := w.Trailer.Values(k); !reflect.DeepEqual(got, v) {
+					t.Errorf("w.Trailer.Values(%q) = %q; want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestForwardResponseMessageTrailers(t *testing.T) {
+	for _, te := range []string{"", "trailers"} {
+		t.Run(fmt.Sprintf("TE=%q", te), func(t *testing.T) {
+			req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+			if te != "" {
+				req.Header.Set("TE", te)
+			}
+			resp := httptest.NewRecorder()
+			ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{TrailerMD: metadata.Pairs("foo", "bar")})
+			runtime.ForwardResponseMessage(ctx, runtime.NewServeMux(), &runtime.JSONPb{}, resp, req, &pb.SimpleMessage{Id: "One"})
+
+			w := resp.Result()
+			var wantDeclare []string
+			want := ""
+			if te != "" {
+				wantDeclare, want = []string{"Grpc-Trailer-Foo"}, "bar"
+			}
+			if got := w.Header.Values("Trailer"); !reflect.DeepEqual(got, wantDeclare) {
+				t.Errorf(`w.Header.Values("Trailer") = %q; want %q`, got, wantDeclare)
+			}
+			if got := w.Trailer.Get("Grpc-Trailer-Foo"); got != want {
+				t.Errorf(`w.Trailer.Get("Grpc-Trailer-Foo") = %q; want %q`, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// WithAutomaticHEAD returns a ServeMuxOption which makes the ServeMux answer HEAD requests
+// with the handlers registered for GET, unless a HEAD handler matches the request.
+//
+// The GET handler runs as usual, but the body it writes is discarded: only the status code,
+// the headers and a Content-Length header holding the size of the discarded body are sent.
+// The handlers registered with WithServerStreaming are skipped, as they would only send
+// the headers once the stream ends: a HEAD request matching no other handler is answered
+// with http.StatusMethodNotAllowed.
+func WithAutomaticHEAD() ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.automaticHEAD = true
+	}
+}
+
+// headResponseWriter serves a HEAD request with a GET handler. It holds the status code
+// back until the handler returns, so that the Content-Length header can be set.
+type headResponseWriter struct {
+	http.ResponseWriter
+	status int
+	length int64
+}
+
+func (w *headResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	w.length += int64(len(b))
+	return len(b), nil
+}
+
+// Flush does nothing, as flushing would send the headers before the Content-Length is known.
+func (w *headResponseWriter) Flush() {}
+
+// finish writes the headers to the underlying http.ResponseWriter.
+func (w *headResponseWriter) finish() {
+	w.WriteHeader(http.StatusOK)
+	if w.status >= http.StatusOK && w.status != http.StatusNoContent && w.status != http.StatusNotModified &&
+		w.Header().Get("Content-Length") == "" {
+		w.Header().Set("Content-Length", strconv.FormatInt(w.length, 10))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
@@ -0,0 +1,239 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var errInProcessHeaderSent = status.Error(codes.Internal, "transport: the header was already sent")
+
+// InProcessStream implements grpc.ServerStream for the streaming methods called in process.
+// It should only be used by the generated files to support streaming calls in the
+// Register{Service}HandlerServer functions.
+//
+// The method runs in its own goroutine, see Start. The request messages it receives are
+// decoded from the body of the HTTP request, and the response messages it sends are
+// returned by Recv, to be forwarded to the HTTP response.
+type InProcessStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	dec    Decoder
+
+	msgs       chan proto.Message
+	headerSent chan struct{}
+	done       chan struct{}
+	err        error
+
+	// mu guards header, trailer and the closing of headerSent.
+	mu      sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+// NewInProcessStream returns an InProcessStream whose requests are decoded by "dec",
+// nil for server streaming methods, which receive their request as an argument.
+func NewInProcessStream(ctx context.Context, dec Decoder) *InProcessStream {
+	s := &InProcessStream{
+		dec:        dec,
+		msgs:       make(chan proto.Message),
+		headerSent: make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.ctx = grpc.NewContextWithServerTransportStream(ctx, inProcessTransportStream{s})
+	return s
+}
+
+// Start calls "method" in a new goroutine. It must be called once.
+func (s *InProcessStream) Start(method func() error) {
+	go func() {
+		defer close(s.done)
+		defer s.sendHeader()
+		s.err = method()
+	}()
+}
+
+// Context returns the context of the method.
+func (s *InProcessStream) Context() context.Context {
+	return s.ctx
+}
+
+// SetHeader sets the header metadata, which is sent with the first response message.
+func (s *InProcessStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.headerSent:
+		return errInProcessHeaderSent
+	default:
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader sends the header metadata.
+func (s *InProcessStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	s.sendHeader()
+	return nil
+}
+
+func (s *InProcessStream) sendHeader() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.headerSent:
+	default:
+		close(s.headerSent)
+	}
+}
+
+// SetTrailer sets the trailer metadata, which is sent when the method returns.
+func (s *InProcessStream) SetTrailer(md metadata.MD) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+// SendMsg sends the response message "m", and blocks until it is received by Recv.
+func (s *InProcessStream) SendMsg(m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected type of response message: %T", m)
+	}
+	s.sendHeader()
+	// Unlike gRPC streams, the message is marshaled after SendMsg returns.
+	msg = proto.Clone(msg)
+	select {
+	case s.msgs <- msg:
+		return nil
+	case <-s.ctx.Done():
+		return status.FromContextError(s.ctx.Err()).Err()
+	}
+}
+
+// RecvMsg decodes the next request message into "m". It returns io.EOF at the end of the requests.
+func (s *InProcessStream) RecvMsg(m interface{}) error {
+	if s.dec == nil {
+		return io.EOF
+	}
+	if err := s.dec.Decode(m); err != nil {
+		if err == io.EOF {
+			return err
+		}
+		if cerr := s.ctx.Err(); cerr != nil {
+			return status.FromContextError(cerr).Err()
+		}
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return nil
+}
+
+// Header waits until the header metadata is sent, and returns it.
+func (s *InProcessStream) Header() metadata.MD {
+	select {
+	case <-s.headerSent:
+	case <-s.ctx.Done():
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.header.Copy()
+}
+
+// Recv returns the next response message sent by the method. Once the method returned,
+// it returns io.EOF if the method succeeded, and the error of the method otherwise.
+func (s *InProcessStream) Recv() (proto.Message, error) {
+	select {
+	case msg := <-s.msgs:
+		return msg, nil
+	case <-s.done:
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+}
+
+// Response waits for the method to return and returns its only response message,
+// for client streaming methods.
+func (s *InProcessStream) Response() (proto.Message, error) {
+	msg, err := s.Recv()
+	if err == io.EOF {
+		return nil, status.Error(codes.Internal, "cardinality violation: the method returned without a response")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Recv(); err != io.EOF {
+		if err == nil {
+			err = status.Error(codes.Internal, "cardinality violation: the method sent more than one response")
+		}
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Trailer returns the trailer metadata set by the method, once it returned.
+func (s *InProcessStream) Trailer() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.trailer.Copy()
+}
+
+// Close cancels the context of the method and waits for it to return. It must be called after Start.
+func (s *InProcessStream) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// FullDuplexRequestBody returns a reader of the body of "req" which can be read while writing
+// the response. Unless "req" is full-duplex, see FullDuplex, the body is read into memory first,
+// up to the size given by WithMaxRequestTrailerBodySize. The errors it returns are meant to be
+// returned as is, to answer larger bodies with http.StatusRequestEntityTooLarge.
+// It should only be used by the generated files, for bidirectional streaming methods.
+func FullDuplexRequestBody(req *http.Request) (io.Reader, error) {
+	ctx := req.Context()
+	if FullDuplex(ctx) {
+		return req.Body, nil
+	}
+	buf, err := readRequestBody(req, maxBodySize(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(buf), nil
+}
+
+// inProcessTransportStream supports grpc.SetHeader, grpc.SendHeader and grpc.SetTrailer
+// with the context of an InProcessStream.
+type inProcessTransportStream struct {
+	s *InProcessStream
+}
+
+func (t inProcessTransportStream) Method() string {
+	method, _ := RPCMethod(t.s.ctx)
+	return method
+}
+
+func (t inProcessTransportStream) SetHeader(md metadata.MD) error {
+	return t.s.SetHeader(md)
+}
+
+func (t inProcessTransportStream) SendHeader(md metadata.MD) error {
+	return t.s.SendHeader(md)
+}
+
+func (t inProcessTransportStream) SetTrailer(md metadata.MD) error {
+	t.s.SetTrailer(md)
+	return nil
+}
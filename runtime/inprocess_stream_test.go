@@ -0,0 +1,227 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ grpc.ServerStream = (*runtime.InProcessStream)(nil)
+
+func TestInProcessStream(t *testing.T) {
+	dec := (&runtime.JSONPb{}).NewDecoder(strings.NewReader(`{"id":"One"} {"id":"Two"}`))
+	stream := runtime.NewInProcessStream(context.Background(), dec)
+	stream.Start(func() error {
+		if err := grpc.SetHeader(stream.Context(), metadata.Pairs("foo", "bar")); err != nil {
+			return err
+		}
+		for {
+			var msg pb.SimpleMessage
+			err := stream.RecvMsg(&msg)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if err := stream.SendMsg(&msg); err != nil {
+				return err
+			}
+		}
+		stream.SetTrailer(metadata.Pairs("baz", "qux"))
+		return nil
+	})
+	defer stream.Close()
+
+	if got, want := stream.Header().Get("foo"), []string{"bar"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf(`stream.Header().Get("foo") = %q; want %q`, got, want)
+	}
+	for _, want := range []*pb.SimpleMessage{{Id: "One"}, {Id: "Two"}} {
+		got, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("stream.Recv() failed with %v; want success", err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("stream.Recv() = %v; want %v", got, want)
+		}
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("stream.Recv() failed with %v; want io.EOF", err)
+	}
+	if got, want := stream.Trailer().Get("baz"), []string{"qux"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf(`stream.Trailer().Get("baz") = %q; want %q`, got, want)
+	}
+}
+
+func TestInProcessStreamErrors(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		body    string
+		method  func(stream *runtime.InProcessStream) error
+		wantErr codes.Code
+	}{
+		{
+			name: "method error",
+			method: func(stream *runtime.InProcessStream) error {
+				return status.Error(codes.NotFound, "not found")
+			},
+			wantErr: codes.NotFound,
+		},
+		{
+			name: "invalid request",
+			body: `{"id":`,
+			method: func(stream *runtime.InProcessStream) error {
+				return stream.RecvMsg(&pb.SimpleMessage{})
+			},
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			name: "header sent",
+			method: func(stream *runtime.InProcessStream) error {
+				if err := stream.SendHeader(nil); err != nil {
+					return err
+				}
+				return stream.SetHeader(metadata.Pairs("foo", "bar"))
+			},
+			wantErr: codes.Internal,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			dec := (&runtime.JSONPb{}).NewDecoder(strings.NewReader(spec.body))
+			stream := runtime.NewInProcessStream(context.Background(), dec)
+			stream.Start(func() error { return spec.method(stream) })
+			defer stream.Close()
+
+			_, err := stream.Recv()
+			if got := status.Code(err); got != spec.wantErr {
+				t.Errorf("stream.Recv() failed with %v; want code %v", err, spec.wantErr)
+			}
+		})
+	}
+}
+
+func TestInProcessStreamResponse(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		sent    []proto.Message
+		wantErr codes.Code
+	}{
+		{
+			name: "one response",
+			sent: []proto.Message{&pb.SimpleMessage{Id: "One"}},
+		},
+		{
+			name:    "no response",
+			wantErr: codes.Internal,
+		},
+		{
+			name:    "too many responses",
+			sent:    []proto.Message{&pb.SimpleMessage{Id: "One"}, &pb.SimpleMessage{Id: "Two"}},
+			wantErr: codes.Internal,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			stream := runtime.NewInProcessStream(context.Background(), nil)
+			stream.Start(func() error {
+				for _, msg := range spec.sent {
+					if err := stream.SendMsg(msg); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			defer stream.Close()
+
+			got, err := stream.Response()
+			if code := status.Code(err); code != spec.wantErr {
+				t.Fatalf("stream.Response() failed with %v; want code %v", err, spec.wantErr)
+			}
+			if err == nil && !proto.Equal(got, spec.sent[0]) {
+				t.Errorf("stream.Response() = %v; want %v", got, spec.sent[0])
+			}
+		})
+	}
+}
+
+func TestInProcessStreamClose(t *testing.T) {
+	stream := runtime.NewInProcessStream(context.Background(), nil)
+	errCh := make(chan error, 1)
+	stream.Start(func() error {
+		err := stream.SendMsg(&pb.SimpleMessage{Id: "One"})
+		errCh <- err
+		return err
+	})
+	stream.Close()
+
+	if err := <-errCh; status.Code(err) != codes.Canceled {
+		t.Errorf("stream.SendMsg failed with %v; want code %v", err, codes.Canceled)
+	}
+}
+
+func TestFullDuplexRequestBody(t *testing.T) {
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"echo"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	for _, spec := range []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name: "within the limit",
+			body: `{"id":"One"}`,
+		},
+		{
+			name:       "too large",
+			body:       `{"id":"One"} {"id":"Two"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(runtime.WithMaxRequestTrailerBodySize(16))
+			var (
+				got    []byte
+				gotErr error
+			)
+			mux.Handle("POST", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+				// The ResponseRecorder cannot be made full-duplex, so the body is read into memory.
+				body, err := runtime.FullDuplexRequestBody(r)
+				if err != nil {
+					gotErr = err
+					return
+				}
+				got, gotErr = ioutil.ReadAll(body)
+			}, runtime.WithFullDuplex())
+
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/echo", strings.NewReader(spec.body)))
+			if spec.wantStatus != 0 {
+				var httpErr *runtime.HTTPStatusError
+				if !errors.As(gotErr, &httpErr) || httpErr.HTTPStatus != spec.wantStatus {
+					t.Fatalf("runtime.FullDuplexRequestBody(r) failed with %v; want HTTP status %d", gotErr, spec.wantStatus)
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatalf("reading the request body failed with %v; want success", gotErr)
+			}
+			if string(got) != spec.body {
+				t.Errorf("body = %q; want %q", got, spec.body)
+			}
+		})
+	}
+}
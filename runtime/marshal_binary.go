@@ -0,0 +1,345 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// binaryEncoder maps the values marshaled by the binary marshalers, CBOR and MsgPack, to the
+// values appended by appendCBOR and appendMsgPack: nil, a bool, a string, a []byte, an int64,
+// a uint64, a float64, a []interface{} or a []jsonMember.
+//
+// Messages are walked with protoreflect and mapped the way protojson maps them, with the same
+// field names and representations of the well-known types, except that integers, including the
+// 64-bit ones protojson writes as strings, are native integers and that bytes fields are byte
+// strings rather than base64 strings.
+type binaryEncoder struct {
+	protojson.MarshalOptions
+}
+
+// value maps "v", a message or one of the non-message values JSONPb marshals.
+func (e binaryEncoder) value(v interface{}) (interface{}, error) {
+	p, ok := v.(proto.Message)
+	if !ok {
+		return e.nonProto(v)
+	}
+	if !e.AllowPartial {
+		if err := proto.CheckInitialized(p); err != nil {
+			return nil, err
+		}
+	}
+	return e.message(p.ProtoReflect())
+}
+
+// nonProto maps a non-message value like JSONPb.marshalNonProtoField, falling back to
+// encoding/json for the values it does not know about.
+func (e binaryEncoder) nonProto(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			if e.EmitUnpopulated {
+				return []interface{}{}, nil
+			}
+			return nil, nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Bytes(), nil
+		}
+		if elem := rv.Type().Elem(); elem.Implements(protoMessageType) || elem.Implements(typeProtoEnum) {
+			values := make([]interface{}, rv.Len())
+			for i := range values {
+				var err error
+				if values[i], err = e.value(rv.Index(i).Interface()); err != nil {
+					return nil, err
+				}
+			}
+			return values, nil
+		}
+	case reflect.Map:
+		members := make([]jsonMember, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			v, err := e.value(rv.MapIndex(k).Interface())
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, jsonMember{key: fmt.Sprintf("%v", k.Interface()), value: v})
+		}
+		sort.Slice(members, func(i, j int) bool { return members[i].key < members[j].key })
+		return members, nil
+	}
+	if enum, ok := rv.Interface().(protoEnum); ok {
+		if e.UseEnumNumbers {
+			return rv.Int(), nil
+		}
+		return enum.String(), nil
+	}
+	j, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(j)
+}
+
+func (e binaryEncoder) message(m protoreflect.Message) (interface{}, error) {
+	if marshal := e.wellKnownType(m.Descriptor().FullName()); marshal != nil {
+		return marshal(m)
+	}
+	return e.fields(m, []jsonMember{})
+}
+
+// fields appends the fields of "m" to "members", in the order protojson writes them.
+func (e binaryEncoder) fields(m protoreflect.Message, members []jsonMember) ([]jsonMember, error) {
+	type field struct {
+		fd protoreflect.FieldDescriptor
+		v  protoreflect.Value
+	}
+	var fields []field
+	if e.EmitUnpopulated {
+		fds := m.Descriptor().Fields()
+		for i := 0; i < fds.Len(); i++ {
+			fd := fds.Get(i)
+			if m.Has(fd) || fd.ContainingOneof() != nil {
+				continue
+			}
+			v := m.Get(fd)
+			if fd.Syntax() == protoreflect.Proto2 && fd.Default().IsValid() || fd.Cardinality() != protoreflect.Repeated && fd.Message() != nil {
+				// An invalid value is mapped to nil.
+				v = protoreflect.Value{}
+			}
+			fields = append(fields, field{fd: fd, v: v})
+		}
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fields = append(fields, field{fd: fd, v: v})
+		return true
+	})
+	// Fields are sorted by declaration index, followed by the extensions sorted by name.
+	sort.Slice(fields, func(i, j int) bool {
+		x, y := fields[i].fd, fields[j].fd
+		if x.IsExtension() != y.IsExtension() {
+			return y.IsExtension()
+		}
+		if x.IsExtension() {
+			return x.FullName() < y.FullName()
+		}
+		return x.Index() < y.Index()
+	})
+
+	for _, f := range fields {
+		name := f.fd.JSONName()
+		if e.UseProtoNames {
+			name = f.fd.TextName()
+		}
+		v, err := e.field(f.fd, f.v)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{key: name, value: v})
+	}
+	return members, nil
+}
+
+func (e binaryEncoder) field(fd protoreflect.FieldDescriptor, v protoreflect.Value) (interface{}, error) {
+	switch {
+	case fd.IsList():
+		l := v.List()
+		values := make([]interface{}, l.Len())
+		for i := range values {
+			var err error
+			if values[i], err = e.singular(fd, l.Get(i)); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case fd.IsMap():
+		return e.mapValue(fd, v.Map())
+	}
+	return e.singular(fd, v)
+}
+
+// mapValue maps the entries of "m" to members keyed by the string representation of their
+// keys, sorted like protojson sorts them.
+func (e binaryEncoder) mapValue(fd protoreflect.FieldDescriptor, m protoreflect.Map) ([]jsonMember, error) {
+	keys := make([]protoreflect.MapKey, 0, m.Len())
+	m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		x, y := keys[i], keys[j]
+		switch x.Interface().(type) {
+		case bool:
+			return !x.Bool() && y.Bool()
+		case int32, int64:
+			return x.Int() < y.Int()
+		case uint32, uint64:
+			return x.Uint() < y.Uint()
+		}
+		return x.String() < y.String()
+	})
+
+	members := make([]jsonMember, 0, len(keys))
+	for _, k := range keys {
+		v, err := e.singular(fd.MapValue(), m.Get(k))
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{key: k.String(), value: v})
+	}
+	return members, nil
+}
+
+func (e binaryEncoder) singular(fd protoreflect.FieldDescriptor, v protoreflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.StringKind:
+		if s := v.String(); utf8.ValidString(s) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("field %s contains invalid UTF-8", fd.FullName())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int(), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint(), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float(), nil
+	case protoreflect.BytesKind:
+		return v.Bytes(), nil
+	case protoreflect.EnumKind:
+		if fd.Enum().FullName() == "google.protobuf.NullValue" {
+			return nil, nil
+		}
+		desc := fd.Enum().Values().ByNumber(v.Enum())
+		if e.UseEnumNumbers || desc == nil {
+			return int64(v.Enum()), nil
+		}
+		return string(desc.Name()), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return e.message(v.Message())
+	}
+	return nil, fmt.Errorf("field %s has an unknown kind %v", fd.FullName(), fd.Kind())
+}
+
+// wellKnownType returns the function mapping the well-known type "name", nil if it is not one.
+func (e binaryEncoder) wellKnownType(name protoreflect.FullName) func(protoreflect.Message) (interface{}, error) {
+	if name.Parent() != "google.protobuf" {
+		return nil
+	}
+	switch name.Name() {
+	case "Any":
+		return e.anyValue
+	case "BoolValue", "Int32Value", "Int64Value", "UInt32Value", "UInt64Value",
+		"FloatValue", "DoubleValue", "StringValue", "BytesValue":
+		return e.wrapperValue
+	case "Struct":
+		return e.structValue
+	case "ListValue":
+		return e.listValue
+	case "Value":
+		return e.knownValue
+	case "Timestamp", "Duration", "FieldMask", "Empty":
+		return e.stringValue
+	}
+	return nil
+}
+
+// anyValue maps an Any to the members of the message it holds, preceded by "@type".
+// The well-known types are held by a "value" member instead.
+func (e binaryEncoder) anyValue(m protoreflect.Message) (interface{}, error) {
+	fds := m.Descriptor().Fields()
+	fdType, fdValue := fds.ByName("type_url"), fds.ByName("value")
+	if !m.Has(fdType) {
+		if !m.Has(fdValue) {
+			return []jsonMember{}, nil
+		}
+		return nil, errors.New("google.protobuf.Any: type_url is not set")
+	}
+
+	typeURL := m.Get(fdType).String()
+	resolver := e.Resolver
+	if resolver == nil {
+		resolver = protoregistry.GlobalTypes
+	}
+	mt, err := resolver.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: unable to resolve %q: %v", typeURL, err)
+	}
+	em := mt.New()
+	opts := proto.UnmarshalOptions{AllowPartial: true, Resolver: resolver}
+	if err := opts.Unmarshal(m.Get(fdValue).Bytes(), em.Interface()); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: unable to unmarshal %q: %v", typeURL, err)
+	}
+
+	members := []jsonMember{{key: "@type", value: typeURL}}
+	if marshal := e.wellKnownType(mt.Descriptor().FullName()); marshal != nil {
+		v, err := marshal(em)
+		if err != nil {
+			return nil, err
+		}
+		return append(members, jsonMember{key: "value", value: v}), nil
+	}
+	return e.fields(em, members)
+}
+
+func (e binaryEncoder) wrapperValue(m protoreflect.Message) (interface{}, error) {
+	fd := m.Descriptor().Fields().ByName("value")
+	return e.singular(fd, m.Get(fd))
+}
+
+func (e binaryEncoder) structValue(m protoreflect.Message) (interface{}, error) {
+	fd := m.Descriptor().Fields().ByName("fields")
+	return e.mapValue(fd, m.Get(fd).Map())
+}
+
+func (e binaryEncoder) listValue(m protoreflect.Message) (interface{}, error) {
+	fd := m.Descriptor().Fields().ByName("values")
+	return e.field(fd, m.Get(fd))
+}
+
+func (e binaryEncoder) knownValue(m protoreflect.Message) (interface{}, error) {
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("kind"))
+	if fd == nil {
+		return nil, errors.New("google.protobuf.Value: none of the oneof fields is set")
+	}
+	v := m.Get(fd)
+	if fd.Kind() == protoreflect.DoubleKind && (math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0)) {
+		return nil, fmt.Errorf("google.protobuf.Value: invalid %v value", v.Float())
+	}
+	return e.singular(fd, v)
+}
+
+// stringValue maps the well-known types which protojson writes as strings, or as an empty
+// object for Empty, to the value written by protojson.
+func (e binaryEncoder) stringValue(m protoreflect.Message) (interface{}, error) {
+	j, err := protojson.MarshalOptions{AllowPartial: true}.Marshal(m.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(j)
+}
@@ -0,0 +1,444 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// CBOR is a Marshaler which marshals/unmarshals into/from CBOR (RFC 8949).
+//
+// Messages are mapped with protoreflect the way JSONPb maps them to JSON, with the same field
+// names and representations of the well-known types, except that integer fields, including
+// 64-bit ones, are encoded as CBOR integers and bytes fields as byte strings. When unmarshaling,
+// the JSON representations are also accepted, as well as integer and boolean map keys for the
+// corresponding map fields and epoch-based date/time values (tag 1) for Timestamps.
+//
+// Streams are CBOR sequences (RFC 8742), whose items are not delimited.
+type CBOR struct {
+	protojson.MarshalOptions
+	protojson.UnmarshalOptions
+}
+
+// ContentType always returns "application/cbor".
+func (*CBOR) ContentType(_ interface{}) string {
+	return "application/cbor"
+}
+
+// Marshal marshals "v" into CBOR.
+func (c *CBOR) Marshal(v interface{}) ([]byte, error) {
+	bv, err := binaryEncoder{c.MarshalOptions}.value(v)
+	if err != nil {
+		return nil, err
+	}
+	return appendCBOR(nil, bv), nil
+}
+
+// Unmarshal unmarshals CBOR "data" into "v".
+func (c *CBOR) Unmarshal(data []byte, v interface{}) error {
+	d := &cborDecoder{r: bufio.NewReader(bytes.NewReader(data))}
+	j, err := d.decodeJSON()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if _, err := d.r.Peek(1); err != io.EOF {
+		return errors.New("unexpected data after CBOR item")
+	}
+	return c.jsonPb().Unmarshal(j, v)
+}
+
+// NewDecoder returns a Decoder which reads a CBOR sequence from "r".
+func (c *CBOR) NewDecoder(r io.Reader) Decoder {
+	d := &cborDecoder{r: bufio.NewReader(r)}
+	return DecoderFunc(func(v interface{}) error {
+		j, err := d.decodeJSON()
+		if err != nil {
+			return err
+		}
+		return c.jsonPb().Unmarshal(j, v)
+	})
+}
+
+// NewEncoder returns an Encoder which writes a CBOR sequence into "w".
+func (c *CBOR) NewEncoder(w io.Writer) Encoder {
+	return EncoderFunc(func(v interface{}) error {
+		b, err := c.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	})
+}
+
+// Delimiter returns nil, the items of CBOR sequences are self-delimiting.
+func (*CBOR) Delimiter() []byte {
+	return nil
+}
+
+func (c *CBOR) jsonPb() *JSONPb {
+	return &JSONPb{MarshalOptions: c.MarshalOptions, UnmarshalOptions: c.UnmarshalOptions}
+}
+
+// CBOR major types.
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMap    = 5
+	cborTag    = 6
+	cborSimple = 7
+)
+
+const (
+	// cborIndefinite is the additional information of indefinite length items.
+	cborIndefinite = 31
+	// cborBreak ends indefinite length items.
+	cborBreak = 0xff
+	// cborTagEpochTime is the tag of epoch-based date/time values.
+	cborTagEpochTime = 1
+)
+
+// appendCBOR appends the CBOR encoding of "v", see binaryEncoder.
+func appendCBOR(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, cborSimple<<5|22)
+	case bool:
+		if v {
+			return append(b, cborSimple<<5|21)
+		}
+		return append(b, cborSimple<<5|20)
+	case string:
+		b = appendCBORHead(b, cborText, uint64(len(v)))
+		return append(b, v...)
+	case []byte:
+		b = appendCBORHead(b, cborBytes, uint64(len(v)))
+		return append(b, v...)
+	case int64:
+		if v < 0 {
+			return appendCBORHead(b, cborNegInt, uint64(-1-v))
+		}
+		return appendCBORHead(b, cborUint, uint64(v))
+	case uint64:
+		return appendCBORHead(b, cborUint, v)
+	case float64:
+		if f := float32(v); float64(f) == v {
+			return appendBigEndian(append(b, cborSimple<<5|26), uint64(math.Float32bits(f)), 4)
+		}
+		return appendBigEndian(append(b, cborSimple<<5|27), math.Float64bits(v), 8)
+	case []interface{}:
+		b = appendCBORHead(b, cborArray, uint64(len(v)))
+		for _, e := range v {
+			b = appendCBOR(b, e)
+		}
+		return b
+	case []jsonMember:
+		b = appendCBORHead(b, cborMap, uint64(len(v)))
+		for _, m := range v {
+			b = appendCBOR(b, m.key)
+			b = appendCBOR(b, m.value)
+		}
+		return b
+	}
+	panic(fmt.Sprintf("unexpected value %T", v))
+}
+
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(b, major<<5|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major<<5|24, byte(n))
+	case n <= math.MaxUint16:
+		return appendBigEndian(append(b, major<<5|25), n, 2)
+	case n <= math.MaxUint32:
+		return appendBigEndian(append(b, major<<5|26), n, 4)
+	default:
+		return appendBigEndian(append(b, major<<5|27), n, 8)
+	}
+}
+
+// cborDecoder transcodes CBOR items to JSON.
+type cborDecoder struct {
+	r *bufio.Reader
+}
+
+// decodeJSON reads the next CBOR item and returns it transcoded to JSON.
+// It returns io.EOF if there are no more items.
+func (d *cborDecoder) decodeJSON() ([]byte, error) {
+	if _, err := d.r.Peek(1); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := d.value(&buf, 0); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// head reads the initial byte of an item and its argument.
+func (d *cborDecoder) head() (major, info byte, arg uint64, err error) {
+	ib, err := d.r.ReadByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info = ib>>5, ib&0x1f
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		arg, err = readBigEndian(d.r, 1<<(info-24))
+	case info == cborIndefinite:
+		if major == cborUint || major == cborNegInt || major == cborTag {
+			err = fmt.Errorf("invalid indefinite length CBOR item of major type %d", major)
+		}
+	default:
+		err = fmt.Errorf("invalid CBOR additional information %d", info)
+	}
+	return major, info, arg, err
+}
+
+// atBreak consumes the break ending an indefinite length item, if it is next.
+func (d *cborDecoder) atBreak() (bool, error) {
+	b, err := d.r.Peek(1)
+	if err != nil {
+		return false, unexpectedEOF(err)
+	}
+	if b[0] != cborBreak {
+		return false, nil
+	}
+	_, err = d.r.ReadByte()
+	return true, err
+}
+
+func (d *cborDecoder) value(buf *bytes.Buffer, depth int) error {
+	if depth > maxTranscodeDepth {
+		return errTranscodeDepth
+	}
+	major, info, arg, err := d.head()
+	if err != nil {
+		return err
+	}
+	switch major {
+	case cborUint:
+		buf.WriteString(strconv.FormatUint(arg, 10))
+	case cborNegInt:
+		buf.WriteString(formatNegativeInt(arg))
+	case cborBytes:
+		b, err := d.bytes(major, info, arg)
+		if err != nil {
+			return err
+		}
+		writeJSONBytes(buf, b)
+	case cborText:
+		b, err := d.bytes(major, info, arg)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return errors.New("invalid UTF-8 in CBOR text string")
+		}
+		writeJSONString(buf, string(b))
+	case cborArray:
+		buf.WriteByte('[')
+		for i := uint64(0); info == cborIndefinite || i < arg; i++ {
+			if info == cborIndefinite {
+				done, err := d.atBreak()
+				if err != nil {
+					return err
+				}
+				if done {
+					break
+				}
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := d.value(buf, depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case cborMap:
+		buf.WriteByte('{')
+		for i := uint64(0); info == cborIndefinite || i < arg; i++ {
+			if info == cborIndefinite {
+				done, err := d.atBreak()
+				if err != nil {
+					return err
+				}
+				if done {
+					break
+				}
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := d.key(buf); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := d.value(buf, depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case cborTag:
+		if arg == cborTagEpochTime {
+			return d.epochTime(buf)
+		}
+		// Other tags are ignored, the tagged item is decoded as is.
+		return d.value(buf, depth+1)
+	case cborSimple:
+		switch info {
+		case 20:
+			buf.WriteString("false")
+		case 21:
+			buf.WriteString("true")
+		case 22, 23:
+			buf.WriteString("null")
+		case 25:
+			writeJSONFloat(buf, halfToFloat64(uint16(arg)))
+		case 26:
+			writeJSONFloat(buf, float64(math.Float32frombits(uint32(arg))))
+		case 27:
+			writeJSONFloat(buf, math.Float64frombits(arg))
+		case cborIndefinite:
+			return errors.New("unexpected CBOR break")
+		default:
+			return fmt.Errorf("unsupported CBOR simple value %d", arg)
+		}
+	}
+	return nil
+}
+
+// bytes reads the content of a byte or text string, concatenating the chunks of indefinite length strings.
+func (d *cborDecoder) bytes(major, info byte, arg uint64) ([]byte, error) {
+	if info != cborIndefinite {
+		return readTranscodeBytes(d.r, arg)
+	}
+	var b []byte
+	for {
+		done, err := d.atBreak()
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return b, nil
+		}
+		chunkMajor, chunkInfo, n, err := d.head()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if chunkMajor != major || chunkInfo == cborIndefinite {
+			return nil, errors.New("invalid chunk in indefinite length CBOR string")
+		}
+		chunk, err := readTranscodeBytes(d.r, n)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(b))+n > maxTranscodeLength {
+			return nil, fmt.Errorf("length exceeds the maximum of %d", maxTranscodeLength)
+		}
+		b = append(b, chunk...)
+	}
+}
+
+// key transcodes a map key to a JSON string. Integer and boolean keys are converted
+// to their string representation, which JSONPb accepts for the corresponding map fields.
+func (d *cborDecoder) key(buf *bytes.Buffer) error {
+	major, info, arg, err := d.head()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	switch {
+	case major == cborText:
+		b, err := d.bytes(major, info, arg)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return errors.New("invalid UTF-8 in CBOR text string")
+		}
+		writeJSONString(buf, string(b))
+	case major == cborUint:
+		writeJSONString(buf, strconv.FormatUint(arg, 10))
+	case major == cborNegInt:
+		writeJSONString(buf, formatNegativeInt(arg))
+	case major == cborSimple && info == 20:
+		writeJSONString(buf, "false")
+	case major == cborSimple && info == 21:
+		writeJSONString(buf, "true")
+	default:
+		return fmt.Errorf("unsupported CBOR map key of major type %d", major)
+	}
+	return nil
+}
+
+// epochTime transcodes an epoch-based date/time value to a RFC 3339 JSON string.
+func (d *cborDecoder) epochTime(buf *bytes.Buffer) error {
+	major, info, arg, err := d.head()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	var t time.Time
+	switch {
+	case major == cborUint && arg <= math.MaxInt64:
+		t = time.Unix(int64(arg), 0)
+	case major == cborNegInt && arg < math.MaxInt64:
+		t = time.Unix(-1-int64(arg), 0)
+	case major == cborSimple && (info == 25 || info == 26 || info == 27):
+		var f float64
+		switch info {
+		case 25:
+			f = halfToFloat64(uint16(arg))
+		case 26:
+			f = float64(math.Float32frombits(uint32(arg)))
+		default:
+			f = math.Float64frombits(arg)
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return errors.New("invalid CBOR epoch-based date/time")
+		}
+		sec, frac := math.Modf(f)
+		t = time.Unix(int64(sec), int64(frac*1e9))
+	default:
+		return errors.New("invalid CBOR epoch-based date/time")
+	}
+	writeJSONTime(buf, t)
+	return nil
+}
+
+// halfToFloat64 converts an IEEE 754 half-precision floating-point number.
+func halfToFloat64(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	frac := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(frac+0x400, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
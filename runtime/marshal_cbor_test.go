@@ -0,0 +1,243 @@
+package runtime_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// binaryTestMessage is marshaled by the tests of the binary marshalers.
+var binaryTestMessage = &examplepb.ABitOfEverything{
+	SingleNested: &examplepb.ABitOfEverything_Nested{Name: "nested"},
+	Uuid:         "6EC2446F-7E89-4127-B3E6-5C05E6BECBA7",
+	Nested: []*examplepb.ABitOfEverything_Nested{
+		{Name: "foo", Amount: 12345},
+	},
+	FloatValue:          1.5,
+	DoubleValue:         0.1,
+	Int64Value:          -1 << 40,
+	Uint64Value:         0xFFFFFFFFFFFFFFFF,
+	Int32Value:          -100000,
+	Sint32Value:         -5,
+	BoolValue:           true,
+	BytesValue:          []byte{0, 1, 2, 0xff},
+	EnumValue:           examplepb.NumericEnum_ONE,
+	RepeatedStringValue: []string{"a", "b"},
+	OneofValue:          &examplepb.ABitOfEverything_OneofString{OneofString: "bar"},
+	MapValue: map[string]examplepb.NumericEnum{
+		"a": examplepb.NumericEnum_ONE,
+		"b": examplepb.NumericEnum_ZERO,
+	},
+	TimestampValue: &timestamppb.Timestamp{Seconds: 1500000000, Nanos: 500},
+	Anytype: &anypb.Any{
+		TypeUrl: "type.googleapis.com/grpc.gateway.runtime.internal.examplepb.SimpleMessage",
+		// SimpleMessage{Id: "any"}
+		Value: []byte{0x0a, 0x03, 'a', 'n', 'y'},
+	},
+}
+
+// binaryTestWellKnownTypes is marshaled by the tests of the binary marshalers, with more
+// well-known types than binaryTestMessage.
+var binaryTestWellKnownTypes = &examplepb.Proto3Message{
+	Int64Value:         math.MinInt64,
+	BytesValue:         []byte{},
+	DurationValue:      durationpb.New(1500 * time.Millisecond),
+	FieldmaskValue:     &field_mask.FieldMask{Paths: []string{"float_value", "nested.int64_value"}},
+	WrapperInt64Value:  wrapperspb.Int64(math.MaxInt64),
+	WrapperUInt64Value: wrapperspb.UInt64(math.MaxUint64),
+	WrapperBytesValue:  wrapperspb.Bytes([]byte{0xff}),
+	MapValue5:          map[int64]string{-1: "a", 1 << 40: "b"},
+	MapValue15:         map[bool]string{true: "c"},
+	StructValueValue:   structpb.NewNumberValue(2),
+	StructValue: &structpb.Struct{Fields: map[string]*structpb.Value{
+		"null":   structpb.NewNullValue(),
+		"list":   structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("d"), structpb.NewBoolValue(true)}}),
+		"nested": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{"e": structpb.NewNumberValue(0.5)}}),
+	}},
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) failed with %v; want success", s, err)
+	}
+	return b
+}
+
+func TestCBORMarshal(t *testing.T) {
+	m := &runtime.CBOR{}
+	for _, spec := range []struct {
+		v    interface{}
+		want string
+	}{
+		// {"id": "foo"}
+		{v: &examplepb.SimpleMessage{Id: "foo"}, want: "a162696463666f6f"},
+		// {}
+		{v: &examplepb.SimpleMessage{}, want: "a0"},
+		// {"result": {"id": "a"}}
+		{v: map[string]proto.Message{"result": &examplepb.SimpleMessage{Id: "a"}}, want: "a166726573756c74a16269646161"},
+		// {"floatValue": 1.5, "int32Value": -100000, "boolValue": true}
+		{
+			v:    &examplepb.ABitOfEverything{FloatValue: 1.5, Int32Value: -100000, BoolValue: true},
+			want: "a36a666c6f617456616c7565fa3fc000006a696e74333256616c75653a0001869f69626f6f6c56616c7565f5",
+		},
+		// {"int64Value": -1 << 40, "uint64Value": 1<<64 - 1, "fixed64Value": 1 << 33, "bytesValue": h'0001ff'},
+		// with CBOR integers and a byte string rather than strings.
+		{
+			v: &examplepb.ABitOfEverything{
+				Int64Value:   -1 << 40,
+				Uint64Value:  0xFFFFFFFFFFFFFFFF,
+				Fixed64Value: 1 << 33,
+				BytesValue:   []byte{0, 1, 0xff},
+			},
+			want: "a46a696e74363456616c75653b000000ffffffffff6b75696e74363456616c75651bffffffffffffffff6c6669786564363456616c75651b00000002000000006a627974657356616c7565430001ff",
+		},
+		// The wrappers are mapped to their value.
+		{v: wrapperspb.Int64(1 << 40), want: "1b0000010000000000"},
+		{v: wrapperspb.Bytes([]byte{1}), want: "4101"},
+		{v: "text", want: "6474657874"},
+	} {
+		got, err := m.Marshal(spec.v)
+		if err != nil {
+			t.Errorf("m.Marshal(%v) failed with %v; want success", spec.v, err)
+			continue
+		}
+		if want := mustDecodeHex(t, spec.want); !bytes.Equal(got, want) {
+			t.Errorf("m.Marshal(%v) = %x; want %x", spec.v, got, want)
+		}
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	for _, m := range []*runtime.CBOR{
+		{},
+		{MarshalOptions: protojson.MarshalOptions{UseProtoNames: true, UseEnumNumbers: true, EmitUnpopulated: true}},
+	} {
+		for _, msg := range []proto.Message{binaryTestMessage, binaryTestWellKnownTypes} {
+			b, err := m.Marshal(msg)
+			if err != nil {
+				t.Fatalf("m.Marshal failed with %v; want success", err)
+			}
+			got := msg.ProtoReflect().New().Interface()
+			if err := m.Unmarshal(b, got); err != nil {
+				t.Fatalf("m.Unmarshal(%x) failed with %v; want success", b, err)
+			}
+			if !proto.Equal(got, msg) {
+				t.Errorf("m.Unmarshal(m.Marshal(msg)) = %v; want %v", got, msg)
+			}
+		}
+	}
+}
+
+func TestCBORUnmarshal(t *testing.T) {
+	m := &runtime.CBOR{}
+	for _, spec := range []struct {
+		name string
+		data string
+		want *examplepb.ABitOfEverything
+	}{
+		{
+			// {"bytesValue": h'0001'}
+			name: "byte string",
+			data: "a16a627974657356616c7565420001",
+			want: &examplepb.ABitOfEverything{BytesValue: []byte{0, 1}},
+		},
+		{
+			// {_ "uuid": (_ "ab", "c"), "nested": [_ {"amount": 1}]}
+			name: "indefinite length",
+			data: "bf64757569647f626162616360ff666e65737465649fa166616d6f756e7401ffff",
+			want: &examplepb.ABitOfEverything{Uuid: "abc", Nested: []*examplepb.ABitOfEverything_Nested{{Amount: 1}}},
+		},
+		{
+			// {"timestampValue": 1(1500000000)}
+			name: "epoch time",
+			data: "a16e74696d657374616d7056616c7565c11a59682f00",
+			want: &examplepb.ABitOfEverything{TimestampValue: timestamppb.New(time.Unix(1500000000, 0))},
+		},
+		{
+			// {"doubleValue": 1.5 as a half-precision float, "int64Value": -2}
+			name: "numbers",
+			data: "a26b646f75626c6556616c7565f93e006a696e74363456616c756521",
+			want: &examplepb.ABitOfEverything{DoubleValue: 1.5, Int64Value: -2},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var got examplepb.ABitOfEverything
+			if err := m.Unmarshal(mustDecodeHex(t, spec.data), &got); err != nil {
+				t.Fatalf("m.Unmarshal failed with %v; want success", err)
+			}
+			if !proto.Equal(&got, spec.want) {
+				t.Errorf("m.Unmarshal = %v; want %v", &got, spec.want)
+			}
+		})
+	}
+}
+
+func TestCBORUnmarshalErrors(t *testing.T) {
+	m := &runtime.CBOR{}
+	for _, data := range []string{
+		// Empty.
+		"",
+		// Truncated map.
+		"a162696463666f",
+		// Trailing data.
+		"a0a0",
+		// Invalid UTF-8.
+		"a162696461ff",
+		// Unsupported map key.
+		"a1a000",
+		// Unexpected break.
+		"ff",
+	} {
+		var got examplepb.SimpleMessage
+		if err := m.Unmarshal(mustDecodeHex(t, data), &got); err == nil {
+			t.Errorf("m.Unmarshal(%s) succeeded; want an error", data)
+		}
+	}
+}
+
+func TestCBORStream(t *testing.T) {
+	m := &runtime.CBOR{}
+	if d := m.Delimiter(); len(d) != 0 {
+		t.Errorf("m.Delimiter() = %q; want empty", d)
+	}
+
+	msgs := []*examplepb.SimpleMessage{{Id: "foo"}, {Id: "bar"}}
+	var buf bytes.Buffer
+	enc := m.NewEncoder(&buf)
+	for _, msg := range msgs {
+		if err := enc.Encode(msg); err != nil {
+			t.Fatalf("enc.Encode(%v) failed with %v; want success", msg, err)
+		}
+	}
+
+	dec := m.NewDecoder(&buf)
+	for _, want := range msgs {
+		var got examplepb.SimpleMessage
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("dec.Decode failed with %v; want success", err)
+		}
+		if !proto.Equal(&got, want) {
+			t.Errorf("dec.Decode = %v; want %v", &got, want)
+		}
+	}
+	var got examplepb.SimpleMessage
+	if err := dec.Decode(&got); err != io.EOF {
+		t.Errorf("dec.Decode = %v; want io.EOF", err)
+	}
+}
@@ -0,0 +1,290 @@
+package runtime
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// defaultMaxFormSize is the default maximum size of an application/x-www-form-urlencoded
+	// request body, the limit applied by (*http.Request).ParseForm.
+	defaultMaxFormSize = 10 << 20
+	// defaultMaxMultipartFormSize is the default maximum size of a multipart/form-data request body.
+	defaultMaxMultipartFormSize = 32 << 20
+)
+
+// FormMarshaler is a Marshaler which unmarshals application/x-www-form-urlencoded request
+// bodies into messages, the way DefaultQueryParser populates messages from query strings:
+// the keys are field paths such as "nested.name", and map entries are set with keys such
+// as "map_value[key]".
+//
+// Forms cannot represent responses, which are marshaled by the embedded Marshaler,
+// or by the default JSONPb marshaler if it is nil.
+type FormMarshaler struct {
+	Marshaler
+
+	// MaxSize is the maximum size of a request body, 10 MiB if zero.
+	MaxSize int64
+}
+
+// ContentType returns the content type of the Marshaler.
+func (f *FormMarshaler) ContentType(v interface{}) string {
+	return formOutboundMarshaler(f.Marshaler).ContentType(v)
+}
+
+// Marshal marshals "v" with the Marshaler.
+func (f *FormMarshaler) Marshal(v interface{}) ([]byte, error) {
+	return formOutboundMarshaler(f.Marshaler).Marshal(v)
+}
+
+// NewEncoder returns the Encoder of the Marshaler.
+func (f *FormMarshaler) NewEncoder(w io.Writer) Encoder {
+	return formOutboundMarshaler(f.Marshaler).NewEncoder(w)
+}
+
+// Unmarshal unmarshals the urlencoded form "data" into "v", which must be a message.
+func (f *FormMarshaler) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return errors.New("unable to unmarshal a form into a non proto field")
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	return populateFormValues(msg, values)
+}
+
+// NewDecoder returns a Decoder which reads a urlencoded form from "r".
+func (f *FormMarshaler) NewDecoder(r io.Reader) Decoder {
+	return DecoderFunc(func(v interface{}) error {
+		maxSize := f.MaxSize
+		if maxSize <= 0 {
+			maxSize = defaultMaxFormSize
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+		if err != nil {
+			return err
+		}
+		if int64(len(data)) > maxSize {
+			return fmt.Errorf("form exceeds the maximum size of %d bytes", maxSize)
+		}
+		return f.Unmarshal(data, v)
+	})
+}
+
+// MultipartFormMarshaler is a Marshaler which unmarshals multipart/form-data request bodies
+// into messages. The values of the form are populated like FormMarshaler populates them, and
+// file parts are mapped onto the bytes or google.api.HttpBody fields named by their form
+// names, repeated fields receiving every file of the same name. A file part sets both the
+// content type and the data of an HttpBody. If the message unmarshaled into is itself an
+// HttpBody, it is set from the only file part of the form.
+//
+// The multipart boundary is taken from the Content-Type header of the request by ForRequest,
+// which MarshalerForRequest calls.
+//
+// Forms cannot represent responses, which are marshaled by the embedded Marshaler,
+// or by the default JSONPb marshaler if it is nil.
+type MultipartFormMarshaler struct {
+	Marshaler
+
+	// MaxSize is the maximum size of the values and files of a form, 32 MiB if zero.
+	MaxSize int64
+	// MaxFileSize is the maximum size of a file, MaxSize if zero.
+	MaxFileSize int64
+	// Boundary is the boundary of the multipart forms read by the Decoders. It is set by
+	// ForRequest, and must be set to decode forms which are not read from a request.
+	Boundary string
+}
+
+// ForRequest returns a copy of the MultipartFormMarshaler reading the forms with the boundary
+// of the Content-Type header of "r".
+func (m *MultipartFormMarshaler) ForRequest(r *http.Request) Marshaler {
+	bound := *m
+	bound.Boundary = ""
+	if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+		bound.Boundary = params["boundary"]
+	}
+	return &bound
+}
+
+// ContentType returns the content type of the Marshaler.
+func (m *MultipartFormMarshaler) ContentType(v interface{}) string {
+	return formOutboundMarshaler(m.Marshaler).ContentType(v)
+}
+
+// Marshal marshals "v" with the Marshaler.
+func (m *MultipartFormMarshaler) Marshal(v interface{}) ([]byte, error) {
+	return formOutboundMarshaler(m.Marshaler).Marshal(v)
+}
+
+// NewEncoder returns the Encoder of the Marshaler.
+func (m *MultipartFormMarshaler) NewEncoder(w io.Writer) Encoder {
+	return formOutboundMarshaler(m.Marshaler).NewEncoder(w)
+}
+
+// Unmarshal unmarshals the multipart form "data" into "v", which must be a message.
+func (m *MultipartFormMarshaler) Unmarshal(data []byte, v interface{}) error {
+	return m.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// NewDecoder returns a Decoder which reads a multipart form from "r".
+func (m *MultipartFormMarshaler) NewDecoder(r io.Reader) Decoder {
+	return DecoderFunc(func(v interface{}) error {
+		msg, ok := v.(proto.Message)
+		if !ok {
+			return errors.New("unable to unmarshal a form into a non proto field")
+		}
+		if m.Boundary == "" {
+			return errors.New("multipart form has no boundary")
+		}
+		return m.populate(msg, multipart.NewReader(r, m.Boundary))
+	})
+}
+
+func (m *MultipartFormMarshaler) populate(msg proto.Message, mr *multipart.Reader) error {
+	remaining := m.MaxSize
+	if remaining <= 0 {
+		remaining = defaultMaxMultipartFormSize
+	}
+	values := make(url.Values)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := part.FormName()
+		if name == "" {
+			continue
+		}
+		limit := remaining
+		if part.FileName() != "" && m.MaxFileSize > 0 && m.MaxFileSize < limit {
+			limit = m.MaxFileSize
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(part, limit+1))
+		if err != nil {
+			return err
+		}
+		if int64(len(data)) > limit {
+			return fmt.Errorf("form part %q exceeds the maximum size of %d bytes", name, limit)
+		}
+		remaining -= int64(len(data))
+
+		if part.FileName() == "" {
+			values.Add(name, string(data))
+			continue
+		}
+		if err := populateFormFile(msg.ProtoReflect(), strings.Split(name, "."), part.Header.Get("Content-Type"), data); err != nil {
+			return err
+		}
+	}
+	return populateFormValues(msg, values)
+}
+
+// populateFormValues populates "values" into "msg" like DefaultQueryParser.
+func populateFormValues(msg proto.Message, values url.Values) error {
+	return (&DefaultQueryParser{}).Parse(msg, values, utilities.NewDoubleArray(nil))
+}
+
+// populateFormFile sets the bytes or google.api.HttpBody field at "fieldPath" in "msgValue"
+// to a file of "data". Unknown fields are ignored, like unknown form values.
+func populateFormFile(msgValue protoreflect.Message, fieldPath []string, contentType string, data []byte) error {
+	if isHTTPBody(msgValue.Descriptor()) {
+		fields := msgValue.Descriptor().Fields()
+		if msgValue.Has(fields.ByName("content_type")) || msgValue.Has(fields.ByName("data")) {
+			return errors.New("too many files for google.api.HttpBody")
+		}
+		setHTTPBody(msgValue, contentType, data)
+		return nil
+	}
+
+	var fieldDescriptor protoreflect.FieldDescriptor
+	for i, fieldName := range fieldPath {
+		fields := msgValue.Descriptor().Fields()
+		fieldDescriptor = fields.ByName(protoreflect.Name(fieldName))
+		if fieldDescriptor == nil {
+			fieldDescriptor = fields.ByJSONName(fieldName)
+			if fieldDescriptor == nil {
+				grpclog.Infof("field not found in %q: %q", msgValue.Descriptor().FullName(), strings.Join(fieldPath, "."))
+				return nil
+			}
+		}
+		if i == len(fieldPath)-1 {
+			break
+		}
+		if fieldDescriptor.Message() == nil || fieldDescriptor.Cardinality() == protoreflect.Repeated {
+			return fmt.Errorf("invalid path: %q is not a message", fieldName)
+		}
+		msgValue = msgValue.Mutable(fieldDescriptor).Message()
+	}
+
+	if fieldDescriptor.IsMap() {
+		return fmt.Errorf("map field %q cannot hold a file", fieldDescriptor.FullName().Name())
+	}
+	if of := fieldDescriptor.ContainingOneof(); of != nil {
+		if f := msgValue.WhichOneof(of); f != nil {
+			return fmt.Errorf("field already set for oneof %q", of.FullName().Name())
+		}
+	}
+
+	var v protoreflect.Value
+	switch {
+	case fieldDescriptor.Kind() == protoreflect.BytesKind:
+		v = protoreflect.ValueOfBytes(data)
+	case fieldDescriptor.Message() != nil && isHTTPBody(fieldDescriptor.Message()):
+		var body protoreflect.Message
+		if fieldDescriptor.IsList() {
+			body = msgValue.Mutable(fieldDescriptor).List().NewElement().Message()
+		} else {
+			body = msgValue.NewField(fieldDescriptor).Message()
+		}
+		setHTTPBody(body, contentType, data)
+		v = protoreflect.ValueOfMessage(body)
+	default:
+		return fmt.Errorf("field %q cannot hold a file", fieldDescriptor.FullName().Name())
+	}
+
+	if fieldDescriptor.IsList() {
+		msgValue.Mutable(fieldDescriptor).List().Append(v)
+		return nil
+	}
+	if msgValue.Has(fieldDescriptor) {
+		return fmt.Errorf("too many files for field %q", fieldDescriptor.FullName().Name())
+	}
+	msgValue.Set(fieldDescriptor, v)
+	return nil
+}
+
+func isHTTPBody(md protoreflect.MessageDescriptor) bool {
+	return md.FullName() == "google.api.HttpBody"
+}
+
+func setHTTPBody(body protoreflect.Message, contentType string, data []byte) {
+	fields := body.Descriptor().Fields()
+	body.Set(fields.ByName("content_type"), protoreflect.ValueOfString(contentType))
+	body.Set(fields.ByName("data"), protoreflect.ValueOfBytes(data))
+}
+
+// formOutboundMarshaler returns "m", or the default marshaler if it is nil.
+func formOutboundMarshaler(m Marshaler) Marshaler {
+	if m == nil {
+		return defaultMarshaler
+	}
+	return m
+}
@@ -0,0 +1,265 @@
+package runtime_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFormMarshalerDecode(t *testing.T) {
+	m := &runtime.FormMarshaler{}
+	for _, spec := range []struct {
+		name string
+		body string
+		want *examplepb.Proto3Message
+	}{
+		{
+			name: "empty",
+			want: &examplepb.Proto3Message{},
+		},
+		{
+			name: "values",
+			body: "string_value=a+b&int32Value=-5&repeated_value=x&repeated_value=y&nested.bool_value=true&map_value[k]=v&timestamp_value=2016-12-15T05:02:00Z",
+			want: &examplepb.Proto3Message{
+				StringValue:    "a b",
+				Int32Value:     -5,
+				RepeatedValue:  []string{"x", "y"},
+				Nested:         &examplepb.Proto3Message{BoolValue: true},
+				MapValue:       map[string]string{"k": "v"},
+				TimestampValue: &timestamppb.Timestamp{Seconds: 1481778120},
+			},
+		},
+		{
+			name: "unknown field",
+			body: "unknown=1&bool_value=1",
+			want: &examplepb.Proto3Message{BoolValue: true},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var got examplepb.Proto3Message
+			if err := m.NewDecoder(strings.NewReader(spec.body)).Decode(&got); err != nil {
+				t.Fatalf("m.NewDecoder(%q).Decode failed with %v; want success", spec.body, err)
+			}
+			if !proto.Equal(&got, spec.want) {
+				t.Errorf("m.NewDecoder(%q).Decode = %v; want %v", spec.body, &got, spec.want)
+			}
+		})
+	}
+}
+
+func TestFormMarshalerDecodeErrors(t *testing.T) {
+	for _, spec := range []struct {
+		name string
+		m    *runtime.FormMarshaler
+		body string
+		v    interface{}
+	}{
+		{name: "invalid value", m: &runtime.FormMarshaler{}, body: "int32_value=a", v: &examplepb.Proto3Message{}},
+		{name: "invalid encoding", m: &runtime.FormMarshaler{}, body: "string_value=%zz", v: &examplepb.Proto3Message{}},
+		{name: "too large", m: &runtime.FormMarshaler{MaxSize: 4}, body: "string_value=a", v: &examplepb.Proto3Message{}},
+		{name: "non message", m: &runtime.FormMarshaler{}, body: "a=b", v: new(string)},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			if err := spec.m.NewDecoder(strings.NewReader(spec.body)).Decode(spec.v); err == nil {
+				t.Errorf("m.NewDecoder(%q).Decode succeeded; want an error", spec.body)
+			}
+		})
+	}
+}
+
+func TestFormMarshalerMarshal(t *testing.T) {
+	msg := &examplepb.SimpleMessage{Id: "foo"}
+	for _, spec := range []struct {
+		m               runtime.Marshaler
+		wantContentType string
+		wantBody        string
+	}{
+		{m: &runtime.FormMarshaler{}, wantContentType: "application/json", wantBody: `{"id":"foo"`},
+		{m: &runtime.MultipartFormMarshaler{Marshaler: &runtime.ProtoMarshaller{}}, wantContentType: "application/octet-stream", wantBody: "\n\x03foo"},
+	} {
+		if got := spec.m.ContentType(msg); got != spec.wantContentType {
+			t.Errorf("m.ContentType = %q; want %q", got, spec.wantContentType)
+		}
+		got, err := spec.m.Marshal(msg)
+		if err != nil {
+			t.Fatalf("m.Marshal failed with %v; want success", err)
+		}
+		if !strings.HasPrefix(string(got), spec.wantBody) {
+			t.Errorf("m.Marshal = %q; want to start with %q", got, spec.wantBody)
+		}
+	}
+}
+
+type formPart struct {
+	name, fileName, contentType, value string
+}
+
+// formBoundary is the boundary of the forms returned by multipartForm.
+const formBoundary = "gateway-form-boundary"
+
+func multipartForm(t *testing.T, parts ...formPart) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.SetBoundary(formBoundary); err != nil {
+		t.Fatalf("w.SetBoundary(%q) failed with %v; want success", formBoundary, err)
+	}
+	for _, p := range parts {
+		var (
+			pw  io.Writer
+			err error
+		)
+		if p.fileName == "" {
+			pw, err = w.CreateFormField(p.name)
+		} else {
+			h := make(textproto.MIMEHeader)
+			h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, p.name, p.fileName))
+			h.Set("Content-Type", p.contentType)
+			pw, err = w.CreatePart(h)
+		}
+		if err != nil {
+			t.Fatalf("failed to create part %q: %v", p.name, err)
+		}
+		if _, err := pw.Write([]byte(p.value)); err != nil {
+			t.Fatalf("failed to write part %q: %v", p.name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("w.Close failed with %v; want success", err)
+	}
+	return buf.Bytes()
+}
+
+func TestMultipartFormMarshalerDecode(t *testing.T) {
+	m := &runtime.MultipartFormMarshaler{Boundary: formBoundary}
+	for _, spec := range []struct {
+		name  string
+		parts []formPart
+		v     proto.Message
+		want  proto.Message
+	}{
+		{
+			name: "values and files",
+			parts: []formPart{
+				{name: "string_value", value: "foo"},
+				{name: "repeated_value", value: "a"},
+				{name: "repeated_value", value: "b"},
+				{name: "bytes_value", fileName: "a.bin", contentType: "application/octet-stream", value: "\x00\x01"},
+				{name: "nested.bytesValue", fileName: "b.txt", contentType: "text/plain", value: "hello"},
+				{name: "unknown", fileName: "c.txt", contentType: "text/plain", value: "ignored"},
+			},
+			v: &examplepb.Proto3Message{},
+			want: &examplepb.Proto3Message{
+				StringValue:   "foo",
+				RepeatedValue: []string{"a", "b"},
+				BytesValue:    []byte{0, 1},
+				Nested:        &examplepb.Proto3Message{BytesValue: []byte("hello")},
+			},
+		},
+		{
+			name: "http body",
+			parts: []formPart{
+				{name: "file", fileName: "a.png", contentType: "image/png", value: "\x89PNG"},
+			},
+			v:    &httpbody.HttpBody{},
+			want: &httpbody.HttpBody{ContentType: "image/png", Data: []byte("\x89PNG")},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			body := multipartForm(t, spec.parts...)
+			if err := m.NewDecoder(bytes.NewReader(body)).Decode(spec.v); err != nil {
+				t.Fatalf("m.NewDecoder(%q).Decode failed with %v; want success", body, err)
+			}
+			if !proto.Equal(spec.v, spec.want) {
+				t.Errorf("m.NewDecoder(%q).Decode = %v; want %v", body, spec.v, spec.want)
+			}
+		})
+	}
+}
+
+func TestMultipartFormMarshalerDecodeErrors(t *testing.T) {
+	for _, spec := range []struct {
+		name string
+		m    *runtime.MultipartFormMarshaler
+		body []byte
+	}{
+		{
+			name: "no boundary",
+			m:    &runtime.MultipartFormMarshaler{},
+			body: multipartForm(t, formPart{name: "string_value", value: "hello"}),
+		},
+		{
+			name: "other boundary",
+			m:    &runtime.MultipartFormMarshaler{Boundary: "other"},
+			body: multipartForm(t, formPart{name: "string_value", value: "hello"}),
+		},
+		{
+			name: "file too large",
+			m:    &runtime.MultipartFormMarshaler{MaxFileSize: 4, Boundary: formBoundary},
+			body: multipartForm(t, formPart{name: "bytes_value", fileName: "a", contentType: "text/plain", value: "hello"}),
+		},
+		{
+			name: "form too large",
+			m:    &runtime.MultipartFormMarshaler{MaxSize: 8, Boundary: formBoundary},
+			body: multipartForm(t, formPart{name: "string_value", value: "hello"}, formPart{name: "repeated_value", value: "world"}),
+		},
+		{
+			name: "file into a string field",
+			m:    &runtime.MultipartFormMarshaler{Boundary: formBoundary},
+			body: multipartForm(t, formPart{name: "string_value", fileName: "a", contentType: "text/plain", value: "hello"}),
+		},
+		{
+			name: "too many files",
+			m:    &runtime.MultipartFormMarshaler{Boundary: formBoundary},
+			body: multipartForm(t,
+				formPart{name: "bytes_value", fileName: "a", contentType: "text/plain", value: "a"},
+				formPart{name: "bytes_value", fileName: "b", contentType: "text/plain", value: "b"},
+			),
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var got examplepb.Proto3Message
+			if err := spec.m.NewDecoder(bytes.NewReader(spec.body)).Decode(&got); err == nil {
+				t.Errorf("m.NewDecoder(%q).Decode succeeded; want an error", spec.body)
+			}
+		})
+	}
+}
+
+func TestMultipartFormMarshalerForRequest(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption("multipart/form-data", &runtime.MultipartFormMarshaler{}))
+	form := multipartForm(t, formPart{name: "string_value", value: "foo"})
+	for _, spec := range []struct {
+		name string
+		body string
+	}{
+		{name: "no preamble", body: string(form)},
+		{name: "preamble", body: "This is a preamble.\r\n" + string(form)},
+		{name: "preamble starting with dashes", body: "--not-the-boundary\r\n" + string(form)},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(spec.body))
+			r.Header.Set("Content-Type", "multipart/form-data; boundary="+formBoundary)
+			inbound, _ := runtime.MarshalerForRequest(mux, r)
+			var got examplepb.Proto3Message
+			if err := inbound.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("inbound.NewDecoder(%q).Decode failed with %v; want success", spec.body, err)
+			}
+			if want := (&examplepb.Proto3Message{StringValue: "foo"}); !proto.Equal(&got, want) {
+				t.Errorf("inbound.NewDecoder(%q).Decode = %v; want %v", spec.body, &got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MsgPack is a Marshaler which marshals/unmarshals into/from MessagePack,
+// see https://github.com/msgpack/msgpack/blob/master/spec.md.
+//
+// Messages are mapped with protoreflect the way JSONPb maps them to JSON, with the same field
+// names and representations of the well-known types, except that integer fields, including
+// 64-bit ones, are encoded as MessagePack integers and bytes fields as binary values. When
+// unmarshaling, the JSON representations are also accepted, as well as integer and boolean map
+// keys for the corresponding map fields and timestamp extension values for Timestamps.
+//
+// Streams are concatenated MessagePack values, which are not delimited.
+type MsgPack struct {
+	protojson.MarshalOptions
+	protojson.UnmarshalOptions
+}
+
+// ContentType always returns "application/msgpack".
+func (*MsgPack) ContentType(_ interface{}) string {
+	return "application/msgpack"
+}
+
+// Marshal marshals "v" into MessagePack.
+func (m *MsgPack) Marshal(v interface{}) ([]byte, error) {
+	bv, err := binaryEncoder{m.MarshalOptions}.value(v)
+	if err != nil {
+		return nil, err
+	}
+	return appendMsgPack(nil, bv), nil
+}
+
+// Unmarshal unmarshals MessagePack "data" into "v".
+func (m *MsgPack) Unmarshal(data []byte, v interface{}) error {
+	d := &msgPackDecoder{r: bufio.NewReader(bytes.NewReader(data))}
+	j, err := d.decodeJSON()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if _, err := d.r.Peek(1); err != io.EOF {
+		return errors.New("unexpected data after MessagePack value")
+	}
+	return m.jsonPb().Unmarshal(j, v)
+}
+
+// NewDecoder returns a Decoder which reads a stream of MessagePack values from "r".
+func (m *MsgPack) NewDecoder(r io.Reader) Decoder {
+	d := &msgPackDecoder{r: bufio.NewReader(r)}
+	return DecoderFunc(func(v interface{}) error {
+		j, err := d.decodeJSON()
+		if err != nil {
+			return err
+		}
+		return m.jsonPb().Unmarshal(j, v)
+	})
+}
+
+// NewEncoder returns an Encoder which writes a stream of MessagePack values into "w".
+func (m *MsgPack) NewEncoder(w io.Writer) Encoder {
+	return EncoderFunc(func(v interface{}) error {
+		b, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	})
+}
+
+// Delimiter returns nil, MessagePack values are self-delimiting.
+func (*MsgPack) Delimiter() []byte {
+	return nil
+}
+
+func (m *MsgPack) jsonPb() *JSONPb {
+	return &JSONPb{MarshalOptions: m.MarshalOptions, UnmarshalOptions: m.UnmarshalOptions}
+}
+
+// msgPackExtTimestamp is the type of the timestamp extension.
+const msgPackExtTimestamp = -1
+
+// appendMsgPack appends the MessagePack encoding of "v", see binaryEncoder.
+func appendMsgPack(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if v {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case string:
+		n := uint64(len(v))
+		switch {
+		case n < 32:
+			b = append(b, 0xa0|byte(n))
+		case n <= math.MaxUint8:
+			b = append(b, 0xd9, byte(n))
+		case n <= math.MaxUint16:
+			b = appendBigEndian(append(b, 0xda), n, 2)
+		default:
+			b = appendBigEndian(append(b, 0xdb), n, 4)
+		}
+		return append(b, v...)
+	case []byte:
+		n := uint64(len(v))
+		switch {
+		case n <= math.MaxUint8:
+			b = append(b, 0xc4, byte(n))
+		case n <= math.MaxUint16:
+			b = appendBigEndian(append(b, 0xc5), n, 2)
+		default:
+			b = appendBigEndian(append(b, 0xc6), n, 4)
+		}
+		return append(b, v...)
+	case int64:
+		switch {
+		case v >= 0:
+			return appendMsgPackUint(b, uint64(v))
+		case v >= -32:
+			return append(b, byte(v))
+		case v >= math.MinInt8:
+			return append(b, 0xd0, byte(v))
+		case v >= math.MinInt16:
+			return appendBigEndian(append(b, 0xd1), uint64(v), 2)
+		case v >= math.MinInt32:
+			return appendBigEndian(append(b, 0xd2), uint64(v), 4)
+		default:
+			return appendBigEndian(append(b, 0xd3), uint64(v), 8)
+		}
+	case uint64:
+		return appendMsgPackUint(b, v)
+	case float64:
+		if f := float32(v); float64(f) == v {
+			return appendBigEndian(append(b, 0xca), uint64(math.Float32bits(f)), 4)
+		}
+		return appendBigEndian(append(b, 0xcb), math.Float64bits(v), 8)
+	case []interface{}:
+		b = appendMsgPackContainer(b, 0x90, 0xdc, uint64(len(v)))
+		for _, e := range v {
+			b = appendMsgPack(b, e)
+		}
+		return b
+	case []jsonMember:
+		b = appendMsgPackContainer(b, 0x80, 0xde, uint64(len(v)))
+		for _, m := range v {
+			b = appendMsgPack(b, m.key)
+			b = appendMsgPack(b, m.value)
+		}
+		return b
+	}
+	panic(fmt.Sprintf("unexpected value %T", v))
+}
+
+func appendMsgPackUint(b []byte, v uint64) []byte {
+	switch {
+	case v <= math.MaxInt8:
+		return append(b, byte(v))
+	case v <= math.MaxUint8:
+		return append(b, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return appendBigEndian(append(b, 0xcd), v, 2)
+	case v <= math.MaxUint32:
+		return appendBigEndian(append(b, 0xce), v, 4)
+	default:
+		return appendBigEndian(append(b, 0xcf), v, 8)
+	}
+}
+
+// appendMsgPackContainer appends the header of an array or a map, "fix" being the
+// format of short containers and "format16" the one of 16 bits long containers.
+func appendMsgPackContainer(b []byte, fix, format16 byte, n uint64) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return appendBigEndian(append(b, format16), n, 2)
+	default:
+		return appendBigEndian(append(b, format16+1), n, 4)
+	}
+}
+
+// msgPackDecoder transcodes MessagePack values to JSON.
+type msgPackDecoder struct {
+	r *bufio.Reader
+}
+
+// decodeJSON reads the next MessagePack value and returns it transcoded to JSON.
+// It returns io.EOF if there are no more values.
+func (d *msgPackDecoder) decodeJSON() ([]byte, error) {
+	if _, err := d.r.Peek(1); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := d.value(&buf, 0); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *msgPackDecoder) value(buf *bytes.Buffer, depth int) error {
+	if depth > maxTranscodeDepth {
+		return errTranscodeDepth
+	}
+	c, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch {
+	case c <= 0x7f:
+		buf.WriteString(strconv.Itoa(int(c)))
+		return nil
+	case c >= 0xe0:
+		buf.WriteString(strconv.Itoa(int(int8(c))))
+		return nil
+	case c <= 0x8f:
+		return d.mapValue(buf, depth, uint64(c&0x0f))
+	case c <= 0x9f:
+		return d.array(buf, depth, uint64(c&0x0f))
+	case c <= 0xbf:
+		return d.str(buf, uint64(c&0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		buf.WriteString("null")
+	case 0xc2:
+		buf.WriteString("false")
+	case 0xc3:
+		buf.WriteString("true")
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readBigEndian(d.r, 1<<(c-0xc4))
+		if err != nil {
+			return err
+		}
+		b, err := readTranscodeBytes(d.r, n)
+		if err != nil {
+			return err
+		}
+		writeJSONBytes(buf, b)
+	case 0xc7, 0xc8, 0xc9:
+		n, err := readBigEndian(d.r, 1<<(c-0xc7))
+		if err != nil {
+			return err
+		}
+		return d.ext(buf, n)
+	case 0xca:
+		bits, err := readBigEndian(d.r, 4)
+		if err != nil {
+			return err
+		}
+		writeJSONFloat(buf, float64(math.Float32frombits(uint32(bits))))
+	case 0xcb:
+		bits, err := readBigEndian(d.r, 8)
+		if err != nil {
+			return err
+		}
+		writeJSONFloat(buf, math.Float64frombits(bits))
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := readBigEndian(d.r, 1<<(c-0xcc))
+		if err != nil {
+			return err
+		}
+		buf.WriteString(strconv.FormatUint(v, 10))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		v, err := readBigEndian(d.r, size)
+		if err != nil {
+			return err
+		}
+		// Sign extend the value.
+		shift := 64 - 8*uint(size)
+		buf.WriteString(strconv.FormatInt(int64(v<<shift)>>shift, 10))
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.ext(buf, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := readBigEndian(d.r, 1<<(c-0xd9))
+		if err != nil {
+			return err
+		}
+		return d.str(buf, n)
+	case 0xdc, 0xdd:
+		n, err := readBigEndian(d.r, 2<<(c-0xdc))
+		if err != nil {
+			return err
+		}
+		return d.array(buf, depth, n)
+	case 0xde, 0xdf:
+		n, err := readBigEndian(d.r, 2<<(c-0xde))
+		if err != nil {
+			return err
+		}
+		return d.mapValue(buf, depth, n)
+	default:
+		return fmt.Errorf("invalid MessagePack format 0x%x", c)
+	}
+	return nil
+}
+
+func (d *msgPackDecoder) str(buf *bytes.Buffer, n uint64) error {
+	b, err := readTranscodeBytes(d.r, n)
+	if err != nil {
+		return err
+	}
+	if !utf8.Valid(b) {
+		return errors.New("invalid UTF-8 in MessagePack string")
+	}
+	writeJSONString(buf, string(b))
+	return nil
+}
+
+func (d *msgPackDecoder) array(buf *bytes.Buffer, depth int, n uint64) error {
+	buf.WriteByte('[')
+	for i := uint64(0); i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := d.value(buf, depth+1); err != nil {
+			return unexpectedEOF(err)
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func (d *msgPackDecoder) mapValue(buf *bytes.Buffer, depth int, n uint64) error {
+	buf.WriteByte('{')
+	for i := uint64(0); i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := d.key(buf); err != nil {
+			return unexpectedEOF(err)
+		}
+		buf.WriteByte(':')
+		if err := d.value(buf, depth+1); err != nil {
+			return unexpectedEOF(err)
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// key transcodes a map key to a JSON string. Integer and boolean keys are converted
+// to their string representation, which JSONPb accepts for the corresponding map fields.
+func (d *msgPackDecoder) key(buf *bytes.Buffer) error {
+	c, err := d.r.Peek(1)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c[0] >= 0xa0 && c[0] <= 0xbf, c[0] >= 0xd9 && c[0] <= 0xdb:
+		return d.value(buf, 0)
+	case c[0] <= 0x7f, c[0] >= 0xe0, c[0] >= 0xcc && c[0] <= 0xd3, c[0] == 0xc2, c[0] == 0xc3:
+		var key bytes.Buffer
+		if err := d.value(&key, 0); err != nil {
+			return err
+		}
+		writeJSONString(buf, key.String())
+		return nil
+	}
+	return fmt.Errorf("unsupported MessagePack map key format 0x%x", c[0])
+}
+
+// ext transcodes an extension value of length "n". Only the timestamp extension is
+// supported, it is transcoded to a RFC 3339 JSON string.
+func (d *msgPackDecoder) ext(buf *bytes.Buffer, n uint64) error {
+	typ, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if int8(typ) != msgPackExtTimestamp {
+		return fmt.Errorf("unsupported MessagePack extension type %d", int8(typ))
+	}
+	var t time.Time
+	switch n {
+	case 4:
+		sec, err := readBigEndian(d.r, 4)
+		if err != nil {
+			return err
+		}
+		t = time.Unix(int64(sec), 0)
+	case 8:
+		v, err := readBigEndian(d.r, 8)
+		if err != nil {
+			return err
+		}
+		t = time.Unix(int64(v&(1<<34-1)), int64(v>>34))
+	case 12:
+		nsec, err := readBigEndian(d.r, 4)
+		if err != nil {
+			return err
+		}
+		sec, err := readBigEndian(d.r, 8)
+		if err != nil {
+			return err
+		}
+		t = time.Unix(int64(sec), int64(nsec))
+	default:
+		return fmt.Errorf("invalid MessagePack timestamp length %d", n)
+	}
+	writeJSONTime(buf, t)
+	return nil
+}
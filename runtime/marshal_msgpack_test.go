@@ -0,0 +1,174 @@
+package runtime_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMsgPackMarshal(t *testing.T) {
+	m := &runtime.MsgPack{}
+	for _, spec := range []struct {
+		v    interface{}
+		want string
+	}{
+		// {"id": "foo"}
+		{v: &examplepb.SimpleMessage{Id: "foo"}, want: "81a26964a3666f6f"},
+		// {}
+		{v: &examplepb.SimpleMessage{}, want: "80"},
+		// {"result": {"id": "a"}}
+		{v: map[string]proto.Message{"result": &examplepb.SimpleMessage{Id: "a"}}, want: "81a6726573756c7481a26964a161"},
+		// {"floatValue": 1.5, "int32Value": -100000, "boolValue": true}
+		{
+			v:    &examplepb.ABitOfEverything{FloatValue: 1.5, Int32Value: -100000, BoolValue: true},
+			want: "83aa666c6f617456616c7565ca3fc00000aa696e74333256616c7565d2fffe7960a9626f6f6c56616c7565c3",
+		},
+		// {"int64Value": -1 << 40, "uint64Value": 1<<64 - 1, "fixed64Value": 1 << 33, "bytesValue": h'0001ff'},
+		// with MessagePack integers and a binary value rather than strings.
+		{
+			v: &examplepb.ABitOfEverything{
+				Int64Value:   -1 << 40,
+				Uint64Value:  0xFFFFFFFFFFFFFFFF,
+				Fixed64Value: 1 << 33,
+				BytesValue:   []byte{0, 1, 0xff},
+			},
+			want: "84aa696e74363456616c7565d3ffffff0000000000ab75696e74363456616c7565cfffffffffffffffffac6669786564363456616c7565cf0000000200000000aa627974657356616c7565c4030001ff",
+		},
+		// The wrappers are mapped to their value.
+		{v: wrapperspb.Int64(1 << 40), want: "cf0000010000000000"},
+		{v: wrapperspb.Bytes([]byte{1}), want: "c40101"},
+		{v: "text", want: "a474657874"},
+	} {
+		got, err := m.Marshal(spec.v)
+		if err != nil {
+			t.Errorf("m.Marshal(%v) failed with %v; want success", spec.v, err)
+			continue
+		}
+		if want := mustDecodeHex(t, spec.want); !bytes.Equal(got, want) {
+			t.Errorf("m.Marshal(%v) = %x; want %x", spec.v, got, want)
+		}
+	}
+}
+
+func TestMsgPackRoundTrip(t *testing.T) {
+	for _, m := range []*runtime.MsgPack{
+		{},
+		{MarshalOptions: protojson.MarshalOptions{UseProtoNames: true, UseEnumNumbers: true, EmitUnpopulated: true}},
+	} {
+		for _, msg := range []proto.Message{binaryTestMessage, binaryTestWellKnownTypes} {
+			b, err := m.Marshal(msg)
+			if err != nil {
+				t.Fatalf("m.Marshal failed with %v; want success", err)
+			}
+			got := msg.ProtoReflect().New().Interface()
+			if err := m.Unmarshal(b, got); err != nil {
+				t.Fatalf("m.Unmarshal(%x) failed with %v; want success", b, err)
+			}
+			if !proto.Equal(got, msg) {
+				t.Errorf("m.Unmarshal(m.Marshal(msg)) = %v; want %v", got, msg)
+			}
+		}
+	}
+}
+
+func TestMsgPackUnmarshal(t *testing.T) {
+	m := &runtime.MsgPack{}
+	for _, spec := range []struct {
+		name string
+		data string
+		want *examplepb.ABitOfEverything
+	}{
+		{
+			// {"bytesValue": bin8 0001}
+			name: "binary",
+			data: "81aa627974657356616c7565c4020001",
+			want: &examplepb.ABitOfEverything{BytesValue: []byte{0, 1}},
+		},
+		{
+			// {"timestampValue": timestamp32 1500000000}
+			name: "timestamp",
+			data: "81ae74696d657374616d7056616c7565d6ff59682f00",
+			want: &examplepb.ABitOfEverything{TimestampValue: timestamppb.New(time.Unix(1500000000, 0))},
+		},
+		{
+			// {"doubleValue": float64 0.5, "int64Value": int8 -100, "uint32Value": uint16 300}
+			name: "numbers",
+			data: "83ab646f75626c6556616c7565cb3fe0000000000000aa696e74363456616c7565d09cab75696e74333256616c7565cd012c",
+			want: &examplepb.ABitOfEverything{DoubleValue: 0.5, Int64Value: -100, Uint32Value: 300},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var got examplepb.ABitOfEverything
+			if err := m.Unmarshal(mustDecodeHex(t, spec.data), &got); err != nil {
+				t.Fatalf("m.Unmarshal failed with %v; want success", err)
+			}
+			if !proto.Equal(&got, spec.want) {
+				t.Errorf("m.Unmarshal = %v; want %v", &got, spec.want)
+			}
+		})
+	}
+}
+
+func TestMsgPackUnmarshalErrors(t *testing.T) {
+	m := &runtime.MsgPack{}
+	for _, data := range []string{
+		// Empty.
+		"",
+		// Truncated map.
+		"81a26964a3666f",
+		// Trailing data.
+		"8080",
+		// Invalid UTF-8.
+		"81a26964a1ff",
+		// Unsupported map key.
+		"818000",
+		// Never used format.
+		"c1",
+		// Unsupported extension.
+		"d40100",
+	} {
+		var got examplepb.SimpleMessage
+		if err := m.Unmarshal(mustDecodeHex(t, data), &got); err == nil {
+			t.Errorf("m.Unmarshal(%s) succeeded; want an error", data)
+		}
+	}
+}
+
+func TestMsgPackStream(t *testing.T) {
+	m := &runtime.MsgPack{}
+	if d := m.Delimiter(); len(d) != 0 {
+		t.Errorf("m.Delimiter() = %q; want empty", d)
+	}
+
+	msgs := []*examplepb.SimpleMessage{{Id: "foo"}, {Id: "bar"}}
+	var buf bytes.Buffer
+	enc := m.NewEncoder(&buf)
+	for _, msg := range msgs {
+		if err := enc.Encode(msg); err != nil {
+			t.Fatalf("enc.Encode(%v) failed with %v; want success", msg, err)
+		}
+	}
+
+	dec := m.NewDecoder(&buf)
+	for _, want := range msgs {
+		var got examplepb.SimpleMessage
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("dec.Decode failed with %v; want success", err)
+		}
+		if !proto.Equal(&got, want) {
+			t.Errorf("dec.Decode = %v; want %v", &got, want)
+		}
+	}
+	var got examplepb.SimpleMessage
+	if err := dec.Decode(&got); err != io.EOF {
+		t.Errorf("dec.Decode = %v; want io.EOF", err)
+	}
+}
@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// defaultProtoDelimitedMaxSize is the default maximum size of a message decoded by ProtoDelimitedMarshaller.
+const defaultProtoDelimitedMaxSize = 4 << 20
+
+// ProtoDelimitedMarshaller is a Marshaller which marshals/unmarshals into/from serialized proto bytes,
+// like ProtoMarshaller, and frames streams by prefixing each message with its size as a varint,
+// the way protodelim and Java's writeDelimitedTo do.
+//
+// Marshal and Unmarshal handle a single message without its prefix; the Decoder, the Encoder
+// and the runtime, which implements LengthDelimited framing, read and write prefixed messages.
+type ProtoDelimitedMarshaller struct {
+	ProtoMarshaller
+
+	// MaxSize is the maximum size of a message read by the Decoder.
+	// It defaults to 4 MiB, the default maximum message size of gRPC servers.
+	MaxSize int
+}
+
+// ContentType always returns "application/x-protobuf-delimited".
+func (*ProtoDelimitedMarshaller) ContentType(_ interface{}) string {
+	return "application/x-protobuf-delimited"
+}
+
+// AppendLengthPrefix appends "size" as a varint to "b".
+func (*ProtoDelimitedMarshaller) AppendLengthPrefix(b []byte, size int) []byte {
+	return protowire.AppendVarint(b, uint64(size))
+}
+
+// NewDecoder returns a Decoder which reads a stream of size-prefixed proto messages from "reader".
+// Decode returns io.EOF at the end of the stream, and io.ErrUnexpectedEOF if it ends within a message.
+func (marshaller *ProtoDelimitedMarshaller) NewDecoder(reader io.Reader) Decoder {
+	r := bufio.NewReader(reader)
+	maxSize := marshaller.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultProtoDelimitedMaxSize
+	}
+	return DecoderFunc(func(value interface{}) error {
+		size, err := readUvarint(r)
+		if err != nil {
+			return err
+		}
+		if size > uint64(maxSize) {
+			return fmt.Errorf("message size %d exceeds the maximum of %d", size, maxSize)
+		}
+		buffer := make([]byte, size)
+		if _, err := io.ReadFull(r, buffer); err != nil {
+			return unexpectedEOF(err)
+		}
+		return marshaller.Unmarshal(buffer, value)
+	})
+}
+
+// NewEncoder returns an Encoder which writes a stream of size-prefixed proto messages into "writer".
+func (marshaller *ProtoDelimitedMarshaller) NewEncoder(writer io.Writer) Encoder {
+	return EncoderFunc(func(value interface{}) error {
+		buffer, err := marshaller.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(frameRecord(marshaller, buffer))
+		return err
+	})
+}
+
+// readUvarint reads a varint from "r". It returns io.EOF only if "r" is at its end.
+func readUvarint(r io.ByteReader) (uint64, error) {
+	var v uint64
+	for i := 0; i < protowire.SizeVarint(1<<63); i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			if i > 0 {
+				return 0, unexpectedEOF(err)
+			}
+			return 0, err
+		}
+		v |= uint64(b&0x7f) << (7 * uint(i))
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+	return 0, errors.New("invalid varint")
+}
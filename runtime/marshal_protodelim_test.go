@@ -0,0 +1,128 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoDelimitedMarshaller(t *testing.T) {
+	m := &runtime.ProtoDelimitedMarshaller{}
+	msgs := []*examplepb.SimpleMessage{{Id: "foo"}, {}, {Id: string(make([]byte, 200))}}
+
+	var buf bytes.Buffer
+	enc := m.NewEncoder(&buf)
+	for _, msg := range msgs {
+		if err := enc.Encode(msg); err != nil {
+			t.Fatalf("enc.Encode(%v) failed with %v; want success", msg, err)
+		}
+	}
+	if got, want := buf.Bytes()[:7], []byte{5, 0x0a, 3, 'f', 'o', 'o', 0}; !bytes.Equal(got, want) {
+		t.Errorf("encoded stream starts with %x; want %x", got, want)
+	}
+
+	dec := m.NewDecoder(&buf)
+	for _, want := range msgs {
+		var got examplepb.SimpleMessage
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("dec.Decode failed with %v; want success", err)
+		}
+		if !proto.Equal(&got, want) {
+			t.Errorf("dec.Decode = %v; want %v", &got, want)
+		}
+	}
+	var got examplepb.SimpleMessage
+	if err := dec.Decode(&got); err != io.EOF {
+		t.Errorf("dec.Decode = %v; want io.EOF", err)
+	}
+}
+
+func TestProtoDelimitedMarshallerDecodeErrors(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		data    []byte
+		maxSize int
+		want    error
+	}{
+		{name: "truncated prefix", data: []byte{0x80}, want: io.ErrUnexpectedEOF},
+		{name: "truncated message", data: []byte{5, 0x0a, 3, 'f'}, want: io.ErrUnexpectedEOF},
+		{name: "too large", data: []byte{5, 0x0a, 3, 'f', 'o', 'o'}, maxSize: 4},
+		{name: "invalid message", data: []byte{2, 0x0a, 3}},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			m := &runtime.ProtoDelimitedMarshaller{MaxSize: spec.maxSize}
+			var got examplepb.SimpleMessage
+			err := m.NewDecoder(bytes.NewReader(spec.data)).Decode(&got)
+			if err == nil {
+				t.Fatalf("dec.Decode succeeded; want an error")
+			}
+			if spec.want != nil && !errors.Is(err, spec.want) {
+				t.Errorf("dec.Decode = %v; want %v", err, spec.want)
+			}
+		})
+	}
+}
+
+func TestForwardResponseStreamLengthDelimited(t *testing.T) {
+	m := &runtime.ProtoDelimitedMarshaller{}
+	msgs := []*examplepb.SimpleMessage{{Id: "One"}, {Id: "Two"}}
+	var count int
+	recv := func() (proto.Message, error) {
+		if count == len(msgs) {
+			return nil, status.Error(codes.Aborted, "aborted")
+		}
+		count++
+		return msgs[count-1], nil
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp := httptest.NewRecorder()
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	runtime.ForwardResponseStream(ctx, runtime.NewServeMux(), m, resp, req, recv)
+
+	if got, want := resp.Header().Get("Content-Type"), "application/x-protobuf-delimited"; got != want {
+		t.Errorf(`resp.Header().Get("Content-Type") = %q; want %q`, got, want)
+	}
+	dec := m.NewDecoder(resp.Body)
+	for _, want := range msgs {
+		var got examplepb.SimpleMessage
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("dec.Decode failed with %v; want success", err)
+		}
+		if !proto.Equal(&got, want) {
+			t.Errorf("dec.Decode = %v; want %v", &got, want)
+		}
+	}
+	var st statuspb.Status
+	if err := dec.Decode(&st); err != nil {
+		t.Fatalf("dec.Decode failed with %v; want success", err)
+	}
+	if got, want := codes.Code(st.Code), codes.Aborted; got != want {
+		t.Errorf("st.Code = %v; want %v", got, want)
+	}
+	if err := dec.Decode(&st); err != io.EOF {
+		t.Errorf("dec.Decode = %v; want io.EOF", err)
+	}
+}
+
+func TestForwardResponseMessageLengthDelimited(t *testing.T) {
+	m := &runtime.ProtoDelimitedMarshaller{}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp := httptest.NewRecorder()
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	runtime.ForwardResponseMessage(ctx, runtime.NewServeMux(), m, resp, req, &examplepb.SimpleMessage{Id: "foo"})
+
+	if got, want := resp.Body.Bytes(), []byte{5, 0x0a, 3, 'f', 'o', 'o'}; !bytes.Equal(got, want) {
+		t.Errorf("resp.Body = %x; want %x", got, want)
+	}
+}
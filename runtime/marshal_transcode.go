@@ -0,0 +1,193 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The binary marshalers, CBOR and MsgPack, map values the way JSONPb does. Marshaled values
+// are mapped by binaryEncoder and appended in the binary format. Decoded binary values are
+// transcoded to JSON to be unmarshaled by JSONPb.
+
+// maxTranscodeDepth is the maximum nesting depth of the decoded binary values,
+// matching the recursion limit of protojson.
+const maxTranscodeDepth = 10000
+
+// maxTranscodeLength is the maximum length of a decoded string or byte string.
+const maxTranscodeLength = math.MaxInt32
+
+// errTranscodeDepth is returned when decoding a value nested deeper than maxTranscodeDepth.
+var errTranscodeDepth = errors.New("exceeded max nesting depth")
+
+// jsonMember is a member of a JSON object.
+type jsonMember struct {
+	key   string
+	value interface{}
+}
+
+// parseJSON parses the JSON value "data", see parseJSONValue.
+func parseJSON(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return parseJSONValue(d)
+}
+
+// parseJSONValue parses the next JSON value read from "d" into nil, a bool, a string,
+// an int64, a uint64, a float64, a []interface{} or a []jsonMember, preserving the order
+// of the object members.
+func parseJSONValue(d *json.Decoder) (interface{}, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			members := []jsonMember{}
+			for d.More() {
+				key, err := d.Token()
+				if err != nil {
+					return nil, err
+				}
+				v, err := parseJSONValue(d)
+				if err != nil {
+					return nil, err
+				}
+				members = append(members, jsonMember{key: key.(string), value: v})
+			}
+			if _, err := d.Token(); err != nil {
+				return nil, err
+			}
+			return members, nil
+		case '[':
+			values := []interface{}{}
+			for d.More() {
+				v, err := parseJSONValue(d)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+			if _, err := d.Token(); err != nil {
+				return nil, err
+			}
+			return values, nil
+		}
+		return nil, fmt.Errorf("unexpected JSON delimiter %v", tok)
+	case json.Number:
+		return parseJSONNumber(tok)
+	default:
+		return tok, nil
+	}
+}
+
+// parseJSONNumber returns "n" as an int64 or a uint64 if it is an integer, as a float64 otherwise.
+func parseJSONNumber(n json.Number) (interface{}, error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return u, nil
+		}
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// writeJSONString writes "s" as a JSON string.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s)
+	buf.Write(b)
+}
+
+// writeJSONBytes writes "b" as a base64 JSON string, the JSON representation of bytes fields.
+func writeJSONBytes(buf *bytes.Buffer, b []byte) {
+	buf.WriteByte('"')
+	buf.WriteString(base64.StdEncoding.EncodeToString(b))
+	buf.WriteByte('"')
+}
+
+// writeJSONFloat writes "f" as a JSON number, or as the JSON string protojson uses for
+// non-finite numbers.
+func writeJSONFloat(buf *bytes.Buffer, f float64) {
+	switch {
+	case math.IsNaN(f):
+		buf.WriteString(`"NaN"`)
+	case math.IsInf(f, 1):
+		buf.WriteString(`"Infinity"`)
+	case math.IsInf(f, -1):
+		buf.WriteString(`"-Infinity"`)
+	default:
+		buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+}
+
+// writeJSONTime writes "t" as a RFC 3339 JSON string, the JSON representation of Timestamps.
+func writeJSONTime(buf *bytes.Buffer, t time.Time) {
+	writeJSONString(buf, t.UTC().Format(time.RFC3339Nano))
+}
+
+// readTranscodeBytes reads "n" bytes from "r", without allocating them upfront.
+func readTranscodeBytes(r io.Reader, n uint64) ([]byte, error) {
+	if n > maxTranscodeLength {
+		return nil, fmt.Errorf("length %d exceeds the maximum of %d", n, maxTranscodeLength)
+	}
+	if n <= 64<<10 {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return b, nil
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readBigEndian reads a "n" bytes long big endian unsigned integer from "r".
+func readBigEndian(r io.ByteReader, n int) (uint64, error) {
+	var v uint64
+	for i := 0; i < n; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+func appendBigEndian(b []byte, v uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		b = append(b, byte(v>>(8*uint(i))))
+	}
+	return b
+}
+
+// unexpectedEOF turns io.EOF into io.ErrUnexpectedEOF, for errors occurring within a value.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// formatNegativeInt returns the decimal representation of -1-n.
+func formatNegativeInt(n uint64) string {
+	if n == math.MaxUint64 {
+		return "-18446744073709551616"
+	}
+	return "-" + strconv.FormatUint(n+1, 10)
+}
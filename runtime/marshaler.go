@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"io"
+	"net/http"
 )
 
 // Marshaler defines a conversion between byte sequence and gRPC payloads / fields.
@@ -48,3 +49,30 @@ type Delimited interface {
 	// Delimiter returns the record separator for the stream.
 	Delimiter() []byte
 }
+
+// LengthDelimited defines a streaming framing where each record is preceded by its length,
+// rather than followed by a Delimiter.
+//
+// The bodies the runtime writes with a LengthDelimited marshaler are sequences of such
+// records: a response message or an error is a single record, and the messages of a
+// stream are written as records of their own, without the {"result": ...} and
+// {"error": ...} wrappers. A stream failing after its first message ends with a record
+// holding the google.rpc.Status of the error.
+type LengthDelimited interface {
+	// AppendLengthPrefix appends the prefix of a "size" bytes long record to "b".
+	AppendLengthPrefix(b []byte, size int) []byte
+}
+
+// StreamKeepAlive defines the chunk written into the idle streams, see WithStreamKeepAlive.
+type StreamKeepAlive interface {
+	// KeepAliveChunk returns a chunk which the decoders of the stream skip.
+	KeepAliveChunk() []byte
+}
+
+// RequestMarshaler defines a Marshaler which depends on the request whose body it unmarshals,
+// e.g. on the parameters of its Content-Type header. The inbound marshaler returned by
+// MarshalerForRequest is the one returned by ForRequest.
+type RequestMarshaler interface {
+	// ForRequest returns the Marshaler unmarshaling the body of "r".
+	ForRequest(r *http.Request) Marshaler
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -34,15 +36,25 @@ var (
 // If it isn't set (or the request Content-Type is empty), checks for "*".
 // If there are multiple Content-Type headers set, choose the first one that it can
 // exactly match in the registry.
-// Otherwise, it follows the above logic for "*"/InboundMarshaler/OutboundMarshaler.
+//
+// The outbound marshaler is negotiated with the Accept header as described in RFC 9110:
+// among the registered MIME types and the content type of the inbound marshaler, the one
+// with the highest quality value is chosen. Media ranges with wildcards, such as
+// "application/*", and parameters are supported. When several MIME types are equally
+// acceptable, the one matched by the most specific media range is preferred, then the
+// inbound marshaler. If the request has no Accept header or nothing it accepts is
+// registered, the outbound marshaler is the inbound one.
+//
+// If the inbound marshaler is a RequestMarshaler, the one returned by its ForRequest
+// method is returned instead.
 func MarshalerForRequest(mux *ServeMux, r *http.Request) (inbound Marshaler, outbound Marshaler) {
-	for _, acceptVal := range r.Header[acceptHeader] {
-		if m, ok := mux.marshalers.mimeMap[acceptVal]; ok {
-			outbound = m
-			break
-		}
-	}
+	inbound, outbound, _ = marshalersForRequest(mux, r)
+	return inbound, outbound
+}
 
+// marshalersForRequest is MarshalerForRequest, also reporting whether the outbound
+// marshaler is acceptable according to the Accept header.
+func marshalersForRequest(mux *ServeMux, r *http.Request) (inbound Marshaler, outbound Marshaler, acceptable bool) {
 	for _, contentTypeVal := range r.Header[contentTypeHeader] {
 		contentType, _, err := mime.ParseMediaType(contentTypeVal)
 		if err != nil {
@@ -58,11 +70,118 @@ func MarshalerForRequest(mux *ServeMux, r *http.Request) (inbound Marshaler, out
 	if inbound == nil {
 		inbound = mux.marshalers.mimeMap[MIMEWildcard]
 	}
-	if outbound == nil {
+
+	outbound, acceptable = mux.marshalers.negotiate(r.Header[acceptHeader], inbound)
+	if !acceptable {
 		outbound = inbound
 	}
+	if rm, ok := inbound.(RequestMarshaler); ok {
+		inbound = rm.ForRequest(r)
+	}
 
-	return inbound, outbound
+	return inbound, outbound, acceptable
+}
+
+// mediaRange is a media range of an Accept header.
+type mediaRange struct {
+	typ, subtype string
+	params       map[string]string
+	q            float64
+}
+
+// parseAccept parses the media ranges of the Accept header values "accept".
+// Invalid media ranges are ignored.
+func parseAccept(accept []string) []mediaRange {
+	var ranges []mediaRange
+	for _, v := range accept {
+		for _, s := range strings.Split(v, ",") {
+			if strings.TrimSpace(s) == "" {
+				continue
+			}
+			mediaType, params, err := mime.ParseMediaType(s)
+			if err != nil {
+				grpclog.Infof("Failed to parse Accept media range %s: %v", s, err)
+				continue
+			}
+			if mediaType == "*" {
+				mediaType = "*/*"
+			}
+			typ, subtype, ok := splitMediaType(mediaType)
+			if !ok || (typ == "*" && subtype != "*") {
+				continue
+			}
+			rng := mediaRange{typ: typ, subtype: subtype, params: params, q: 1}
+			if q, ok := params["q"]; ok {
+				// Parameters following the quality value are accept extensions, which are ignored.
+				f, err := strconv.ParseFloat(q, 64)
+				if err != nil || f < 0 || f > 1 {
+					continue
+				}
+				rng.q = f
+				delete(params, "q")
+			}
+			ranges = append(ranges, rng)
+		}
+	}
+	return ranges
+}
+
+func splitMediaType(mediaType string) (typ, subtype string, ok bool) {
+	i := strings.IndexByte(mediaType, '/')
+	if i <= 0 || i == len(mediaType)-1 {
+		return "", "", false
+	}
+	return mediaType[:i], mediaType[i+1:], true
+}
+
+// quality returns the quality value of the MIME type "mimeType" according to the
+// most specific of "ranges" matching it, and the specificity of that range.
+// The quality value is zero if no range matches. An invalid MIME type, such as the
+// empty content type of some marshalers, is only matched by "*/*".
+func quality(ranges []mediaRange, mimeType string) (q float64, specificity int) {
+	var typ, subtype string
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err == nil {
+		typ, subtype, _ = splitMediaType(mediaType)
+	}
+	specificity = -1
+	for _, rng := range ranges {
+		var spec int
+		switch {
+		case rng.typ == "*":
+			spec = 0
+		case rng.typ != typ:
+			continue
+		case rng.subtype == "*":
+			spec = 1
+		case rng.subtype != subtype:
+			continue
+		default:
+			spec = 2
+		}
+		if len(rng.params) > 0 {
+			if !paramsMatch(rng.params, params) {
+				continue
+			}
+			spec++
+		}
+		if spec > specificity {
+			q, specificity = rng.q, spec
+		}
+	}
+	return q, specificity
+}
+
+// paramsMatch reports whether "params" has no parameter contradicting "want".
+// A parameter missing from "params", such as the charset of most registered
+// MIME types, is not considered contradicting.
+func paramsMatch(want, params map[string]string) bool {
+	for k, v := range want {
+		if p, ok := params[k]; ok && !strings.EqualFold(p, v) {
+			return false
+		}
+	}
+	return true
 }
 
 // marshalerRegistry is a mapping from MIME types to Marshalers.
@@ -82,6 +201,41 @@ func (m marshalerRegistry) add(mime string, marshaler Marshaler) error {
 	return nil
 }
 
+// negotiate returns the marshaler to use for a response to a request having the
+// Accept header values "accept", see MarshalerForRequest. It reports false if
+// neither a registered marshaler nor "fallback" are acceptable.
+func (m marshalerRegistry) negotiate(accept []string, fallback Marshaler) (Marshaler, bool) {
+	if len(accept) == 0 {
+		return fallback, true
+	}
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		return fallback, true
+	}
+
+	best := fallback
+	bestQ, bestSpec := quality(ranges, fallback.ContentType(nil))
+	var bestMIME string
+	for mimeType, marshaler := range m.mimeMap {
+		if mimeType == MIMEWildcard {
+			continue
+		}
+		q, spec := quality(ranges, mimeType)
+		switch {
+		case q > bestQ, q == bestQ && spec > bestSpec:
+		case q == bestQ && spec == bestSpec && marshaler != fallback && best != fallback && mimeType < bestMIME:
+			// Break ties deterministically.
+		default:
+			continue
+		}
+		best, bestQ, bestSpec, bestMIME = marshaler, q, spec, mimeType
+	}
+	if bestQ <= 0 {
+		return nil, false
+	}
+	return best, true
+}
+
 // makeMarshalerMIMERegistry returns a new registry of marshalers.
 // It allows for a mapping of case-sensitive Content-Type MIME type string to runtime.Marshaler interfaces.
 //
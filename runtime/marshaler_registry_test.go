@@ -91,6 +91,100 @@ func TestMarshalerForRequest(t *testing.T) {
 	}
 }
 
+func TestMarshalerForRequestNegotiation(t *testing.T) {
+	marshalers := []dummyMarshaler{0, 1, 2, 3}
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &marshalers[0]),
+		runtime.WithMarshalerOption("application/json", &marshalers[1]),
+		runtime.WithMarshalerOption("text/plain", &marshalers[2]),
+		runtime.WithMarshalerOption("application/x-protobuf", &marshalers[3]),
+	)
+
+	for _, spec := range []struct {
+		accept      []string
+		contentType string
+		wantOut     runtime.Marshaler
+	}{
+		{
+			wantOut: &marshalers[0],
+		},
+		{
+			contentType: "application/json",
+			wantOut:     &marshalers[1],
+		},
+		{
+			accept:  []string{"application/json, text/plain;q=0.5"},
+			wantOut: &marshalers[1],
+		},
+		{
+			accept:  []string{"application/json;q=0.5, text/plain"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"application/json;q=0.5", "text/plain"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"text/*"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"application/*;q=0.8, application/x-protobuf"},
+			wantOut: &marshalers[3],
+		},
+		{
+			accept:  []string{"application/*, application/json;q=0"},
+			wantOut: &marshalers[3],
+		},
+		{
+			accept:  []string{"text/plain; charset=utf-8"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"text/plain;q=0.2, */*;q=0.5"},
+			wantOut: &marshalers[0],
+		},
+		{
+			accept:      []string{"*/*"},
+			contentType: "text/plain",
+			wantOut:     &marshalers[2],
+		},
+		{
+			accept:      []string{"application/json, */*;q=0.1"},
+			contentType: "text/plain",
+			wantOut:     &marshalers[1],
+		},
+		// Nothing acceptable, fall back to the inbound marshaler.
+		{
+			accept:      []string{"image/png"},
+			contentType: "application/x-protobuf",
+			wantOut:     &marshalers[3],
+		},
+		// Invalid media ranges are ignored.
+		{
+			accept:  []string{"text/, text/plain"},
+			wantOut: &marshalers[2],
+		},
+	} {
+		t.Run(fmt.Sprintf("%q", spec.accept), func(t *testing.T) {
+			r, err := http.NewRequest("GET", "http://example.com", nil)
+			if err != nil {
+				t.Fatalf(`http.NewRequest("GET", "http://example.com", nil) failed with %v; want success`, err)
+			}
+			for _, accept := range spec.accept {
+				r.Header.Add("Accept", accept)
+			}
+			if spec.contentType != "" {
+				r.Header.Set("Content-Type", spec.contentType)
+			}
+			_, out := runtime.MarshalerForRequest(mux, r)
+			if got, want := out, spec.wantOut; got != want {
+				t.Errorf("out = %#v; want %#v", got, want)
+			}
+		})
+	}
+}
+
 type dummyMarshaler int
 
 func (dummyMarshaler) ContentType(_ interface{}) string { return "" }
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/textproto"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
 	"google.golang.org/grpc/codes"
@@ -50,11 +54,20 @@ var (
 // A HandlerFunc handles a specific pair of path pattern and HTTP method.
 type HandlerFunc func(w http.ResponseWriter, r *http.Request, pathParams map[string]string)
 
+// A Middleware handler wraps another HandlerFunc to do some pre- and/or post-processing of the request.
+// This is used as an alternative to gRPC interceptors when using the direct-to-implementation
+// registration methods. It is generally recommended to use gRPC client or server interceptors instead
+// where possible.
+type Middleware func(HandlerFunc) HandlerFunc
+
 // ServeMux is a request multiplexer for grpc-gateway.
 // It matches http requests to patterns and invokes the corresponding handler.
 type ServeMux struct {
-	// handlers maps HTTP method to a list of handlers.
-	handlers                  map[string][]handler
+	// mu guards handlers and handlerSeq.
+	mu sync.RWMutex
+	// handlers maps HTTP method to a tree of handlers.
+	handlers                  map[string]*routeTree
+	handlerSeq                uint64
 	forwardResponseOptions    []func(context.Context, http.ResponseWriter, proto.Message) error
 	marshalers                marshalerRegistry
 	incomingHeaderMatcher     HeaderMatcherFunc
@@ -65,6 +78,26 @@ type ServeMux struct {
 	routingErrorHandler       RoutingErrorHandlerFunc
 	disablePathLengthFallback bool
 	unescapingMode            UnescapingMode
+	middlewares               []Middleware
+	cors                      *CORSOptions
+	automaticHEAD             bool
+	pathPrefix                string
+	problemDetails            bool
+	errorDetailsMapping       bool
+	httpStatusMapping         map[codes.Code]int
+	methodHTTPStatusMapping   map[string]map[codes.Code]int
+	compressors               []namedCompressor
+	maxDecompressedSize       int64
+	disableNotAcceptable      bool
+	disableServerSentEvents   bool
+	sseKeepAlive              time.Duration
+	sseEventIDs               bool
+	webSocket                 *WebSocketOptions
+	streamKeepAlive           time.Duration
+	streamMaxDuration         time.Duration
+	streamWriteTimeout        time.Duration
+	streamEnvelope            StreamEnvelope
+	maxRequestTrailerBodySize int64
 }
 
 // ServeMuxOption is an option that can be given to a ServeMux on construction.
@@ -82,6 +115,32 @@ func WithForwardResponseOption(forwardResponseOption func(context.Context, http.
 	}
 }
 
+// WithMiddlewares sets server middlewares for all handlers. The middlewares run after routing,
+// so the request context carries the matched pattern (see HTTPPattern), the path parameters
+// (see PathParams) and, for generated handlers, the gRPC method name (see RPCMethod).
+// The first middleware is the outermost one.
+func WithMiddlewares(middlewares ...Middleware) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.middlewares = append(serveMux.middlewares, middlewares...)
+	}
+}
+
+// WithPathPrefix returns a ServeMuxOption which serves the handlers below "prefix", e.g. "/api/v2".
+// The prefix is prepended to the patterns given to Handle and HandlePath, and therefore
+// to the patterns reported by HTTPPattern, HTTPPathPattern and Routes. It only applies to
+// the handlers registered after the option, including by the options which follow it.
+//
+// Give the same prefix to the path_prefix option of protoc-gen-openapiv2 so that it is
+// reflected in the basePath of the OpenAPI output.
+func WithPathPrefix(prefix string) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.pathPrefix = ""
+		if prefix = strings.Trim(prefix, "/"); prefix != "" {
+			serveMux.pathPrefix = "/" + prefix
+		}
+	}
+}
+
 // WithEscapingType sets the escaping type. See the definitions of UnescapingMode
 // for more information.
 func WithUnescapingMode(mode UnescapingMode) ServeMuxOption {
@@ -191,13 +250,65 @@ func WithStreamErrorHandler(fn StreamErrorHandlerFunc) ServeMuxOption {
 // WithRoutingErrorHandler returns a ServeMuxOption for configuring a custom error handler to  handle http routing errors.
 //
 // Method called for errors which can happen before gRPC route selected or executed.
-// The following error codes: StatusMethodNotAllowed StatusNotFound StatusBadRequest
+// The following error codes: StatusMethodNotAllowed StatusNotFound StatusBadRequest StatusUnsupportedMediaType
+// StatusNotAcceptable
 func WithRoutingErrorHandler(fn RoutingErrorHandlerFunc) ServeMuxOption {
 	return func(serveMux *ServeMux) {
 		serveMux.routingErrorHandler = fn
 	}
 }
 
+// WithHTTPStatusMapping returns a ServeMuxOption which overrides the HTTP status codes which
+// gRPC codes are mapped to, e.g. to reply to FailedPrecondition errors with 409 instead of 400.
+// The codes missing from "mapping" keep the status code of HTTPStatusFromCode.
+//
+// The mapping is used by DefaultHTTPErrorHandler and for the errors of server streaming methods
+// returned before the first message, see ServeMux.HTTPStatusFromCode.
+func WithHTTPStatusMapping(mapping map[codes.Code]int) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		if serveMux.httpStatusMapping == nil {
+			serveMux.httpStatusMapping = make(map[codes.Code]int, len(mapping))
+		}
+		for code, st := range mapping {
+			serveMux.httpStatusMapping[code] = st
+		}
+	}
+}
+
+// WithMethodHTTPStatusMapping returns a ServeMuxOption which overrides the HTTP status codes which
+// gRPC codes are mapped to for the RPC method "rpcMethod", e.g. "/example.Service/Method".
+// It takes precedence over WithHTTPStatusMapping. The RPC method is taken from the request
+// context, see RPCMethod.
+func WithMethodHTTPStatusMapping(rpcMethod string, mapping map[codes.Code]int) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		if serveMux.methodHTTPStatusMapping == nil {
+			serveMux.methodHTTPStatusMapping = make(map[string]map[codes.Code]int)
+		}
+		m, ok := serveMux.methodHTTPStatusMapping[rpcMethod]
+		if !ok {
+			m = make(map[codes.Code]int, len(mapping))
+			serveMux.methodHTTPStatusMapping[rpcMethod] = m
+		}
+		for code, st := range mapping {
+			m[code] = st
+		}
+	}
+}
+
+// WithErrorDetailsMapping returns a ServeMuxOption which makes DefaultHTTPErrorHandler translate
+// the error details of a status into HTTP headers and status codes:
+//
+//	RetryInfo -> Retry-After header
+//	QuotaFailure -> 429 Too Many Requests, with RateLimit-Remaining and RateLimit-Reset headers
+//	PreconditionFailure -> 412 Precondition Failed
+//
+// The status code of a HTTPStatusError is kept as is.
+func WithErrorDetailsMapping() ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.errorDetailsMapping = true
+	}
+}
+
 // WithDisablePathLengthFallback returns a ServeMuxOption for disable path length fallback.
 func WithDisablePathLengthFallback() ServeMuxOption {
 	return func(serveMux *ServeMux) {
@@ -205,6 +316,30 @@ func WithDisablePathLengthFallback() ServeMuxOption {
 	}
 }
 
+// WithDisableNotAcceptable returns a ServeMuxOption which disables replying with http.StatusNotAcceptable
+// to requests whose Accept header matches no registered marshaler. Such requests are then answered with
+// the inbound marshaler, see MarshalerForRequest.
+//
+// Without this option, only the handlers registered with an RPC method name, such as the generated ones,
+// reply with http.StatusNotAcceptable, except those of the methods returning google.api.HttpBody.
+// See WithRPCMethodName and WithHTTPBodyResponse.
+func WithDisableNotAcceptable() ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.disableNotAcceptable = true
+	}
+}
+
+// WithMaxRequestTrailerBodySize returns a ServeMuxOption which sets the maximum size of the request
+// bodies of streaming methods read into memory, 4 MiB if not given: those declaring trailers, which
+// are read to forward the trailers of client streaming methods, and those of the bidirectional
+// streaming methods which are not full-duplex, see FullDuplexRequestBody. Larger bodies are
+// answered with http.StatusRequestEntityTooLarge.
+func WithMaxRequestTrailerBodySize(size int64) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.maxRequestTrailerBodySize = size
+	}
+}
+
 // WithHealthEndpointAt returns a ServeMuxOption that will add an endpoint to the created ServeMux at the path specified by endpointPath.
 // When called the handler will forward the request to the upstream grpc service health check (defined in the
 // gRPC Health Checking Protocol).
@@ -259,7 +394,7 @@ func WithHealthzEndpoint(healthCheckClient grpc_health_v1.HealthClient) ServeMux
 // NewServeMux returns a new ServeMux whose internal mapping is empty.
 func NewServeMux(opts ...ServeMuxOption) *ServeMux {
 	serveMux := &ServeMux{
-		handlers:               make(map[string][]handler),
+		handlers:               make(map[string]*routeTree),
 		forwardResponseOptions: make([]func(context.Context, http.ResponseWriter, proto.Message) error, 0),
 		marshalers:             makeMarshalerMIMERegistry(),
 		errorHandler:           DefaultHTTPErrorHandler,
@@ -285,9 +420,163 @@ func NewServeMux(opts ...ServeMuxOption) *ServeMux {
 	return serveMux
 }
 
+// HandleOption is an option that can be given to ServeMux.Handle.
+type HandleOption func(*handler)
+
+// WithRPCMethodName returns a HandleOption which records the full name of the gRPC
+// method served by the handler, in the format of "/package.service/method".
+//
+// The generated code passes the same name it gives to AnnotateContext.
+func WithRPCMethodName(name string) HandleOption {
+	return func(h *handler) {
+		h.rpcMethod = name
+	}
+}
+
+// WithFullDuplex returns a HandleOption for the handlers reading the request body while writing
+// the response, such as the generated handlers of bidirectional streaming methods. The ServeMux
+// makes their HTTP/1.x requests full-duplex when the server supports it, as net/http does since
+// Go 1.21, HTTP/2 requests always being full-duplex. See FullDuplex.
+func WithFullDuplex() HandleOption {
+	return func(h *handler) {
+		h.fullDuplex = true
+	}
+}
+
+// WithClientStreaming returns a HandleOption for the handlers of client and bidirectional streaming
+// methods, whose request body holds a stream of messages. The HTTP trailers of their requests are
+// forwarded as metadata unless they are full-duplex, see AnnotateContext.
+func WithClientStreaming() HandleOption {
+	return func(h *handler) {
+		h.clientStreaming = true
+	}
+}
+
+// WithServerStreaming returns a HandleOption for the handlers of server and bidirectional streaming
+// methods, whose response body holds a stream of messages. They do not answer HEAD requests,
+// see WithAutomaticHEAD.
+func WithServerStreaming() HandleOption {
+	return func(h *handler) {
+		h.serverStreaming = true
+	}
+}
+
+// WithHTTPBodyResponse returns a HandleOption for the handlers of methods returning google.api.HttpBody,
+// which set the Content-Type of their responses themselves. Their requests are not answered with
+// http.StatusNotAcceptable whatever their Accept header, see WithDisableNotAcceptable.
+func WithHTTPBodyResponse() HandleOption {
+	return func(h *handler) {
+		h.httpBodyResponse = true
+	}
+}
+
+// WithRouteMiddlewares returns a HandleOption which wraps the handler with "middlewares".
+// They run inside the middlewares given by WithMiddlewares, the first one being the outermost.
+func WithRouteMiddlewares(middlewares ...Middleware) HandleOption {
+	return func(h *handler) {
+		h.h = chainMiddlewares(h.h, middlewares)
+	}
+}
+
+// A RouteMatcher reports whether a request may be served by a route, in addition to its
+// HTTP method and path pattern.
+type RouteMatcher func(r *http.Request) bool
+
+// WithRouteMatcher returns a HandleOption which restricts the handler to the requests
+// accepted by "matcher". A request which is not accepted is routed as if the handler
+// was not registered, so it may be served by another handler of the same pattern.
+//
+// CORS preflight requests are answered regardless of the route matchers, since they
+// do not carry the headers of the actual request.
+func WithRouteMatcher(matcher RouteMatcher) HandleOption {
+	return func(h *handler) {
+		h.matchers = append(h.matchers, matcher)
+	}
+}
+
+// WithHost returns a HandleOption which restricts the handler to the requests for "host".
+// The port of the request host is ignored and the comparison is case-insensitive.
+// A leading "*." matches any subdomain, e.g. "*.example.com" matches "api.example.com"
+// but not "example.com".
+func WithHost(host string) HandleOption {
+	return WithRouteMatcher(func(r *http.Request) bool {
+		return matchHost(host, r.Host)
+	})
+}
+
+// WithHeader returns a HandleOption which restricts the handler to the requests whose
+// header "key" has the value "value". If "value" is empty, the header only has to be present.
+func WithHeader(key, value string) HandleOption {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	return WithRouteMatcher(func(r *http.Request) bool {
+		values, ok := r.Header[key]
+		if value == "" {
+			return ok
+		}
+		return containsString(values, value)
+	})
+}
+
 // Handle associates "h" to the pair of HTTP method and path pattern.
-func (s *ServeMux) Handle(meth string, pat Pattern, h HandlerFunc) {
-	s.handlers[meth] = append([]handler{{pat: pat, h: h}}, s.handlers[meth]...)
+// Handlers registered later take precedence over the ones registered earlier
+// when more than one pattern matches a request.
+func (s *ServeMux) Handle(meth string, pat Pattern, h HandlerFunc, opts ...HandleOption) {
+	hdl := &handler{meth: meth, pat: s.prefixed(pat), h: h}
+	for _, opt := range opts {
+		opt(hdl)
+	}
+	hdl.h = chainMiddlewares(hdl.h, s.middlewares)
+	s.insert(hdl)
+	for _, reg := range hdl.regs {
+		reg.add(s, hdl)
+	}
+}
+
+// Unregister removes the handlers associated to the pair of HTTP method and path pattern.
+// It is safe to call concurrently with ServeHTTP: requests already dispatched to the
+// handlers are not interrupted.
+func (s *ServeMux) Unregister(meth string, pat Pattern) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tree, ok := s.handlers[meth]
+	if !ok {
+		return
+	}
+	pat = s.prefixed(pat)
+	for _, h := range tree.all() {
+		if h.pat.String() == pat.String() {
+			tree.remove(h)
+		}
+	}
+}
+
+// prefixed returns "pat" below the path prefix of the ServeMux.
+func (s *ServeMux) prefixed(pat Pattern) Pattern {
+	if s.pathPrefix == "" {
+		return pat
+	}
+	return pat.withPrefix(strings.Split(s.pathPrefix[1:], "/"))
+}
+
+func (s *ServeMux) insert(h *handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tree, ok := s.handlers[h.meth]
+	if !ok {
+		tree = &routeTree{}
+		s.handlers[h.meth] = tree
+	}
+	s.handlerSeq++
+	h.seq = s.handlerSeq
+	tree.insert(h)
+}
+
+func (s *ServeMux) remove(h *handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tree, ok := s.handlers[h.meth]; ok {
+		tree.remove(h)
+	}
 }
 
 // HandlePath allows users to configure custom path handlers.
@@ -343,10 +632,25 @@ func (s *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if s.cors != nil {
+		if isPreflight(r) {
+			if methods := s.allowedMethods(nil, components); len(methods) > 0 && !containsString(methods, http.MethodOptions) {
+				s.cors.handlePreflight(w, r, s, methods)
+				return
+			}
+		} else {
+			s.cors.handleActual(w, r)
+		}
+	}
+
 	// Verb out here is to memoize for the fallback case below
 	var verb string
 
-	for _, h := range s.handlers[r.Method] {
+	// The routing trees are searched with the components as they were
+	// received, since trying a pattern with a verb may strip it off below.
+	lookupComponents := append([]string(nil), components...)
+
+	for _, h := range s.lookup(r.Method, lookupComponents) {
 		// If the pattern has a verb, explicitly look for a suffix in the last
 		// component that matches a colon plus the verb. This allows us to
 		// handle some cases that otherwise can't be correctly handled by the
@@ -383,60 +687,308 @@ func (s *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		h.h(w, r, pathParams)
+		if !h.matchesRequest(r) {
+			continue
+		}
+		s.dispatch(w, r, h, pathParams)
 		return
 	}
 
 	// lookup other methods to handle fallback from GET to POST and
 	// to determine if it is NotImplemented or NotFound.
-	for m, handlers := range s.handlers {
-		if m == r.Method {
+	for _, h := range s.lookupOthers(r.Method, lookupComponents) {
+		pathParams, err := h.pat.MatchAndEscape(components, verb, s.unescapingMode)
+		if err != nil {
+			var mse MalformedSequenceError
+			if ok := errors.As(err, &mse); ok {
+				_, outboundMarshaler := MarshalerForRequest(s, r)
+				s.errorHandler(ctx, s, outboundMarshaler, w, r, &HTTPStatusError{
+					HTTPStatus: http.StatusBadRequest,
+					Err:        mse,
+				})
+			}
 			continue
 		}
-		for _, h := range handlers {
-			pathParams, err := h.pat.MatchAndEscape(components, verb, s.unescapingMode)
-			if err != nil {
-				var mse MalformedSequenceError
-				if ok := errors.As(err, &mse); ok {
-					_, outboundMarshaler := MarshalerForRequest(s, r)
-					s.errorHandler(ctx, s, outboundMarshaler, w, r, &HTTPStatusError{
-						HTTPStatus: http.StatusBadRequest,
-						Err:        mse,
-					})
-				}
-				continue
-			}
-			// X-HTTP-Method-Override is optional. Always allow fallback to POST.
-			if s.isPathLengthFallback(r) {
-				if err := r.ParseForm(); err != nil {
-					_, outboundMarshaler := MarshalerForRequest(s, r)
-					sterr := status.Error(codes.InvalidArgument, err.Error())
-					s.errorHandler(ctx, s, outboundMarshaler, w, r, sterr)
-					return
-				}
-				h.h(w, r, pathParams)
+		if !h.matchesRequest(r) {
+			continue
+		}
+		// WebSocket handshakes are GET requests, whatever the method of the pattern.
+		if s.webSocket != nil && h.rpcMethod != "" && isWebSocketUpgrade(r) {
+			s.dispatch(w, r, h, pathParams)
+			return
+		}
+		// X-HTTP-Method-Override is optional. Always allow fallback to POST.
+		if s.isPathLengthFallback(r) {
+			if err := r.ParseForm(); err != nil {
+				_, outboundMarshaler := MarshalerForRequest(s, r)
+				sterr := status.Error(codes.InvalidArgument, err.Error())
+				s.errorHandler(ctx, s, outboundMarshaler, w, r, sterr)
 				return
 			}
-			_, outboundMarshaler := MarshalerForRequest(s, r)
-			s.routingErrorHandler(ctx, s, outboundMarshaler, w, r, http.StatusMethodNotAllowed)
+			s.dispatch(w, r, h, pathParams)
 			return
 		}
+		_, outboundMarshaler := MarshalerForRequest(s, r)
+		ctx = withAllowedMethods(ctx, s.allowedMethods(r, lookupComponents))
+		s.routingErrorHandler(ctx, s, outboundMarshaler, w, r.WithContext(ctx), http.StatusMethodNotAllowed)
+		return
 	}
 
 	_, outboundMarshaler := MarshalerForRequest(s, r)
 	s.routingErrorHandler(ctx, s, outboundMarshaler, w, r, http.StatusNotFound)
 }
 
+// Route describes a handler registered on a ServeMux.
+type Route struct {
+	// Method is the HTTP method of the route.
+	Method string
+	// Pattern is the path template of the route, as returned by Pattern.String.
+	Pattern string
+	// Verb is the verb part of the path template. It is empty if the template does not have one.
+	Verb string
+	// RPCMethod is the full name of the gRPC method served by the route, in the format of
+	// "/package.service/method". It is empty if the route was registered without WithRPCMethodName.
+	RPCMethod string
+}
+
+// Routes returns the routes registered on the ServeMux, sorted by HTTP method.
+// Routes having the same HTTP method are listed by precedence, highest first.
+func (s *ServeMux) Routes() []Route {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	methods := make([]string, 0, len(s.handlers))
+	for meth := range s.handlers {
+		methods = append(methods, meth)
+	}
+	sort.Strings(methods)
+
+	var routes []Route
+	for _, meth := range methods {
+		for _, h := range s.handlers[meth].all() {
+			routes = append(routes, Route{
+				Method:    meth,
+				Pattern:   h.pat.String(),
+				Verb:      h.pat.Verb(),
+				RPCMethod: h.rpcMethod,
+			})
+		}
+	}
+	return routes
+}
+
 // GetForwardResponseOptions returns the ForwardResponseOptions associated with this ServeMux.
 func (s *ServeMux) GetForwardResponseOptions() []func(context.Context, http.ResponseWriter, proto.Message) error {
 	return s.forwardResponseOptions
 }
 
+// dispatch invokes "h" through the middlewares of the ServeMux, annotating the request
+// context with the routing result.
+func (s *ServeMux) dispatch(w http.ResponseWriter, r *http.Request, h *handler, pathParams map[string]string) {
+	ctx := withHTTPPattern(r.Context(), h.pat)
+	ctx = withPathParams(ctx, pathParams)
+	if h.rpcMethod != "" {
+		ctx = withRPCMethod(ctx, h.rpcMethod)
+	}
+	r, code := s.decompressRequest(r)
+	if code != 0 {
+		if code == http.StatusUnsupportedMediaType {
+			w.Header().Set("Accept-Encoding", strings.Join(s.compressionEncodings(), ", "))
+		}
+		_, outboundMarshaler := MarshalerForRequest(s, r)
+		s.routingErrorHandler(ctx, s, outboundMarshaler, w, r.WithContext(ctx), code)
+		return
+	}
+	if s.webSocket != nil && h.rpcMethod != "" && isWebSocketUpgrade(r) {
+		s.serveWebSocket(w, r.WithContext(ctx), h, pathParams)
+		return
+	}
+	if h.rpcMethod != "" && !h.httpBodyResponse && !s.disableNotAcceptable && (s.disableServerSentEvents || !acceptsEventStream(r)) {
+		// Clients accepting Problem Details get them for errors, and the inbound marshaler otherwise.
+		if _, outboundMarshaler, ok := marshalersForRequest(s, r); !ok && !acceptsProblemDetails(r) {
+			s.routingErrorHandler(ctx, s, outboundMarshaler, w, r.WithContext(ctx), http.StatusNotAcceptable)
+			return
+		}
+	}
+	if h.clientStreaming {
+		ctx = withClientStreaming(ctx)
+	}
+	if h.fullDuplex {
+		ctx = withFullDuplex(ctx, enableFullDuplex(w, r))
+		ctx = withMaxBodySize(ctx, s.maxRequestBodySize())
+	}
+	if r.Method == http.MethodHead && h.meth != http.MethodHead {
+		hw := &headResponseWriter{ResponseWriter: w}
+		h.h(hw, r.WithContext(ctx), pathParams)
+		hw.finish()
+		return
+	}
+	h.h(w, r.WithContext(ctx), pathParams)
+}
+
+// enableFullDuplex makes "r" full-duplex if it is possible, and reports whether it is.
+func enableFullDuplex(w http.ResponseWriter, r *http.Request) bool {
+	if r.ProtoMajor >= 2 {
+		return true
+	}
+	// Like http.ResponseController.EnableFullDuplex, which is not available before Go 1.21.
+	for {
+		switch t := w.(type) {
+		case interface{ EnableFullDuplex() error }:
+			return t.EnableFullDuplex() == nil
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// chainMiddlewares wraps "h" with "middlewares", the first one being the outermost.
+func chainMiddlewares(h HandlerFunc, middlewares []Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// allowedMethods returns the sorted HTTP methods having a handler whose pattern matches "components".
+// The route matchers of the handlers are only evaluated if "r" is not nil.
+func (s *ServeMux) allowedMethods(r *http.Request, components []string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var methods []string
+	var head bool
+	for meth, tree := range s.handlers {
+		var matched bool
+		for _, h := range tree.lookup(components) {
+			if !h.matches(components, s.unescapingMode) || (r != nil && !h.matchesRequest(r)) {
+				continue
+			}
+			matched = true
+			// The server streaming GET handlers do not answer HEAD requests, see lookup.
+			if meth == http.MethodGet && !h.serverStreaming {
+				head = true
+			}
+		}
+		if matched {
+			methods = append(methods, meth)
+		}
+	}
+	if s.automaticHEAD && head && !containsString(methods, http.MethodHead) {
+		methods = append(methods, http.MethodHead)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// lookup returns the handlers registered for "meth" which may match "components",
+// in the order in which they must be tried.
+func (s *ServeMux) lookup(meth string, components []string) []*handler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var handlers []*handler
+	if tree, ok := s.handlers[meth]; ok {
+		handlers = tree.lookup(components)
+	}
+	if meth == http.MethodHead && s.automaticHEAD {
+		// HEAD handlers take precedence over the GET ones. The server streaming handlers are
+		// skipped, as their response would only be complete once the stream ends.
+		if tree, ok := s.handlers[http.MethodGet]; ok {
+			for _, h := range tree.lookup(components) {
+				if !h.serverStreaming {
+					handlers = append(handlers, h)
+				}
+			}
+		}
+	}
+	return handlers
+}
+
+// lookupOthers returns the handlers registered for HTTP methods other than "meth"
+// which may match "components".
+func (s *ServeMux) lookupOthers(meth string, components []string) []*handler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var handlers []*handler
+	for m, tree := range s.handlers {
+		if m != meth {
+			handlers = append(handlers, tree.lookup(components)...)
+		}
+	}
+	return handlers
+}
+
 func (s *ServeMux) isPathLengthFallback(r *http.Request) bool {
 	return !s.disablePathLengthFallback && r.Method == "POST" && r.Header.Get("Content-Type") == "application/x-www-form-urlencoded"
 }
 
 type handler struct {
-	pat Pattern
-	h   HandlerFunc
+	// meth is the HTTP method the handler was registered for.
+	meth string
+	pat  Pattern
+	// h is the registered HandlerFunc, wrapped with its route middlewares and those of the ServeMux.
+	h HandlerFunc
+	// seq is the registration order of the handler.
+	seq uint64
+	// rpcMethod is the full name of the gRPC method served by the handler, if known.
+	rpcMethod string
+	// fullDuplex is whether the handler reads the request body while writing the response.
+	fullDuplex bool
+	// clientStreaming is whether the handler serves a client or bidirectional streaming method.
+	clientStreaming bool
+	// serverStreaming is whether the handler serves a server or bidirectional streaming method.
+	serverStreaming bool
+	// httpBodyResponse is whether the handler serves a method returning google.api.HttpBody.
+	httpBodyResponse bool
+	// matchers are the route matchers which must all accept a request served by the handler.
+	matchers []RouteMatcher
+	// regs are the Registrations the handler belongs to.
+	regs []*Registration
+}
+
+// matchesRequest reports whether all the route matchers of "h" accept "r".
+func (h *handler) matchesRequest(r *http.Request) bool {
+	for _, m := range h.matchers {
+		if !m(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchHost reports whether "host", without its port, matches "pattern" as described by WithHost.
+func matchHost(pattern, host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		suffix := pattern[1:]
+		return len(host) > len(suffix) && strings.EqualFold(host[len(host)-len(suffix):], suffix)
+	}
+	return strings.EqualFold(pattern, host)
+}
+
+// matches reports whether the pattern of "h" matches "components", splitting off
+// the verb of the pattern from the last component if it carries it.
+func (h *handler) matches(components []string, unescapingMode UnescapingMode) bool {
+	var verb string
+	if patVerb := h.pat.Verb(); patVerb != "" {
+		l := len(components)
+		lastComponent := components[l-1]
+		if idx := len(lastComponent) - len(patVerb) - 1; idx > 0 && strings.HasSuffix(lastComponent, ":"+patVerb) {
+			components = append(components[:l-1:l-1], lastComponent[:idx])
+			verb = patVerb
+		}
+	}
+	_, err := h.pat.MatchAndEscape(components, verb, unescapingMode)
+	return err == nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -87,6 +89,47 @@ func TestMuxServeHTTP(t *testing.T) {
 			respStatus:  http.StatusOK,
 			respContent: "GET /foo",
 		},
+		{
+			patterns: []stubPattern{
+				{
+					method: "GET",
+					ops:    []int{int(utilities.OpLitPush), 0},
+					pool:   []string{"foo"},
+				},
+				{
+					method: "GET",
+					ops:    []int{int(utilities.OpPush), 0},
+				},
+			},
+			reqMethod:   "GET",
+			reqPath:     "/foo",
+			respStatus:  http.StatusOK,
+			respContent: "GET /*",
+		},
+		{
+			patterns: []stubPattern{
+				{
+					method: "GET",
+					ops: []int{
+						int(utilities.OpLitPush), 0,
+						int(utilities.OpPushM), 0,
+						int(utilities.OpConcatN), 1,
+						int(utilities.OpCapture), 1,
+						int(utilities.OpLitPush), 2,
+					},
+					pool: []string{"foo", "name", "baz"},
+				},
+				{
+					method: "GET",
+					ops:    []int{int(utilities.OpLitPush), 0, int(utilities.OpLitPush), 1},
+					pool:   []string{"foo", "bar"},
+				},
+			},
+			reqMethod:   "GET",
+			reqPath:     "/foo/bar/qux/baz",
+			respStatus:  http.StatusOK,
+			respContent: "GET /foo/{name=**}/baz",
+		},
 		{
 			patterns: []stubPattern{
 				{
@@ -115,7 +158,7 @@ func TestMuxServeHTTP(t *testing.T) {
 			},
 			reqMethod:  "DELETE",
 			reqPath:    "/foo",
-			respStatus: http.StatusNotImplemented,
+			respStatus: http.StatusMethodNotAllowed,
 		},
 		{
 			patterns: []stubPattern{
@@ -146,7 +189,7 @@ func TestMuxServeHTTP(t *testing.T) {
 			headers: map[string]string{
 				"Content-Type": "application/x-www-form-urlencoded",
 			},
-			respStatus:                http.StatusNotImplemented,
+			respStatus:                http.StatusMethodNotAllowed,
 			disablePathLengthFallback: true,
 		},
 		{
@@ -206,7 +249,7 @@ func TestMuxServeHTTP(t *testing.T) {
 			headers: map[string]string{
 				"Content-Type": "application/json",
 			},
-			respStatus: http.StatusNotImplemented,
+			respStatus: http.StatusMethodNotAllowed,
 		},
 		{
 			patterns: []stubPattern{
@@ -510,6 +553,40 @@ func TestMuxServeHTTP(t *testing.T) {
 	}
 }
 
+func BenchmarkServeMux(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("routes=%d", n), func(b *testing.B) {
+			mux := runtime.NewServeMux()
+			for i := 0; i < n; i++ {
+				for _, meth := range []string{"GET", "POST", "DELETE"} {
+					err := mux.HandlePath(meth, fmt.Sprintf("/v1/resources%d/{id}", i), func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {})
+					if err != nil {
+						b.Fatalf("mux.HandlePath failed with %v; want success", err)
+					}
+				}
+			}
+			for _, tc := range []struct {
+				name   string
+				method string
+				path   string
+			}{
+				{"first", "GET", "/v1/resources0/abc"},
+				{"last", "GET", fmt.Sprintf("/v1/resources%d/abc", n-1)},
+				{"not_found", "GET", "/v2/resources/abc"},
+				{"method_not_allowed", "PUT", fmt.Sprintf("/v1/resources%d/abc", n/2)},
+			} {
+				r := httptest.NewRequest(tc.method, tc.path, nil)
+				b.Run(tc.name, func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						mux.ServeHTTP(httptest.NewRecorder(), r)
+					}
+				})
+			}
+		})
+	}
+}
+
 var defaultHeaderMatcherTests = []struct {
 	name     string
 	in       string
@@ -605,6 +682,150 @@ func TestServeMux_HandlePath(t *testing.T) {
 	}
 }
 
+func TestServeMux_Routes(t *testing.T) {
+	mux := runtime.NewServeMux()
+	testFn := func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {}
+	for _, route := range []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/v1/{bucket}/do:action"},
+		{"GET", "/v1/b/{bucket_name=buckets/*}/o/{name}"},
+		{"GET", "/v1/endpoint/**"},
+	} {
+		if err := mux.HandlePath(route.method, route.path, testFn); err != nil {
+			t.Fatalf("mux.HandlePath(%q, %q, testFn) failed with %v; want success", route.method, route.path, err)
+		}
+	}
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	mux.Handle("GET", pat, testFn, runtime.WithRPCMethodName("/example.EchoService/Echo"))
+
+	want := []runtime.Route{
+		{Method: "GET", Pattern: "/echo/{id=*}", RPCMethod: "/example.EchoService/Echo"},
+		{Method: "GET", Pattern: "/v1/endpoint/**"},
+		{Method: "GET", Pattern: "/v1/b/{bucket_name=buckets/*}/o/{name=*}"},
+		{Method: "POST", Pattern: "/v1/{bucket=*}/do:action", Verb: "action"},
+	}
+	if got := mux.Routes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("mux.Routes() = %#v; want %#v", got, want)
+	}
+}
+
+func TestWithFullDuplex(t *testing.T) {
+	for _, spec := range []struct {
+		name       string
+		opts       []runtime.HandleOption
+		protoMajor int
+		server     bool
+		want       bool
+	}{
+		{
+			name:       "HTTP/1.1 server",
+			opts:       []runtime.HandleOption{runtime.WithFullDuplex()},
+			protoMajor: 1,
+			server:     true,
+			want:       true,
+		},
+		{
+			name:       "HTTP/1.1 writer without full-duplex support",
+			opts:       []runtime.HandleOption{runtime.WithFullDuplex()},
+			protoMajor: 1,
+			want:       false,
+		},
+		{
+			name:       "HTTP/2",
+			opts:       []runtime.HandleOption{runtime.WithFullDuplex()},
+			protoMajor: 2,
+			want:       true,
+		},
+		{
+			name:       "not full-duplex handler",
+			protoMajor: 1,
+			server:     true,
+			want:       false,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux()
+			pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"echo"}, "")
+			if err != nil {
+				t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+			}
+			var got bool
+			mux.Handle("POST", pat, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				got = runtime.FullDuplex(r.Context())
+			}, spec.opts...)
+
+			if spec.server {
+				s := httptest.NewServer(mux)
+				defer s.Close()
+				resp, err := http.Post(s.URL+"/echo", "application/json", strings.NewReader("{}"))
+				if err != nil {
+					t.Fatalf("http.Post failed with %v; want success", err)
+				}
+				resp.Body.Close()
+			} else {
+				r := httptest.NewRequest("POST", "/echo", strings.NewReader("{}"))
+				r.ProtoMajor = spec.protoMajor
+				mux.ServeHTTP(httptest.NewRecorder(), r)
+			}
+			if got != spec.want {
+				t.Errorf("runtime.FullDuplex(ctx) = %t; want %t", got, spec.want)
+			}
+		})
+	}
+}
+
+func TestWithMiddlewares(t *testing.T) {
+	var (
+		calls  []string
+		chains int
+	)
+	middleware := func(name string) runtime.Middleware {
+		return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+			chains++
+			return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+				calls = append(calls, name)
+				next(w, r, pathParams)
+			}
+		}
+	}
+
+	mux := runtime.NewServeMux(runtime.WithMiddlewares(middleware("first"), middleware("second")))
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	mux.Handle("GET", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		calls = append(calls, "handler")
+
+		ctx := r.Context()
+		if got, ok := runtime.HTTPPattern(ctx); !ok || got.String() != pat.String() {
+			t.Errorf("runtime.HTTPPattern(ctx) = %v, %t; want %v, true", got, ok, pat)
+		}
+		if got, ok := runtime.PathParams(ctx); !ok || got["id"] != "foo" {
+			t.Errorf("runtime.PathParams(ctx) = %v, %t; want id=foo", got, ok)
+		}
+		if got, ok := runtime.RPCMethod(ctx); !ok || got != "/example.EchoService/Echo" {
+			t.Errorf("runtime.RPCMethod(ctx) = %q, %t; want %q, true", got, ok, "/example.EchoService/Echo")
+		}
+	}, runtime.WithRPCMethodName("/example.EchoService/Echo"), runtime.WithRouteMiddlewares(middleware("route")))
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/echo/foo", nil))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/echo/foo", nil))
+
+	if want := []string{"first", "second", "route", "handler", "first", "second", "route", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v; want %v", calls, want)
+	}
+	// The middlewares wrap the handler once, when it is registered.
+	if got, want := chains, 3; got != want {
+		t.Errorf("chains = %d; want %d", got, want)
+	}
+}
+
 var healthCheckTests = []struct {
 	name           string
 	code           codes.Code
@@ -643,6 +864,470 @@ var healthCheckTests = []struct {
 	},
 }
 
+func TestServeMux_MethodNotAllowed(t *testing.T) {
+	mux := runtime.NewServeMux()
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	for _, meth := range []string{"PUT", "GET", "DELETE"} {
+		mux.Handle(meth, pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {})
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("PATCH", "/echo/foo", nil))
+	if got, want := w.Code, http.StatusMethodNotAllowed; got != want {
+		t.Errorf("w.Code = %d; want %d", got, want)
+	}
+	if got, want := w.Header().Get("Allow"), "DELETE, GET, PUT"; got != want {
+		t.Errorf(`w.Header().Get("Allow") = %q; want %q`, got, want)
+	}
+
+	var allowed []string
+	mux = runtime.NewServeMux(runtime.WithRoutingErrorHandler(func(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+		allowed, _ = runtime.AllowedMethods(ctx)
+		w.WriteHeader(httpStatus)
+	}))
+	mux.Handle("GET", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {})
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/echo/foo", nil))
+	if want := []string{"GET"}; !reflect.DeepEqual(allowed, want) {
+		t.Errorf("runtime.AllowedMethods(ctx) = %v; want %v", allowed, want)
+	}
+}
+
+func TestWithAutomaticHEAD(t *testing.T) {
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	get := func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"id":%q}`, pathParams["id"])
+	}
+
+	for _, spec := range []struct {
+		name          string
+		opts          []runtime.ServeMuxOption
+		head          bool
+		streaming     bool
+		respStatus    int
+		contentLength string
+		allow         string
+	}{
+		{
+			name:       "disabled",
+			respStatus: http.StatusMethodNotAllowed,
+			allow:      "GET",
+		},
+		{
+			name:          "enabled",
+			opts:          []runtime.ServeMuxOption{runtime.WithAutomaticHEAD()},
+			respStatus:    http.StatusAccepted,
+			contentLength: "12",
+		},
+		{
+			name:       "explicit HEAD handler",
+			opts:       []runtime.ServeMuxOption{runtime.WithAutomaticHEAD()},
+			head:       true,
+			respStatus: http.StatusNoContent,
+		},
+		{
+			name:       "server streaming",
+			opts:       []runtime.ServeMuxOption{runtime.WithAutomaticHEAD()},
+			streaming:  true,
+			respStatus: http.StatusMethodNotAllowed,
+			allow:      "GET",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(spec.opts...)
+			if spec.streaming {
+				mux.Handle("GET", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+					t.Errorf("the server streaming handler was called for %s", r.Method)
+				}, runtime.WithServerStreaming())
+			} else {
+				mux.Handle("GET", pat, get)
+			}
+			if spec.head {
+				mux.Handle("HEAD", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+					w.WriteHeader(http.StatusNoContent)
+				})
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("HEAD", "/echo/foo", nil))
+			if got, want := w.Code, spec.respStatus; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+			if spec.contentLength != "" {
+				if got, want := w.Header().Get("Content-Length"), spec.contentLength; got != want {
+					t.Errorf(`w.Header().Get("Content-Length") = %q; want %q`, got, want)
+				}
+				if got := w.Body.Len(); got != 0 {
+					t.Errorf("w.Body.Len() = %d; want 0", got)
+				}
+			}
+			if got, want := w.Header().Get("Allow"), spec.allow; got != want {
+				t.Errorf(`w.Header().Get("Allow") = %q; want %q`, got, want)
+			}
+		})
+	}
+}
+
+func TestServeMux_RouteMatchers(t *testing.T) {
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	handler := func(name string) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			fmt.Fprint(w, name)
+		}
+	}
+	mux := runtime.NewServeMux()
+	mux.Handle("GET", pat, handler("v1"))
+	mux.Handle("GET", pat, handler("v2"), runtime.WithHeader("X-API-Version", "2"))
+	mux.Handle("GET", pat, handler("exact host"), runtime.WithHost("api.example.com"))
+	mux.Handle("GET", pat, handler("any subdomain"), runtime.WithHost("*.example.net"))
+	mux.Handle("POST", pat, handler("post"), runtime.WithHost("admin.example.com"))
+
+	for _, spec := range []struct {
+		name        string
+		method      string
+		host        string
+		headers     map[string]string
+		respStatus  int
+		respContent string
+	}{
+		{
+			name:        "unconstrained",
+			method:      "GET",
+			host:        "example.org",
+			respStatus:  http.StatusOK,
+			respContent: "v1",
+		},
+		{
+			name:        "header",
+			method:      "GET",
+			host:        "example.org",
+			headers:     map[string]string{"X-Api-Version": "2"},
+			respStatus:  http.StatusOK,
+			respContent: "v2",
+		},
+		{
+			name:        "header mismatch",
+			method:      "GET",
+			host:        "example.org",
+			headers:     map[string]string{"X-Api-Version": "3"},
+			respStatus:  http.StatusOK,
+			respContent: "v1",
+		},
+		{
+			name:        "host with port",
+			method:      "GET",
+			host:        "API.example.com:8080",
+			respStatus:  http.StatusOK,
+			respContent: "exact host",
+		},
+		{
+			name:        "wildcard host",
+			method:      "GET",
+			host:        "a.b.example.net",
+			respStatus:  http.StatusOK,
+			respContent: "any subdomain",
+		},
+		{
+			name:        "wildcard host does not match the domain itself",
+			method:      "GET",
+			host:        "example.net",
+			respStatus:  http.StatusOK,
+			respContent: "v1",
+		},
+		{
+			name:        "other method on matching host",
+			method:      "POST",
+			host:        "admin.example.com",
+			respStatus:  http.StatusOK,
+			respContent: "post",
+		},
+		{
+			name:       "other method on other host",
+			method:     "POST",
+			host:       "example.org",
+			respStatus: http.StatusMethodNotAllowed,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r := httptest.NewRequest(spec.method, "/echo/foo", nil)
+			r.Host = spec.host
+			for k, v := range spec.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			if got, want := w.Code, spec.respStatus; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+			if spec.respContent != "" {
+				if got, want := w.Body.String(), spec.respContent; got != want {
+					t.Errorf("w.Body = %q; want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestServeMux_Unregister(t *testing.T) {
+	mux := runtime.NewServeMux()
+	for _, path := range []string{"/echo/{id}", "/echo/{id}:verb", "/echo/{id}/reply"} {
+		if err := mux.HandlePath("GET", path, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {}); err != nil {
+			t.Fatalf("mux.HandlePath(%q) failed with %v; want success", path, err)
+		}
+	}
+	if err := mux.HandlePath("POST", "/echo/{id}", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {}); err != nil {
+		t.Fatalf("mux.HandlePath failed with %v; want success", err)
+	}
+
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	mux.Unregister("GET", pat)
+
+	want := []runtime.Route{
+		{Method: "GET", Pattern: "/echo/{id=*}/reply"},
+		{Method: "GET", Pattern: "/echo/{id=*}:verb", Verb: "verb"},
+		{Method: "POST", Pattern: "/echo/{id=*}"},
+	}
+	if got := mux.Routes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("mux.Routes() = %v; want %v", got, want)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/echo/foo", nil))
+	if got, want := w.Code, http.StatusMethodNotAllowed; got != want {
+		t.Errorf("w.Code = %d; want %d", got, want)
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	handler := func(name string) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			fmt.Fprint(w, name)
+		}
+	}
+	serve := func(mux *runtime.ServeMux) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/echo/foo", nil))
+		return w
+	}
+
+	mux := runtime.NewServeMux()
+	mux.Handle("GET", pat, handler("v1"))
+	var reg runtime.Registration
+	mux.Handle("GET", pat, handler("v2"), runtime.WithRegistration(&reg))
+	if got, want := serve(mux).Body.String(), "v2"; got != want {
+		t.Errorf("w.Body = %q; want %q", got, want)
+	}
+
+	if err := reg.Close(); err != nil {
+		t.Fatalf("reg.Close() failed with %v; want success", err)
+	}
+	if got, want := serve(mux).Body.String(), "v1"; got != want {
+		t.Errorf("w.Body = %q; want %q", got, want)
+	}
+
+	mux.Handle("GET", pat, handler("v3"), runtime.WithRegistration(&reg))
+	if got, want := serve(mux).Body.String(), "v1"; got != want {
+		t.Errorf("w.Body = %q after registering on a closed registration; want %q", got, want)
+	}
+	if got, want := len(mux.Routes()), 1; got != want {
+		t.Errorf("len(mux.Routes()) = %d; want %d", got, want)
+	}
+}
+
+func TestRegistration_concurrentServeHTTP(t *testing.T) {
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0, int(utilities.OpPush), 0, int(utilities.OpConcatN), 1, int(utilities.OpCapture), 1}, []string{"echo", "id"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	mux := runtime.NewServeMux()
+	mux.Handle("GET", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {})
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, httptest.NewRequest("GET", "/echo/foo", nil))
+				if w.Code != http.StatusOK {
+					t.Errorf("w.Code = %d; want %d", w.Code, http.StatusOK)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		var reg runtime.Registration
+		mux.Handle("GET", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {}, runtime.WithRegistration(&reg))
+		mux.Handle("POST", pat, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {}, runtime.WithRegistration(&reg))
+		_ = mux.Routes()
+		if err := reg.Close(); err != nil {
+			t.Errorf("reg.Close() failed with %v; want success", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestWithPathPrefix(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithPathPrefix("/api/v2/"))
+	var gotPattern, gotPathPattern string
+	err := mux.HandlePath("GET", "/echo/{id}", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		pat, _ := runtime.HTTPPattern(r.Context())
+		gotPattern = pat.String()
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.EchoService/Echo", runtime.WithHTTPPathPattern("/echo/{id}"))
+		if err != nil {
+			t.Errorf("runtime.AnnotateContext failed with %v; want success", err)
+			return
+		}
+		gotPathPattern, _ = runtime.HTTPPathPattern(ctx)
+		fmt.Fprint(w, pathParams["id"])
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath failed with %v; want success", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/echo/foo", nil))
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Fatalf("w.Code = %d; want %d", got, want)
+	}
+	if got, want := w.Body.String(), "foo"; got != want {
+		t.Errorf("w.Body = %q; want %q", got, want)
+	}
+	if want := "/api/v2/echo/{id=*}"; gotPattern != want {
+		t.Errorf("runtime.HTTPPattern(ctx) = %q; want %q", gotPattern, want)
+	}
+	if want := "/api/v2/echo/{id}"; gotPathPattern != want {
+		t.Errorf("runtime.HTTPPathPattern(ctx) = %q; want %q", gotPathPattern, want)
+	}
+	if want := []runtime.Route{{Method: "GET", Pattern: "/api/v2/echo/{id=*}"}}; !reflect.DeepEqual(mux.Routes(), want) {
+		t.Errorf("mux.Routes() = %v; want %v", mux.Routes(), want)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/echo/foo", nil))
+	if got, want := w.Code, http.StatusNotFound; got != want {
+		t.Errorf("w.Code = %d; want %d", got, want)
+	}
+}
+
+func TestServeMux_NotAcceptable(t *testing.T) {
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"echo"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	testFn := func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {}
+
+	for _, spec := range []struct {
+		name       string
+		opts       []runtime.ServeMuxOption
+		rpcMethod  string
+		httpBody   bool
+		accept     string
+		wantStatus int
+	}{
+		{
+			name:       "acceptable",
+			rpcMethod:  "/example.EchoService/Echo",
+			accept:     "text/html, application/*;q=0.5",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "not acceptable",
+			rpcMethod:  "/example.EchoService/Echo",
+			accept:     "text/html, application/json;q=0",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:       "HttpBody response",
+			rpcMethod:  "/example.EchoService/Download",
+			httpBody:   true,
+			accept:     "image/png",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "problem details",
+			rpcMethod:  "/example.EchoService/Echo",
+			accept:     "application/problem+json",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "without RPC method",
+			accept:     "text/html",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "disabled",
+			opts:       []runtime.ServeMuxOption{runtime.WithDisableNotAcceptable()},
+			rpcMethod:  "/example.EchoService/Echo",
+			accept:     "text/html",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "server-sent events",
+			rpcMethod:  "/example.EchoService/Echo",
+			accept:     "text/event-stream",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "server-sent events disabled",
+			opts:       []runtime.ServeMuxOption{runtime.WithDisableServerSentEvents()},
+			rpcMethod:  "/example.EchoService/Echo",
+			accept:     "text/event-stream",
+			wantStatus: http.StatusNotAcceptable,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(spec.opts...)
+			var opts []runtime.HandleOption
+			if spec.rpcMethod != "" {
+				opts = append(opts, runtime.WithRPCMethodName(spec.rpcMethod))
+			}
+			if spec.httpBody {
+				opts = append(opts, runtime.WithHTTPBodyResponse())
+			}
+			mux.Handle("GET", pat, testFn, opts...)
+
+			r := httptest.NewRequest("GET", "/echo", nil)
+			r.Header.Set("Accept", spec.accept)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			if got, want := w.Code, spec.wantStatus; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+			if spec.wantStatus == http.StatusNotAcceptable {
+				if got, want := w.Header().Get("Content-Type"), "application/json"; got != want {
+					t.Errorf(`w.Header().Get("Content-Type") = %q; want %q`, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestWithHealthzEndpoint_codes(t *testing.T) {
 	for _, tt := range healthCheckTests {
 		t.Run(tt.name, func(t *testing.T) {
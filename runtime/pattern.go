@@ -3,6 +3,7 @@ package runtime
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -234,6 +235,151 @@ func (p Pattern) Match(components []string, verb string) (map[string]string, err
 	return p.MatchAndEscape(components, verb, UnescapingModeDefault)
 }
 
+// Expand returns the path matched by the Pattern whose variables are bound to "params",
+// escaping their values for the default UnescapingMode.
+// It is the reverse of MatchAndEscape. See ExpandAndEscape for more details.
+func (p Pattern) Expand(params map[string]string) (string, error) {
+	return p.ExpandAndEscape(params, UnescapingModeDefault)
+}
+
+// ExpandAndEscape returns the path matched by the Pattern whose variables are bound to "params",
+// escaping their values so that a ServeMux using "unescapingMode" matches them back.
+//
+// A variable spanning several segments, e.g. {name=shelves/*/books/*}, takes a value whose segments
+// are separated by "/". It returns an error if a variable has no value, if the value does not
+// match the segments of the variable or if the Pattern has a wildcard outside of a variable.
+func (p Pattern) ExpandAndEscape(params map[string]string, unescapingMode UnescapingMode) (string, error) {
+	// segments holds the ops which push a path segment, in order. Each element of
+	// stack is the range of segments pushed by the ops it results from.
+	type span struct{ start, end int }
+	var (
+		segments []op
+		stack    []span
+		vars     = make([]span, len(p.vars))
+	)
+	for _, o := range p.ops {
+		switch o.code {
+		case utilities.OpPush, utilities.OpLitPush, utilities.OpPushM:
+			stack = append(stack, span{len(segments), len(segments) + 1})
+			segments = append(segments, o)
+		case utilities.OpConcatN:
+			l := len(stack) - o.operand
+			stack = append(stack[:l], span{stack[l].start, stack[len(stack)-1].end})
+		case utilities.OpCapture:
+			n := len(stack) - 1
+			vars[o.operand] = stack[n]
+			stack = stack[:n]
+		}
+	}
+
+	components := make([]string, len(segments))
+	bound := make([]bool, len(segments))
+	for i, v := range vars {
+		name := p.vars[i]
+		val, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing value for variable %q", name)
+		}
+		if err := p.expandVariable(components[v.start:v.end], segments[v.start:v.end], val, unescapingMode); err != nil {
+			return "", fmt.Errorf("variable %q: %w", name, err)
+		}
+		for j := v.start; j < v.end; j++ {
+			bound[j] = true
+		}
+	}
+	for i, o := range segments {
+		if bound[i] {
+			continue
+		}
+		if o.code != utilities.OpLitPush {
+			return "", fmt.Errorf("pattern %s has a wildcard outside of a variable", p)
+		}
+		components[i] = p.pool[o.operand]
+	}
+
+	// A deep wildcard may match no segment at all.
+	nonEmpty := components[:0]
+	for i, c := range components {
+		if c != "" || segments[i].code != utilities.OpPushM {
+			nonEmpty = append(nonEmpty, c)
+		}
+	}
+	path := "/" + strings.Join(nonEmpty, "/")
+	if p.verb != "" {
+		path += ":" + p.verb
+	}
+	return path, nil
+}
+
+// expandVariable sets "components" to the escaped segments of "val", bound to the
+// variable made of "segments".
+func (p Pattern) expandVariable(components []string, segments []op, val string, unescapingMode UnescapingMode) error {
+	if len(segments) == 1 && segments[0].code == utilities.OpPush {
+		// A single segment is fully unescaped, but the ServeMux splits the
+		// path on escaped slashes in these modes.
+		if strings.Contains(val, "/") && (unescapingMode == UnescapingModeLegacy || unescapingMode == UnescapingModeAllCharacters) {
+			return fmt.Errorf("value %q contains a slash: %w", val, ErrNotMatch)
+		}
+		components[0] = url.PathEscape(val)
+		return nil
+	}
+
+	parts := strings.Split(val, "/")
+	deep := -1
+	for i, o := range segments {
+		if o.code == utilities.OpPushM {
+			deep = i
+		}
+	}
+	if deep < 0 && len(parts) != len(segments) || len(parts) < len(segments)-1 {
+		return fmt.Errorf("value %q has %d segments: %w", val, len(parts), ErrNotMatch)
+	}
+	for i, o := range segments {
+		var part string
+		switch {
+		case i == deep:
+			// The deep wildcard takes the segments left by the others.
+			tail := len(segments) - 1 - i
+			part = escapeMultiSegment(strings.Join(parts[i:len(parts)-tail], "/"), unescapingMode)
+		case deep >= 0 && i > deep:
+			part = parts[len(parts)-(len(segments)-i)]
+		default:
+			part = parts[i]
+		}
+		switch o.code {
+		case utilities.OpLitPush:
+			if lit := p.pool[o.operand]; part != lit {
+				return fmt.Errorf("segment %q of value %q is not %q: %w", part, val, lit, ErrNotMatch)
+			}
+		case utilities.OpPush:
+			part = url.PathEscape(part)
+		}
+		components[i] = part
+	}
+	return nil
+}
+
+// escapeMultiSegment escapes the value of a deep wildcard, keeping its slashes and
+// the escape sequences which are not unescaped in "mode".
+func escapeMultiSegment(s string, mode UnescapingMode) string {
+	var t strings.Builder
+	t.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '/':
+			t.WriteByte(c)
+		case c == '%' && mode != UnescapingModeLegacy && i+2 < len(s) && ishex(s[i+1]) && ishex(s[i+2]) &&
+			!shouldUnescapeWithMode(unhex(s[i+1])<<4|unhex(s[i+2]), mode):
+			t.WriteString(s[i : i+3])
+			i += 2
+		default:
+			t.WriteString(url.PathEscape(s[i : i+1]))
+		}
+	}
+	return t.String()
+}
+
 // Verb returns the verb part of the Pattern.
 func (p Pattern) Verb() string { return p.verb }
 
@@ -265,6 +411,24 @@ func (p Pattern) String() string {
 	return "/" + segs
 }
 
+// withPrefix returns a copy of the pattern which matches the paths of "p" below
+// the literal "segments".
+func (p Pattern) withPrefix(segments []string) Pattern {
+	if len(segments) == 0 {
+		return p
+	}
+	pool := append(p.pool[:len(p.pool):len(p.pool)], segments...)
+	ops := make([]op, 0, len(segments)+len(p.ops))
+	for i := range segments {
+		ops = append(ops, op{code: utilities.OpLitPush, operand: len(p.pool) + i})
+	}
+	p.ops = append(ops, p.ops...)
+	p.pool = pool
+	// The literals of the prefix are never popped.
+	p.stacksize += len(segments)
+	return p
+}
+
 /*
  * The following code is adopted and modified from Go's standard library
  * and carries the attached license.
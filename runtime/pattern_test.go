@@ -2,10 +2,13 @@ package runtime
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
 )
 
@@ -633,3 +636,139 @@ func TestPatternString(t *testing.T) {
 		}
 	}
 }
+
+func TestExpand(t *testing.T) {
+	for _, spec := range []struct {
+		tmpl   string
+		params map[string]string
+		mode   UnescapingMode
+
+		want    string
+		wantErr bool
+	}{
+		{
+			tmpl: "/",
+			want: "/",
+		},
+		{
+			tmpl: "/v1/echo:ping",
+			want: "/v1/echo:ping",
+		},
+		{
+			tmpl:   "/v1/echo/{id}",
+			params: map[string]string{"id": "my id?"},
+			want:   "/v1/echo/my%20id%3F",
+		},
+		{
+			tmpl:   "/v1/echo/{id}:ping",
+			params: map[string]string{"id": "x"},
+			want:   "/v1/echo/x:ping",
+		},
+		{
+			tmpl:   "/v1/{name=shelves/*/books/*}",
+			params: map[string]string{"name": "shelves/s1/books/b 1"},
+			want:   "/v1/shelves/s1/books/b%201",
+		},
+		{
+			tmpl:    "/v1/{name=shelves/*/books/*}",
+			params:  map[string]string{"name": "shelves/s1/magazines/m1"},
+			wantErr: true,
+		},
+		{
+			tmpl:    "/v1/{name=shelves/*}",
+			params:  map[string]string{"name": "shelves/s1/books/b1"},
+			wantErr: true,
+		},
+		{
+			tmpl:   "/v1/{name=objects/**}/tail",
+			params: map[string]string{"name": "objects/a b/c"},
+			want:   "/v1/objects/a%20b/c/tail",
+		},
+		{
+			tmpl:   "/v1/{name=objects/**}/tail",
+			params: map[string]string{"name": "objects/"},
+			want:   "/v1/objects/tail",
+		},
+		{
+			tmpl:   "/v1/{id}",
+			params: map[string]string{"id": "a/b"},
+			mode:   UnescapingModeAllExceptReserved,
+			want:   "/v1/a%2Fb",
+		},
+		{
+			tmpl:    "/v1/{id}",
+			params:  map[string]string{"id": "a/b"},
+			mode:    UnescapingModeLegacy,
+			wantErr: true,
+		},
+		{
+			tmpl:   "/v1/{path=**}",
+			params: map[string]string{"path": "a%2Fb/c%"},
+			mode:   UnescapingModeAllExceptSlash,
+			want:   "/v1/a%2Fb/c%25",
+		},
+		{
+			tmpl:   "/v1/{path=**}",
+			params: map[string]string{"path": "a b/c"},
+			mode:   UnescapingModeAllCharacters,
+			want:   "/v1/a%20b/c",
+		},
+		{
+			tmpl:    "/v1/{id}",
+			wantErr: true,
+		},
+		{
+			tmpl:    "/v1/*",
+			wantErr: true,
+		},
+	} {
+		t.Run(spec.tmpl, func(t *testing.T) {
+			compiler, err := httprule.Parse(spec.tmpl)
+			if err != nil {
+				t.Fatalf("httprule.Parse(%q) failed with %v; want success", spec.tmpl, err)
+			}
+			tp := compiler.Compile()
+			p, err := NewPattern(tp.Version, tp.OpCodes, tp.Pool, tp.Verb)
+			if err != nil {
+				t.Fatalf("NewPattern failed with %v; want success", err)
+			}
+
+			got, err := p.ExpandAndEscape(spec.params, spec.mode)
+			if spec.wantErr {
+				if err == nil {
+					t.Errorf("%s.ExpandAndEscape(%v, %v) = %q; want error", p, spec.params, spec.mode, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s.ExpandAndEscape(%v, %v) failed with %v; want success", p, spec.params, spec.mode, err)
+			}
+			if got != spec.want {
+				t.Errorf("%s.ExpandAndEscape(%v, %v) = %q; want %q", p, spec.params, spec.mode, got, spec.want)
+			}
+
+			// The ServeMux matches the expanded path back to the parameters.
+			mux := NewServeMux(WithUnescapingMode(spec.mode))
+			var params map[string]string
+			mux.Handle("GET", p, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+				params = pathParams
+			})
+			r, err := http.NewRequest("GET", got, nil)
+			if err != nil {
+				t.Fatalf("http.NewRequest(%q) failed with %v; want success", got, err)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("mux.ServeHTTP(%q): w.Code = %d; want %d", got, w.Code, http.StatusOK)
+			}
+			want := spec.params
+			if want == nil {
+				want = map[string]string{}
+			}
+			if !reflect.DeepEqual(params, want) {
+				t.Errorf("pathParams = %v; want %v", params, want)
+			}
+		})
+	}
+}
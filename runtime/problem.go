@@ -0,0 +1,152 @@
+package runtime
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// MIMEProblemJSON is the media type of Problem Details documents, see RFC 7807.
+const MIMEProblemJSON = "application/problem+json"
+
+// ProblemDetails is a Problem Details document as defined by RFC 7807.
+type ProblemDetails struct {
+	// Type is a URI reference identifying the problem type. It defaults to "about:blank".
+	Type string
+	// Title is a short summary of the problem type.
+	Title string
+	// Status is the HTTP status code of the response.
+	Status int
+	// Detail explains this occurrence of the problem.
+	Detail string
+	// Instance is a URI reference identifying this occurrence of the problem.
+	Instance string
+	// Extensions holds the extension members, marshaled next to the members above.
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON implements json.Marshaler. Empty members are omitted.
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	doc := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		doc[k] = v
+	}
+	typ := p.Type
+	if typ == "" {
+		typ = "about:blank"
+	}
+	doc["type"] = typ
+	if p.Title != "" {
+		doc["title"] = p.Title
+	}
+	if p.Status != 0 {
+		doc["status"] = p.Status
+	}
+	if p.Detail != "" {
+		doc["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		doc["instance"] = p.Instance
+	}
+	return json.Marshal(doc)
+}
+
+// problemParam describes a request parameter reported by a BadRequest detail.
+type problemParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// problemLink is a link reported by a Help detail.
+type problemLink struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// NewProblemDetails converts "s", replied with "httpStatus" to "r", into a Problem Details document.
+//
+// The gRPC code is kept as the "code" extension member. The details of "s" are converted as follows,
+// other details are dropped:
+//
+//	BadRequest -> "invalid-params", a list of {"name", "reason"} objects
+//	ErrorInfo -> "reason", "domain" and "metadata"
+//	Help -> "help", a list of {"description", "url"} objects
+func NewProblemDetails(r *http.Request, httpStatus int, s *status.Status) *ProblemDetails {
+	p := &ProblemDetails{
+		Title:  http.StatusText(httpStatus),
+		Status: httpStatus,
+		Detail: s.Message(),
+		Extensions: map[string]interface{}{
+			"code": int(s.Code()),
+		},
+	}
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+
+	var (
+		params []problemParam
+		links  []problemLink
+	)
+	for _, d := range s.Details() {
+		switch d := d.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				params = append(params, problemParam{Name: v.GetField(), Reason: v.GetDescription()})
+			}
+		case *errdetails.ErrorInfo:
+			p.Extensions["reason"] = d.GetReason()
+			p.Extensions["domain"] = d.GetDomain()
+			if len(d.GetMetadata()) > 0 {
+				p.Extensions["metadata"] = d.GetMetadata()
+			}
+		case *errdetails.Help:
+			for _, l := range d.GetLinks() {
+				links = append(links, problemLink{Description: l.GetDescription(), URL: l.GetUrl()})
+			}
+		}
+	}
+	if len(params) > 0 {
+		p.Extensions["invalid-params"] = params
+	}
+	if len(links) > 0 {
+		p.Extensions["help"] = links
+	}
+	return p
+}
+
+// WithProblemDetails returns a ServeMuxOption which makes DefaultHTTPErrorHandler reply with
+// Problem Details documents (RFC 7807) instead of Status messages, whatever the request accepts.
+//
+// Without this option, DefaultHTTPErrorHandler replies with a Problem Details document only to
+// requests whose Accept header explicitly lists application/problem+json.
+func WithProblemDetails() ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.problemDetails = true
+	}
+}
+
+// acceptsProblemDetails reports whether the Accept header of "r" explicitly lists application/problem+json.
+func acceptsProblemDetails(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || mediaType != MIMEProblemJSON {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q <= 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProblemDetailsHTTPError(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid book").WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "book.title", Description: "must not be empty"},
+			},
+		},
+		&errdetails.ErrorInfo{
+			Reason:   "INVALID_TITLE",
+			Domain:   "library.example.com",
+			Metadata: map[string]string{"shelf": "1"},
+		},
+		&errdetails.Help{
+			Links: []*errdetails.Help_Link{
+				{Description: "Books", Url: "https://library.example.com/docs/books"},
+			},
+		},
+		&errdetails.DebugInfo{Detail: "dropped"},
+	)
+	if err != nil {
+		t.Fatalf("status.WithDetails failed with %v; want success", err)
+	}
+	wantProblem := map[string]interface{}{
+		"type":     "about:blank",
+		"title":    "Bad Request",
+		"status":   float64(http.StatusBadRequest),
+		"detail":   "invalid book",
+		"instance": "/v1/shelves/1/books",
+		"code":     float64(codes.InvalidArgument),
+		"reason":   "INVALID_TITLE",
+		"domain":   "library.example.com",
+		"metadata": map[string]interface{}{"shelf": "1"},
+		"invalid-params": []interface{}{
+			map[string]interface{}{"name": "book.title", "reason": "must not be empty"},
+		},
+		"help": []interface{}{
+			map[string]interface{}{"description": "Books", "url": "https://library.example.com/docs/books"},
+		},
+	}
+
+	for _, spec := range []struct {
+		name        string
+		opts        []runtime.ServeMuxOption
+		accept      string
+		wantProblem bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "with option",
+			opts:        []runtime.ServeMuxOption{runtime.WithProblemDetails()},
+			accept:      "application/json",
+			wantProblem: true,
+		},
+		{
+			name:        "accept",
+			accept:      "application/json;q=0.5, application/problem+json",
+			wantProblem: true,
+		},
+		{
+			name:   "accept with zero quality",
+			accept: "application/problem+json;q=0",
+		},
+		{
+			name:   "accept wildcard",
+			accept: "*/*",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/shelves/1/books", nil)
+			if spec.accept != "" {
+				r.Header.Set("Accept", spec.accept)
+			}
+			w := httptest.NewRecorder()
+			mux := runtime.NewServeMux(spec.opts...)
+			runtime.HTTPError(context.Background(), mux, &runtime.JSONPb{}, w, r, st.Err())
+
+			if got, want := w.Code, http.StatusBadRequest; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+			wantContentType := "application/json"
+			if spec.wantProblem {
+				wantContentType = runtime.MIMEProblemJSON
+			}
+			if got := w.Header().Get("Content-Type"); got != wantContentType {
+				t.Errorf("Content-Type = %q; want %q", got, wantContentType)
+			}
+
+			var got map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal(%s) failed with %v; want success", w.Body.Bytes(), err)
+			}
+			if !spec.wantProblem {
+				if _, ok := got["type"]; ok {
+					t.Errorf("body = %s; want a Status message", w.Body.Bytes())
+				}
+				return
+			}
+			if diff := cmp.Diff(wantProblem, got); diff != "" {
+				t.Errorf("body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProblemDetailsHTTPStatusError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodDelete, "/v1/books", nil)
+	w := httptest.NewRecorder()
+	mux := runtime.NewServeMux(runtime.WithProblemDetails())
+	err := &runtime.HTTPStatusError{
+		HTTPStatus: http.StatusMethodNotAllowed,
+		Err:        status.Error(codes.Unimplemented, http.StatusText(http.StatusMethodNotAllowed)),
+	}
+	runtime.HTTPError(context.Background(), mux, &runtime.JSONPb{}, w, r, err)
+
+	if got, want := w.Code, http.StatusMethodNotAllowed; got != want {
+		t.Errorf("w.Code = %d; want %d", got, want)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) failed with %v; want success", w.Body.Bytes(), err)
+	}
+	want := map[string]interface{}{
+		"type":     "about:blank",
+		"title":    "Method Not Allowed",
+		"status":   float64(http.StatusMethodNotAllowed),
+		"detail":   "Method Not Allowed",
+		"instance": "/v1/books",
+		"code":     float64(codes.Unimplemented),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("body mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,6 +1,8 @@
 package runtime
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -69,6 +71,90 @@ func PopulateFieldFromPath(msg proto.Message, fieldPathString string, value stri
 	return populateFieldValueFromPath(msg.ProtoReflect(), fieldPath, []string{value})
 }
 
+// FormatFieldFromPath returns the string representation of the field at "fieldPathString" in
+// "msg", in the format parsed by PopulateFieldFromPath and the path parameter converters.
+// It is used to fill the path parameters of a Pattern from a request message. The elements
+// of a repeated field are joined with "separator".
+func FormatFieldFromPath(msg proto.Message, fieldPathString string, separator string) (string, error) {
+	msgValue := msg.ProtoReflect()
+	fieldPath := strings.Split(fieldPathString, ".")
+	var fieldDescriptor protoreflect.FieldDescriptor
+	for i, fieldName := range fieldPath {
+		fields := msgValue.Descriptor().Fields()
+		fieldDescriptor = fields.ByName(protoreflect.Name(fieldName))
+		if fieldDescriptor == nil {
+			fieldDescriptor = fields.ByJSONName(fieldName)
+			if fieldDescriptor == nil {
+				return "", fmt.Errorf("field not found in %q: %q", msgValue.Descriptor().FullName(), fieldPathString)
+			}
+		}
+		if i == len(fieldPath)-1 {
+			break
+		}
+		if fieldDescriptor.Message() == nil || fieldDescriptor.Cardinality() == protoreflect.Repeated {
+			return "", fmt.Errorf("invalid path: %q is not a message", fieldName)
+		}
+		msgValue = msgValue.Get(fieldDescriptor).Message()
+	}
+
+	switch {
+	case fieldDescriptor.IsMap():
+		return "", fmt.Errorf("map field %q cannot be formatted", fieldDescriptor.FullName().Name())
+	case fieldDescriptor.IsList():
+		list := msgValue.Get(fieldDescriptor).List()
+		values := make([]string, list.Len())
+		for i := range values {
+			v, err := formatField(fieldDescriptor, list.Get(i))
+			if err != nil {
+				return "", fmt.Errorf("formatting list %q: %w", fieldDescriptor.FullName().Name(), err)
+			}
+			values[i] = v
+		}
+		return strings.Join(values, separator), nil
+	}
+	v, err := formatField(fieldDescriptor, msgValue.Get(fieldDescriptor))
+	if err != nil {
+		return "", fmt.Errorf("formatting field %q: %w", fieldDescriptor.FullName().Name(), err)
+	}
+	return v, nil
+}
+
+// formatField is the reverse of parseField.
+func formatField(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) (string, error) {
+	switch fieldDescriptor.Kind() {
+	case protoreflect.EnumKind:
+		if v := fieldDescriptor.Enum().Values().ByNumber(value.Enum()); v != nil {
+			return string(v.Name()), nil
+		}
+		return strconv.Itoa(int(value.Enum())), nil
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), nil
+	case protoreflect.BytesKind:
+		return base64.URLEncoding.EncodeToString(value.Bytes()), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return formatMessage(value.Message().Interface())
+	default:
+		return value.String(), nil
+	}
+}
+
+// formatMessage is the reverse of parseMessage.
+func formatMessage(msg proto.Message) (string, error) {
+	if fm, ok := msg.(*field_mask.FieldMask); ok {
+		return strings.Join(fm.GetPaths(), ","), nil
+	}
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	// Well known types such as Timestamp are represented by a JSON string.
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return s, nil
+	}
+	return string(b), nil
+}
+
 func populateFieldValueFromPath(msgValue protoreflect.Message, fieldPath []string, values []string) error {
 	if len(fieldPath) < 1 {
 		return errors.New("no field path")
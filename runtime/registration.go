@@ -0,0 +1,53 @@
+package runtime
+
+import "sync"
+
+// A Registration groups handlers registered on one or more ServeMuxes, e.g. the handlers of
+// a service, so that they can be unregistered together. The zero value is ready to use.
+//
+// Give it to ServeMux.Handle, or to the generated Register*Handler functions, with
+// WithRegistration.
+type Registration struct {
+	mu       sync.Mutex
+	closed   bool
+	handlers []registeredHandler
+}
+
+type registeredHandler struct {
+	mux *ServeMux
+	h   *handler
+}
+
+// WithRegistration returns a HandleOption which adds the handler to "reg".
+// The handler is unregistered right away if "reg" is already closed.
+func WithRegistration(reg *Registration) HandleOption {
+	return func(h *handler) {
+		h.regs = append(h.regs, reg)
+	}
+}
+
+// add records "h", which was registered on "mux". It is unregistered right away
+// if the Registration is closed.
+func (r *Registration) add(mux *ServeMux, h *handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		mux.remove(h)
+		return
+	}
+	r.handlers = append(r.handlers, registeredHandler{mux: mux, h: h})
+}
+
+// Close unregisters the handlers of the Registration from their ServeMux.
+// Requests already dispatched to the handlers are not interrupted.
+// Close is safe to call more than once and concurrently with ServeMux.ServeHTTP.
+func (r *Registration) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for _, rh := range r.handlers {
+		rh.mux.remove(rh.h)
+	}
+	r.handlers = nil
+	return nil
+}
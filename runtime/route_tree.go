@@ -0,0 +1,174 @@
+package runtime
+
+import (
+	"sort"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+)
+
+// routeTree indexes the handlers registered for a single HTTP method by the
+// literal segments of their patterns, so that only the handlers which can
+// possibly match a request path need to be evaluated by Pattern.MatchAndEscape.
+type routeTree struct {
+	root routeNode
+}
+
+// routeNode is a node of a routeTree. Every edge consumes exactly one path
+// component.
+type routeNode struct {
+	// literals holds the children reached through a literal segment.
+	literals map[string]*routeNode
+	// wildcard is the child reached through a single segment wildcard ("*").
+	wildcard *routeNode
+	// leaves holds the handlers whose patterns end at this node.
+	leaves []*handler
+	// deep holds the handlers whose patterns continue with a multi segment
+	// wildcard ("**") at this node. The rest of those patterns is left to
+	// Pattern.MatchAndEscape.
+	deep []*handler
+}
+
+// insert adds "h" to the tree.
+func (t *routeTree) insert(h *handler) {
+	n := &t.root
+	for _, op := range h.pat.ops {
+		switch op.code {
+		case utilities.OpLitPush:
+			lit := h.pat.pool[op.operand]
+			child, ok := n.literals[lit]
+			if !ok {
+				if n.literals == nil {
+					n.literals = make(map[string]*routeNode)
+				}
+				child = &routeNode{}
+				n.literals[lit] = child
+			}
+			n = child
+		case utilities.OpPush:
+			if n.wildcard == nil {
+				n.wildcard = &routeNode{}
+			}
+			n = n.wildcard
+		case utilities.OpPushM:
+			n.deep = append(n.deep, h)
+			return
+		}
+	}
+	n.leaves = append(n.leaves, h)
+}
+
+// remove deletes "h" from the tree, pruning the nodes left without handlers.
+// It reports whether "h" was found.
+func (t *routeTree) remove(h *handler) bool {
+	return t.root.remove(h, h.pat.ops)
+}
+
+func (n *routeNode) remove(h *handler, ops []op) bool {
+	for i, o := range ops {
+		var child *routeNode
+		switch o.code {
+		case utilities.OpLitPush:
+			child = n.literals[h.pat.pool[o.operand]]
+		case utilities.OpPush:
+			child = n.wildcard
+		case utilities.OpPushM:
+			return removeHandler(&n.deep, h)
+		default:
+			continue
+		}
+		if child == nil || !child.remove(h, ops[i+1:]) {
+			return false
+		}
+		if child.empty() {
+			if o.code == utilities.OpPush {
+				n.wildcard = nil
+			} else {
+				delete(n.literals, h.pat.pool[o.operand])
+			}
+		}
+		return true
+	}
+	return removeHandler(&n.leaves, h)
+}
+
+func (n *routeNode) empty() bool {
+	return len(n.literals) == 0 && n.wildcard == nil && len(n.leaves) == 0 && len(n.deep) == 0
+}
+
+func removeHandler(handlers *[]*handler, h *handler) bool {
+	for i, v := range *handlers {
+		if v == h {
+			*handlers = append((*handlers)[:i:i], (*handlers)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the handlers whose patterns may match "components", in the
+// order in which they must be tried: the most recently registered first.
+// The returned handlers still need to be matched with Pattern.MatchAndEscape.
+func (t *routeTree) lookup(components []string) []*handler {
+	var candidates []*handler
+	t.root.collect(components, 0, &candidates)
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].seq > candidates[j].seq
+	})
+	return candidates
+}
+
+// all returns every handler in the tree, the most recently registered first.
+func (t *routeTree) all() []*handler {
+	var handlers []*handler
+	t.root.walk(func(h *handler) {
+		handlers = append(handlers, h)
+	})
+	sort.Slice(handlers, func(i, j int) bool {
+		return handlers[i].seq > handlers[j].seq
+	})
+	return handlers
+}
+
+func (n *routeNode) walk(fn func(*handler)) {
+	for _, h := range n.deep {
+		fn(h)
+	}
+	for _, h := range n.leaves {
+		fn(h)
+	}
+	for _, child := range n.literals {
+		child.walk(fn)
+	}
+	if n.wildcard != nil {
+		n.wildcard.walk(fn)
+	}
+}
+
+func (n *routeNode) collect(components []string, pos int, candidates *[]*handler) {
+	*candidates = append(*candidates, n.deep...)
+	if pos == len(components) {
+		*candidates = append(*candidates, n.leaves...)
+		return
+	}
+
+	c := components[pos]
+	if child, ok := n.literals[c]; ok {
+		child.collect(components, pos+1, candidates)
+	}
+	if pos == len(components)-1 {
+		// The last component may carry a verb, which ServeHTTP only splits
+		// off while trying a pattern having that verb. Follow every literal
+		// the component could be reduced to.
+		for i := 0; i < len(c); i++ {
+			if c[i] != ':' {
+				continue
+			}
+			if child, ok := n.literals[c[:i]]; ok {
+				child.collect(components, pos+1, candidates)
+			}
+		}
+	}
+	if n.wildcard != nil {
+		n.wildcard.collect(components, pos+1, candidates)
+	}
+}
@@ -0,0 +1,148 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/protobuf/proto"
+)
+
+// MIMEEventStream is the MIME type of Server-Sent Events, see
+// https://html.spec.whatwg.org/multipage/server-sent-events.html.
+const MIMEEventStream = "text/event-stream"
+
+// WithDisableServerSentEvents returns a ServeMuxOption which disables forwarding the responses of
+// server streaming methods as Server-Sent Events to the requests accepting text/event-stream.
+func WithDisableServerSentEvents() ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.disableServerSentEvents = true
+	}
+}
+
+// WithServerSentEventsKeepAlive returns a ServeMuxOption which makes the Server-Sent Events streams
+// send a ": keep-alive" comment after every "interval" without events, so that the connections
+// are not closed by proxies and load balancers while the server streams are idle.
+func WithServerSentEventsKeepAlive(interval time.Duration) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.sseKeepAlive = interval
+	}
+}
+
+// WithServerSentEventIDs returns a ServeMuxOption which numbers the events of the Server-Sent Events
+// streams from 1 with their "id:" field.
+func WithServerSentEventIDs() ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.sseEventIDs = true
+	}
+}
+
+// acceptsEventStream reports whether the Accept header of "r" explicitly accepts text/event-stream.
+func acceptsEventStream(r *http.Request) bool {
+	for _, rng := range parseAccept(r.Header[acceptHeader]) {
+		if rng.typ+"/"+rng.subtype == MIMEEventStream && rng.q > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardResponseEventStream forwards the stream from gRPC server to REST client as Server-Sent Events.
+// The messages are "message" events, whose data are the messages marshaled by "marshaler", and an
+// error ends the stream with an "error" event, whose data is the status returned by the stream error
+// handler. The response status is always http.StatusOK, as EventSource does not read other responses.
+func forwardResponseEventStream(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, f http.Flusher, req *http.Request, recv func() (proto.Message, error), opts []func(context.Context, http.ResponseWriter, proto.Message) error) {
+	if err := handleForwardResponseOptions(ctx, w, nil, opts); err != nil {
+		HTTPError(ctx, mux, marshaler, w, req, err)
+		return
+	}
+	w.Header().Set("Content-Type", MIMEEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	f.Flush()
+
+	keepAlive := mux.sseKeepAlive
+	if keepAlive <= 0 {
+		keepAlive = mux.streamKeepAlive
+	}
+	sc := mux.newStreamControl(w, recv, keepAlive)
+	defer sc.stop()
+
+	for n := 1; ; {
+		resp, err := sc.recv()
+		if err == io.EOF {
+			return
+		}
+		if err == errStreamKeepAlive {
+			if err := sc.write([]byte(": keep-alive\n\n")); err != nil {
+				grpclog.Infof("Failed to send keep-alive: %v", err)
+				if err == errStreamWriteTimeout {
+					handleForwardResponseEventStreamError(ctx, mux, marshaler, sc, err)
+				}
+				return
+			}
+			continue
+		}
+		if err == nil {
+			err = handleForwardResponseOptions(ctx, w, resp, opts)
+		}
+		if err != nil {
+			handleForwardResponseEventStreamError(ctx, mux, marshaler, sc, err)
+			return
+		}
+
+		buf, err := marshalStreamMessage(marshaler, resp)
+		if err != nil {
+			grpclog.Infof("Failed to marshal response chunk: %v", err)
+			handleForwardResponseEventStreamError(ctx, mux, marshaler, sc, err)
+			return
+		}
+
+		var id string
+		if mux.sseEventIDs {
+			id = strconv.Itoa(n)
+		}
+		n++
+		if err := sc.write(formatEvent("message", id, buf)); err != nil {
+			grpclog.Infof("Failed to send response chunk: %v", err)
+			if err == errStreamWriteTimeout {
+				handleForwardResponseEventStreamError(ctx, mux, marshaler, sc, err)
+			}
+			return
+		}
+	}
+}
+
+func handleForwardResponseEventStreamError(ctx context.Context, mux *ServeMux, marshaler Marshaler, sc *streamControl, err error) {
+	st := mux.streamErrorHandler(ctx, err)
+	buf, merr := marshaler.Marshal(st.Proto())
+	if merr != nil {
+		grpclog.Infof("Failed to marshal an error: %v", merr)
+		return
+	}
+	if werr := sc.writeError(formatEvent("error", "", buf)); werr != nil {
+		grpclog.Infof("Failed to notify error to client: %v", werr)
+	}
+}
+
+// formatEvent returns an event of type "event" and identifier "id", if not empty, with "data".
+// Each line of "data" is written as a "data:" field.
+func formatEvent(event, id string, data []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("event: " + event + "\n")
+	if id != "" {
+		buf.WriteString("id: " + id + "\n")
+	}
+	data = bytes.ReplaceAll(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), []byte("\r"), []byte("\n"))
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
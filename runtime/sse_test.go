@@ -0,0 +1,157 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestForwardResponseStreamServerSentEvents(t *testing.T) {
+	type msg struct {
+		pb  proto.Message
+		err error
+	}
+	for _, spec := range []struct {
+		name   string
+		opts   []runtime.ServeMuxOption
+		accept string
+		msgs   []msg
+		want   string
+	}{
+		{
+			name:   "messages",
+			accept: "text/event-stream",
+			msgs:   []msg{{pb: &pb.SimpleMessage{Id: "One"}}, {pb: &pb.SimpleMessage{Id: "Two"}}},
+			want: "event: message\ndata: {\"id\":\"One\"}\n\n" +
+				"event: message\ndata: {\"id\":\"Two\"}\n\n",
+		},
+		{
+			name:   "event ids",
+			opts:   []runtime.ServeMuxOption{runtime.WithServerSentEventIDs()},
+			accept: "text/html, text/event-stream;q=0.5",
+			msgs:   []msg{{pb: &pb.SimpleMessage{Id: "One"}}, {pb: &pb.SimpleMessage{Id: "Two"}}},
+			want: "event: message\nid: 1\ndata: {\"id\":\"One\"}\n\n" +
+				"event: message\nid: 2\ndata: {\"id\":\"Two\"}\n\n",
+		},
+		{
+			name:   "multi-line data",
+			accept: "text/event-stream",
+			msgs:   []msg{{pb: &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("a\nb\r\nc")}}},
+			want:   "event: message\ndata: a\ndata: b\ndata: c\n\n",
+		},
+		{
+			name:   "error",
+			accept: "text/event-stream",
+			msgs:   []msg{{pb: &pb.SimpleMessage{Id: "One"}}, {err: status.Error(codes.OutOfRange, "done")}},
+			want: "event: message\ndata: {\"id\":\"One\"}\n\n" +
+				"event: error\ndata: {\"code\":11,\"message\":\"done\"}\n\n",
+		},
+		{
+			name:   "error before the first message",
+			accept: "text/event-stream",
+			msgs:   []msg{{err: errors.New("failed")}},
+			want:   "event: error\ndata: {\"code\":2,\"message\":\"failed\"}\n\n",
+		},
+		{
+			name:   "wildcard",
+			accept: "*/*",
+			msgs:   []msg{{pb: &pb.SimpleMessage{Id: "One"}}},
+			want:   "{\"result\":{\"id\":\"One\"}}\n",
+		},
+		{
+			name:   "disabled",
+			opts:   []runtime.ServeMuxOption{runtime.WithDisableServerSentEvents()},
+			accept: "text/event-stream",
+			msgs:   []msg{{pb: &pb.SimpleMessage{Id: "One"}}},
+			want:   "{\"result\":{\"id\":\"One\"}}\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var count int
+			recv := func() (proto.Message, error) {
+				if count >= len(spec.msgs) {
+					return nil, io.EOF
+				}
+				m := spec.msgs[count]
+				count++
+				return m.pb, m.err
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", spec.accept)
+			resp := httptest.NewRecorder()
+			ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+			runtime.ForwardResponseStream(ctx, runtime.NewServeMux(spec.opts...), &runtime.JSONPb{}, resp, req, recv)
+
+			if got := compactEventData(resp.Body.String()); got != spec.want {
+				t.Errorf("resp.Body = %q; want %q", got, spec.want)
+			}
+			if got, want := resp.Code, http.StatusOK; got != want {
+				t.Errorf("resp.Code = %d; want %d", got, want)
+			}
+			if !strings.HasPrefix(spec.want, "event:") {
+				return
+			}
+			if got, want := resp.Header().Get("Content-Type"), "text/event-stream"; got != want {
+				t.Errorf(`resp.Header().Get("Content-Type") = %q; want %q`, got, want)
+			}
+			if got, want := resp.Header().Get("Cache-Control"), "no-cache"; got != want {
+				t.Errorf(`resp.Header().Get("Cache-Control") = %q; want %q`, got, want)
+			}
+		})
+	}
+}
+
+// compactEventData removes the insignificant spaces of the JSON data of "events",
+// which protojson randomly adds.
+func compactEventData(events string) string {
+	lines := strings.Split(events, "\n")
+	for i, line := range lines {
+		if data := strings.TrimPrefix(line, "data: "); data != line {
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, []byte(data)); err == nil {
+				lines[i] = "data: " + buf.String()
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestForwardResponseStreamServerSentEventsKeepAlive(t *testing.T) {
+	var sent bool
+	recv := func() (proto.Message, error) {
+		if sent {
+			return nil, io.EOF
+		}
+		sent = true
+		time.Sleep(50 * time.Millisecond)
+		return &pb.SimpleMessage{Id: "One"}, nil
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	resp := httptest.NewRecorder()
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	mux := runtime.NewServeMux(runtime.WithServerSentEventsKeepAlive(10 * time.Millisecond))
+	runtime.ForwardResponseStream(ctx, mux, &runtime.JSONPb{}, resp, req, recv)
+
+	got := compactEventData(resp.Body.String())
+	if !strings.HasPrefix(got, ": keep-alive\n\n") {
+		t.Errorf("resp.Body = %q; want to start with a keep-alive comment", got)
+	}
+	if want := "event: message\ndata: {\"id\":\"One\"}\n\n"; !strings.Contains(got, want) {
+		t.Errorf("resp.Body = %q; want to contain %q", got, want)
+	}
+}
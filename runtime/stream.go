@@ -0,0 +1,270 @@
+package runtime
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WithStreamKeepAlive returns a ServeMuxOption which makes ForwardResponseStream write a keep-alive
+// chunk after every "interval" without messages, so that the connections are not closed by proxies
+// and load balancers while the server streams are idle.
+//
+// The chunk is defined by the outbound marshaler if it implements StreamKeepAlive. Otherwise it is
+// the delimiter of the stream if it only consists of whitespace, e.g. the newline of the JSON
+// marshalers, and no keep-alive is written for the other marshalers. Server-Sent Events streams
+// write a ": keep-alive" comment, at the interval of WithServerSentEventsKeepAlive if given.
+func WithStreamKeepAlive(interval time.Duration) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.streamKeepAlive = interval
+	}
+}
+
+// WithStreamMaxDuration returns a ServeMuxOption which ends the streams forwarded by
+// ForwardResponseStream after "d", with a codes.DeadlineExceeded error chunk.
+//
+// Ending a stream returns from ForwardResponseStream, without waiting for its receive function,
+// and the generated handlers then cancel the gRPC stream.
+func WithStreamMaxDuration(d time.Duration) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.streamMaxDuration = d
+	}
+}
+
+// WithStreamWriteTimeout returns a ServeMuxOption which ends the streams forwarded by
+// ForwardResponseStream with a codes.DeadlineExceeded error chunk when a message cannot be written
+// within "timeout", e.g. because the client stopped reading the response. The generated handlers
+// then cancel the gRPC stream, rather than holding it open for as long as the client does.
+//
+// The timeout is set as the write deadline of the response, see http.ResponseController, and is
+// ignored if the http.ResponseWriter does not support write deadlines. The error chunk is given a
+// short grace period, the shortest of "timeout" and one second, before the connection is closed.
+// It only reaches the clients which read the response again within that period.
+func WithStreamWriteTimeout(timeout time.Duration) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.streamWriteTimeout = timeout
+	}
+}
+
+// errStreamKeepAlive is returned by streamControl.recv when a keep-alive chunk is due.
+var errStreamKeepAlive = errors.New("stream keep-alive")
+
+// errStreamWriteTimeout is returned by streamControl.write when the write timeout expired.
+var errStreamWriteTimeout = status.Error(codes.DeadlineExceeded, "the client did not read the stream within the write timeout")
+
+// streamWriteTimeoutGrace is the longest time given to write the error chunk of a stream once its
+// write timeout expired.
+const streamWriteTimeoutGrace = time.Second
+
+// streamControl applies the keep-alive interval, the maximum duration and the write timeout of
+// the ServeMux to a stream.
+type streamControl struct {
+	w            http.ResponseWriter
+	recvFunc     func() (proto.Message, error)
+	writeTimeout time.Duration
+
+	// results receives the results of recvFunc, called in its own goroutine if the stream has a
+	// keep-alive interval or a maximum duration, until done is closed.
+	results chan streamResult
+	done    chan struct{}
+
+	keepAlive      time.Duration
+	keepAliveTimer *time.Timer
+	maxDuration    time.Duration
+	maxTimer       *time.Timer
+
+	// ended is true once recvFunc returned an error, including io.EOF.
+	ended bool
+	// timedOut is true once the write timeout expired.
+	timedOut bool
+}
+
+type streamResult struct {
+	msg proto.Message
+	err error
+}
+
+// newStreamControl returns the streamControl of a stream written into "w", whose messages are
+// received by "recv". It writes keep-alive chunks after every "keepAlive", if positive.
+// It must be stopped once the stream ends.
+func (s *ServeMux) newStreamControl(w http.ResponseWriter, recv func() (proto.Message, error), keepAlive time.Duration) *streamControl {
+	c := &streamControl{
+		w:            w,
+		recvFunc:     recv,
+		writeTimeout: s.streamWriteTimeout,
+		keepAlive:    keepAlive,
+		maxDuration:  s.streamMaxDuration,
+	}
+	if c.keepAlive <= 0 && c.maxDuration <= 0 {
+		return c
+	}
+	if c.keepAlive > 0 {
+		c.keepAliveTimer = time.NewTimer(c.keepAlive)
+	}
+	if c.maxDuration > 0 {
+		c.maxTimer = time.NewTimer(c.maxDuration)
+	}
+	c.results = make(chan streamResult)
+	c.done = make(chan struct{})
+	go func() {
+		for {
+			msg, err := recv()
+			select {
+			case c.results <- streamResult{msg: msg, err: err}:
+			case <-c.done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// recv returns the next message of the stream, or errStreamKeepAlive if the stream was idle for
+// the keep-alive interval, or a codes.DeadlineExceeded error once the maximum duration elapsed.
+func (c *streamControl) recv() (proto.Message, error) {
+	if c.results == nil {
+		msg, err := c.recvFunc()
+		c.ended = err != nil
+		return msg, err
+	}
+	var keepAlive, maxDuration <-chan time.Time
+	if c.keepAliveTimer != nil {
+		keepAlive = c.keepAliveTimer.C
+	}
+	if c.maxTimer != nil {
+		maxDuration = c.maxTimer.C
+	}
+	select {
+	case r := <-c.results:
+		c.ended = r.err != nil
+		return r.msg, r.err
+	case <-keepAlive:
+		c.keepAliveTimer.Reset(c.keepAlive)
+		return nil, errStreamKeepAlive
+	case <-maxDuration:
+		return nil, status.Errorf(codes.DeadlineExceeded, "the stream exceeded its maximum duration of %v", c.maxDuration)
+	}
+}
+
+// write writes "chunks" and flushes them within the write timeout, if any.
+// It returns errStreamWriteTimeout if the write timeout expired.
+func (c *streamControl) write(chunks ...[]byte) error {
+	var deadline time.Time
+	if c.writeTimeout > 0 {
+		deadline = time.Now().Add(c.writeTimeout)
+		if !setWriteDeadline(c.w, deadline) {
+			deadline = time.Time{}
+		}
+	}
+	err := writeChunks(c.w, chunks)
+	if !deadline.IsZero() {
+		if err != nil && !time.Now().Before(deadline) {
+			c.timedOut = true
+			return errStreamWriteTimeout
+		}
+		setWriteDeadline(c.w, time.Time{})
+	}
+	if err != nil {
+		return err
+	}
+	if c.keepAliveTimer != nil {
+		if !c.keepAliveTimer.Stop() {
+			select {
+			case <-c.keepAliveTimer.C:
+			default:
+			}
+		}
+		c.keepAliveTimer.Reset(c.keepAlive)
+	}
+	return nil
+}
+
+// writeError writes "chunks", which end the stream with an error. Once the write timeout expired,
+// they are written within a grace period, see WithStreamWriteTimeout.
+func (c *streamControl) writeError(chunks ...[]byte) error {
+	if c.timedOut {
+		grace := c.writeTimeout
+		if grace > streamWriteTimeoutGrace {
+			grace = streamWriteTimeoutGrace
+		}
+		setWriteDeadline(c.w, time.Now().Add(grace))
+		defer setWriteDeadline(c.w, time.Time{})
+	}
+	return writeChunks(c.w, chunks)
+}
+
+func writeChunks(w http.ResponseWriter, chunks [][]byte) error {
+	for _, chunk := range chunks {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return flushError(w)
+}
+
+// stop stops the timers of the stream and the goroutine receiving its messages, once its
+// receive function returns.
+func (c *streamControl) stop() {
+	if c.keepAliveTimer != nil {
+		c.keepAliveTimer.Stop()
+	}
+	if c.maxTimer != nil {
+		c.maxTimer.Stop()
+	}
+	if c.done != nil {
+		close(c.done)
+	}
+}
+
+// streamKeepAliveChunk returns the keep-alive chunk of the streams written by "marshaler"
+// with "delimiter", nil if there is none.
+func streamKeepAliveChunk(marshaler Marshaler, delimiter []byte) []byte {
+	if ka, ok := marshaler.(StreamKeepAlive); ok {
+		return ka.KeepAliveChunk()
+	}
+	if len(delimiter) > 0 && len(bytes.TrimSpace(delimiter)) == 0 {
+		return delimiter
+	}
+	return nil
+}
+
+// flushError flushes "w" and returns the error of the flush, like http.ResponseController.Flush,
+// which is not available before Go 1.20.
+func flushError(w http.ResponseWriter) error {
+	for {
+		switch t := w.(type) {
+		case interface{ FlushError() error }:
+			return t.FlushError()
+		case http.Flusher:
+			t.Flush()
+			return nil
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return errors.New("flush not supported")
+		}
+	}
+}
+
+// setWriteDeadline sets the write deadline of "w", like http.ResponseController.SetWriteDeadline,
+// which is not available before Go 1.20. It reports whether "w" supports write deadlines.
+func setWriteDeadline(w http.ResponseWriter, deadline time.Time) bool {
+	for {
+		switch t := w.(type) {
+		case interface{ SetWriteDeadline(time.Time) error }:
+			return t.SetWriteDeadline(deadline) == nil
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
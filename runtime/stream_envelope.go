@@ -0,0 +1,118 @@
+package runtime
+
+import (
+	"google.golang.org/grpc/status"
+)
+
+// StreamEnvelope frames the chunks of the streams forwarded by ForwardResponseStream, see
+// WithStreamEnvelope. The chunks are marshaled by the outbound marshaler, and each one is
+// followed by the delimiter of the marshaler.
+type StreamEnvelope interface {
+	// Message returns the "n"th chunk of a stream, numbered from 0, holding "msg", the response
+	// message or its field selected by response_body.
+	Message(marshaler Marshaler, n int, msg interface{}) ([]byte, error)
+	// Error returns the "n"th chunk of a stream, holding the status "st" of an error.
+	Error(marshaler Marshaler, n int, st *status.Status) ([]byte, error)
+	// End returns the chunk ending a stream of "n" chunks, including its error if any,
+	// or nil if the stream simply ends.
+	End(marshaler Marshaler, n int) []byte
+}
+
+// WithStreamEnvelope returns a ServeMuxOption which frames the chunks of the streams forwarded by
+// ForwardResponseStream with "envelope". It defaults to ResultStreamEnvelope.
+//
+// The envelope does not apply to the streams written by LengthDelimited marshalers, which are
+// always framed by RawStreamEnvelope, nor to HttpBody messages, whose data is written as is.
+func WithStreamEnvelope(envelope StreamEnvelope) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.streamEnvelope = envelope
+	}
+}
+
+// ResultStreamEnvelope is the default StreamEnvelope, which wraps each message as
+// {"result": message} and an error as {"error": status}.
+type ResultStreamEnvelope struct{}
+
+// Message returns {"result": msg}.
+func (ResultStreamEnvelope) Message(marshaler Marshaler, _ int, msg interface{}) ([]byte, error) {
+	return marshaler.Marshal(map[string]interface{}{"result": msg})
+}
+
+// Error returns {"error": st}.
+func (ResultStreamEnvelope) Error(marshaler Marshaler, _ int, st *status.Status) ([]byte, error) {
+	return marshaler.Marshal(errorChunk(st))
+}
+
+// End returns nil.
+func (ResultStreamEnvelope) End(Marshaler, int) []byte {
+	return nil
+}
+
+// RawStreamEnvelope is a StreamEnvelope which writes each message and error as is, e.g. as
+// newline delimited JSON with the JSON marshalers. An error is written as its google.rpc.Status.
+type RawStreamEnvelope struct{}
+
+// Message returns msg.
+func (RawStreamEnvelope) Message(marshaler Marshaler, _ int, msg interface{}) ([]byte, error) {
+	return marshaler.Marshal(msg)
+}
+
+// Error returns st as a google.rpc.Status.
+func (RawStreamEnvelope) Error(marshaler Marshaler, _ int, st *status.Status) ([]byte, error) {
+	return marshaler.Marshal(st.Proto())
+}
+
+// End returns nil.
+func (RawStreamEnvelope) End(Marshaler, int) []byte {
+	return nil
+}
+
+// ArrayStreamEnvelope is a StreamEnvelope which writes the stream as a JSON array of its messages,
+// so that the clients which do not read streams can parse the whole response. An error is written
+// as a last {"error": status} element. It should only be used with JSON marshalers.
+type ArrayStreamEnvelope struct{}
+
+// Message returns msg, preceded by "[" for the first chunk and by "," otherwise.
+func (ArrayStreamEnvelope) Message(marshaler Marshaler, n int, msg interface{}) ([]byte, error) {
+	buf, err := marshaler.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return arrayElement(n, buf), nil
+}
+
+// Error returns {"error": st}, preceded by "[" for the first chunk and by "," otherwise.
+func (ArrayStreamEnvelope) Error(marshaler Marshaler, n int, st *status.Status) ([]byte, error) {
+	buf, err := marshaler.Marshal(errorChunk(st))
+	if err != nil {
+		return nil, err
+	}
+	return arrayElement(n, buf), nil
+}
+
+// End returns "]", or "[]" for an empty stream.
+func (ArrayStreamEnvelope) End(_ Marshaler, n int) []byte {
+	if n == 0 {
+		return []byte("[]")
+	}
+	return []byte("]")
+}
+
+func arrayElement(n int, buf []byte) []byte {
+	sep := byte(',')
+	if n == 0 {
+		sep = '['
+	}
+	return append([]byte{sep}, buf...)
+}
+
+// streamEnvelopeFor returns the StreamEnvelope of the streams written by "marshaler".
+func (s *ServeMux) streamEnvelopeFor(marshaler Marshaler) StreamEnvelope {
+	if _, ok := marshaler.(LengthDelimited); ok {
+		return RawStreamEnvelope{}
+	}
+	if s.streamEnvelope != nil {
+		return s.streamEnvelope
+	}
+	return ResultStreamEnvelope{}
+}
@@ -0,0 +1,162 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestForwardResponseStreamEnvelope(t *testing.T) {
+	type msg struct {
+		pb  proto.Message
+		err error
+	}
+	one := msg{pb: &pb.SimpleMessage{Id: "One"}}
+	two := msg{pb: &pb.SimpleMessage{Id: "Two"}}
+	fail := msg{err: status.Error(codes.OutOfRange, "done")}
+	for _, spec := range []struct {
+		name       string
+		envelope   runtime.StreamEnvelope
+		msgs       []msg
+		wantStatus int
+		want       string
+	}{
+		{
+			name:       "default",
+			msgs:       []msg{one, two, fail},
+			wantStatus: http.StatusOK,
+			want:       `{"result":{"id":"One"}}` + "\n" + `{"result":{"id":"Two"}}` + "\n" + `{"error":{"code":11,"message":"done"}}` + "\n",
+		},
+		{
+			name:       "result",
+			envelope:   runtime.ResultStreamEnvelope{},
+			msgs:       []msg{one},
+			wantStatus: http.StatusOK,
+			want:       `{"result":{"id":"One"}}` + "\n",
+		},
+		{
+			name:       "raw",
+			envelope:   runtime.RawStreamEnvelope{},
+			msgs:       []msg{one, two, fail},
+			wantStatus: http.StatusOK,
+			want:       `{"id":"One"}` + "\n" + `{"id":"Two"}` + "\n" + `{"code":11,"message":"done"}` + "\n",
+		},
+		{
+			name:       "array",
+			envelope:   runtime.ArrayStreamEnvelope{},
+			msgs:       []msg{one, two},
+			wantStatus: http.StatusOK,
+			want:       `[{"id":"One"}` + "\n" + `,{"id":"Two"}` + "\n" + "]\n",
+		},
+		{
+			name:       "array with an error",
+			envelope:   runtime.ArrayStreamEnvelope{},
+			msgs:       []msg{one, fail},
+			wantStatus: http.StatusOK,
+			want:       `[{"id":"One"}` + "\n" + `,{"error":{"code":11,"message":"done"}}` + "\n" + "]\n",
+		},
+		{
+			name:       "array with an error only",
+			envelope:   runtime.ArrayStreamEnvelope{},
+			msgs:       []msg{fail},
+			wantStatus: http.StatusBadRequest,
+			want:       `[{"error":{"code":11,"message":"done"}}` + "\n" + "]\n",
+		},
+		{
+			name:       "empty array",
+			envelope:   runtime.ArrayStreamEnvelope{},
+			wantStatus: http.StatusOK,
+			want:       "[]\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var count int
+			recv := func() (proto.Message, error) {
+				if count >= len(spec.msgs) {
+					return nil, io.EOF
+				}
+				m := spec.msgs[count]
+				count++
+				return m.pb, m.err
+			}
+			var opts []runtime.ServeMuxOption
+			if spec.envelope != nil {
+				opts = append(opts, runtime.WithStreamEnvelope(spec.envelope))
+			}
+			mux := runtime.NewServeMux(opts...)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			resp := httptest.NewRecorder()
+			ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+			runtime.ForwardResponseStream(ctx, mux, &runtime.JSONPb{}, resp, req, recv)
+
+			if resp.Code != spec.wantStatus {
+				t.Errorf("resp.Code = %d; want %d", resp.Code, spec.wantStatus)
+			}
+			if got := compactJSONLines(t, resp.Body.String()); got != spec.want {
+				t.Errorf("resp.Body = %q; want %q", got, spec.want)
+			}
+			if _, ok := spec.envelope.(runtime.ArrayStreamEnvelope); ok {
+				var elems []json.RawMessage
+				if err := json.Unmarshal(resp.Body.Bytes(), &elems); err != nil {
+					t.Errorf("json.Unmarshal(%q) failed with %v; want success", resp.Body.String(), err)
+				}
+			}
+		})
+	}
+}
+
+func TestForwardResponseStreamEnvelopeLengthDelimited(t *testing.T) {
+	var sent bool
+	recv := func() (proto.Message, error) {
+		if sent {
+			return nil, io.EOF
+		}
+		sent = true
+		return &pb.SimpleMessage{Id: "One"}, nil
+	}
+	mux := runtime.NewServeMux(runtime.WithStreamEnvelope(runtime.ArrayStreamEnvelope{}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp := httptest.NewRecorder()
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	marshaler := &runtime.ProtoDelimitedMarshaller{}
+	runtime.ForwardResponseStream(ctx, mux, marshaler, resp, req, recv)
+
+	got := new(pb.SimpleMessage)
+	if err := marshaler.NewDecoder(resp.Body).Decode(got); err != nil {
+		t.Fatalf("Decode failed with %v; want success", err)
+	}
+	if want := (&pb.SimpleMessage{Id: "One"}); !proto.Equal(got, want) {
+		t.Errorf("message = %v; want %v", got, want)
+	}
+	if resp.Body.Len() != 0 {
+		t.Errorf("resp.Body has %d trailing bytes; want none", resp.Body.Len())
+	}
+}
+
+// compactJSONLines compacts each line of "s" which is JSON, leaving the other lines as is.
+func compactJSONLines(t *testing.T, s string) string {
+	t.Helper()
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		var prefix string
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, ",") {
+			prefix, line = line[:1], line[1:]
+		}
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(line)); err == nil {
+			lines[i] = prefix + buf.String()
+		}
+	}
+	return strings.Join(lines, "\n")
+}
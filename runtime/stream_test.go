@@ -0,0 +1,254 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/proto"
+)
+
+// keepAliveMarshaler is a JSONPb marshaler with a custom keep-alive chunk.
+type keepAliveMarshaler struct {
+	runtime.JSONPb
+}
+
+func (*keepAliveMarshaler) KeepAliveChunk() []byte {
+	return []byte("{}\n")
+}
+
+func TestForwardResponseStreamKeepAlive(t *testing.T) {
+	for _, spec := range []struct {
+		name      string
+		marshaler runtime.Marshaler
+		accept    string
+		wantStart string
+	}{
+		{
+			name:      "newline",
+			marshaler: &runtime.JSONPb{},
+			wantStart: "\n",
+		},
+		{
+			name:      "custom chunk",
+			marshaler: &keepAliveMarshaler{},
+			wantStart: "{}\n",
+		},
+		{
+			name:      "no keep-alive",
+			marshaler: &runtime.ProtoDelimitedMarshaller{},
+		},
+		{
+			name:      "server-sent events",
+			marshaler: &runtime.JSONPb{},
+			accept:    "text/event-stream",
+			wantStart: ": keep-alive\n\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var sent bool
+			recv := func() (proto.Message, error) {
+				if sent {
+					return nil, io.EOF
+				}
+				sent = true
+				time.Sleep(50 * time.Millisecond)
+				return &pb.SimpleMessage{Id: "One"}, nil
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if spec.accept != "" {
+				req.Header.Set("Accept", spec.accept)
+			}
+			resp := httptest.NewRecorder()
+			ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+			mux := runtime.NewServeMux(runtime.WithStreamKeepAlive(10 * time.Millisecond))
+			runtime.ForwardResponseStream(ctx, mux, spec.marshaler, resp, req, recv)
+
+			got := resp.Body.String()
+			if spec.wantStart == "" {
+				if buf, _ := spec.marshaler.Marshal(&pb.SimpleMessage{Id: "One"}); !strings.HasSuffix(got, string(buf)) || len(got) != len(buf)+1 {
+					t.Errorf("resp.Body = %q; want the message only", got)
+				}
+				return
+			}
+			if !strings.HasPrefix(got, spec.wantStart) {
+				t.Errorf("resp.Body = %q; want to start with %q", got, spec.wantStart)
+			}
+			if !strings.Contains(got, "One") {
+				t.Errorf("resp.Body = %q; want to contain the message", got)
+			}
+		})
+	}
+}
+
+func TestForwardResponseStreamMaxDuration(t *testing.T) {
+	const errChunk = `{"error":{"code":4,"message":"the stream exceeded its maximum duration of 20ms"}}`
+	for _, spec := range []struct {
+		name       string
+		sent       int
+		wantStatus int
+		wantChunks []string
+	}{
+		{
+			name:       "no message",
+			wantStatus: http.StatusGatewayTimeout,
+			wantChunks: []string{errChunk},
+		},
+		{
+			name:       "after a message",
+			sent:       1,
+			wantStatus: http.StatusOK,
+			wantChunks: []string{`{"result":{"id":"One"}}`, errChunk},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var count int
+			recv := func() (proto.Message, error) {
+				if count < spec.sent {
+					count++
+					return &pb.SimpleMessage{Id: "One"}, nil
+				}
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			resp := httptest.NewRecorder()
+			mux := runtime.NewServeMux(runtime.WithStreamMaxDuration(20 * time.Millisecond))
+			runtime.ForwardResponseStream(runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{}), mux, &runtime.JSONPb{}, resp, req, recv)
+
+			if resp.Code != spec.wantStatus {
+				t.Errorf("resp.Code = %d; want %d", resp.Code, spec.wantStatus)
+			}
+			body := resp.Body.String()
+			if !strings.HasSuffix(body, "\n") {
+				t.Fatalf("resp.Body = %q; want chunks ending with a newline", body)
+			}
+			chunks := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+			if len(chunks) != len(spec.wantChunks) {
+				t.Fatalf("resp.Body = %q; want %d chunks", body, len(spec.wantChunks))
+			}
+			for i, chunk := range chunks {
+				// protojson does not guarantee a stable whitespace.
+				var buf bytes.Buffer
+				if err := json.Compact(&buf, []byte(chunk)); err != nil {
+					t.Fatalf("json.Compact(%q) failed with %v; want success", chunk, err)
+				}
+				if got := buf.String(); got != spec.wantChunks[i] {
+					t.Errorf("chunk %d = %q; want %q", i, got, spec.wantChunks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestForwardResponseStreamWriteTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+	mux := runtime.NewServeMux(runtime.WithStreamWriteTimeout(50 * time.Millisecond))
+	if err := mux.HandlePath(http.MethodGet, "/stream", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		go func() {
+			<-ctx.Done()
+			close(canceled)
+		}()
+		msg := &pb.SimpleMessage{Id: strings.Repeat("x", 64<<10)}
+		recv := func() (proto.Message, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return msg, nil
+		}
+		runtime.ForwardResponseStream(runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{}), mux, &runtime.JSONPb{}, w, r, recv)
+	}); err != nil {
+		t.Fatalf("mux.HandlePath failed with %v; want success", err)
+	}
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/stream")
+	if err != nil {
+		t.Fatalf("http.Get failed with %v; want success", err)
+	}
+	defer resp.Body.Close()
+
+	// The client does not read the response, so the stream must be canceled.
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Errorf("the stream was not canceled when the client stopped reading")
+	}
+}
+
+// stallingResponseWriter is a ResponseRecorder supporting write deadlines, whose first write blocks
+// until the write deadline, like a client which stopped reading the response for a while. The
+// writes fail once the deadline expired.
+type stallingResponseWriter struct {
+	*httptest.ResponseRecorder
+	deadline time.Time
+	stalled  bool
+}
+
+func (w *stallingResponseWriter) SetWriteDeadline(deadline time.Time) error {
+	w.deadline = deadline
+	return nil
+}
+
+func (w *stallingResponseWriter) Write(b []byte) (int, error) {
+	if !w.deadline.IsZero() && !time.Now().Before(w.deadline) {
+		return 0, context.DeadlineExceeded
+	}
+	if !w.stalled && !w.deadline.IsZero() {
+		w.stalled = true
+		time.Sleep(time.Until(w.deadline))
+		return 0, context.DeadlineExceeded
+	}
+	return w.ResponseRecorder.Write(b)
+}
+
+func TestForwardResponseStreamWriteTimeoutErrorChunk(t *testing.T) {
+	for _, spec := range []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{
+			name: "JSON stream",
+			want: `{"error":{"code":4,"message":"the client did not read the stream within the write timeout"}}` + "\n",
+		},
+		{
+			name:   "Server-Sent Events",
+			accept: "text/event-stream",
+			want:   "event: error\ndata: {\"code\":4,\"message\":\"the client did not read the stream within the write timeout\"}\n\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(runtime.WithStreamWriteTimeout(20 * time.Millisecond))
+			req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+			if spec.accept != "" {
+				req.Header.Set("Accept", spec.accept)
+			}
+			w := &stallingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+			recv := func() (proto.Message, error) {
+				return &pb.SimpleMessage{Id: "One"}, nil
+			}
+			runtime.ForwardResponseStream(runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{}), mux, &runtime.JSONPb{}, w, req, recv)
+
+			// protojson does not guarantee a stable whitespace.
+			got := strings.ReplaceAll(w.Body.String(), " ", "")
+			want := strings.ReplaceAll(spec.want, " ", "")
+			if got != want {
+				t.Errorf("w.Body = %q; want %q", w.Body.String(), spec.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,630 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WebSocketOptions configures the WebSocket transport of a ServeMux, see WithWebSocket.
+type WebSocketOptions struct {
+	// CheckOrigin reports whether the WebSocket handshake request "r" may be accepted.
+	// If nil, the requests without an Origin header, the requests whose Origin host is
+	// the requested host and, if WithCORS is used, the requests from the allowed origins
+	// are accepted.
+	CheckOrigin func(r *http.Request) bool
+	// MaxMessageSize is the maximum size of an inbound message, 4 MiB if zero.
+	MaxMessageSize int64
+}
+
+// WithWebSocket returns a ServeMuxOption which enables the WebSocket transport (RFC 6455) for
+// the handlers registered with an RPC method name, such as the generated ones, so that browsers
+// can call client and bidirectional streaming methods interactively. See WithRPCMethodName.
+//
+// The WebSocket handshake is a GET request to the path of the method, whatever the HTTP method
+// of its pattern. Its headers are turned into gRPC metadata like those of other requests. Each
+// inbound message is decoded by the inbound marshaler and sent on the gRPC stream, and an empty
+// message ends the client stream. Each response message, or the only response of the unary and
+// client streaming methods, is sent as a message, without the {"result": ...} wrapper, text
+// messages being used for textual content types. The connection
+// is closed with the status of the call: code 1000 if it succeeded, 4000 plus the gRPC code
+// otherwise, with the status message as reason. Closing the connection cancels the call.
+//
+// The handshake response is written when the handler reads the first message of the client, which
+// cannot send it before, or writes its first message. The header metadata received by then are
+// part of it, as headers mapped by the outgoing header matcher. The header metadata received
+// later and the trailer metadata, with MetadataTrailerPrefix, are sent in a text message right
+// before the Close frame, formatted as an HTTP header block. It is only sent if there are some.
+func WithWebSocket(opts WebSocketOptions) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.webSocket = &opts
+	}
+}
+
+const (
+	// webSocketGUID is concatenated to the Sec-WebSocket-Key of a handshake, RFC 6455 section 1.3.
+	webSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	// defaultMaxWebSocketMessageSize is the default maximum size of an inbound message.
+	defaultMaxWebSocketMessageSize = 4 << 20
+	// webSocketCloseTimeout is how long the closing handshake waits for the Close frame of the client.
+	webSocketCloseTimeout = 5 * time.Second
+)
+
+// WebSocket opcodes, RFC 6455 section 5.2.
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xa
+)
+
+// WebSocket status codes, RFC 6455 section 7.4.
+const (
+	wsCloseNormal          = 1000
+	wsCloseProtocolError   = 1002
+	wsCloseInvalidPayload  = 1007
+	wsCloseMessageTooBig   = 1009
+	wsCloseGRPCStatusStart = 4000
+)
+
+// maxWebSocketCloseReason is the maximum size of the reason of a Close frame.
+const maxWebSocketCloseReason = 123
+
+var errWebSocketClosed = errors.New("websocket: close frame already sent")
+
+// webSocketCloseError is a protocol error closing a WebSocket connection.
+type webSocketCloseError struct {
+	code   int
+	reason string
+}
+
+func (e *webSocketCloseError) Error() string {
+	return fmt.Sprintf("websocket: %s (%d)", e.reason, e.code)
+}
+
+// isWebSocketUpgrade reports whether "r" is a WebSocket handshake request.
+func isWebSocketUpgrade(r *http.Request) bool {
+	return r.Method == http.MethodGet && headerHasToken(r.Header, "Connection", "upgrade") && headerHasToken(r.Header, "Upgrade", "websocket")
+}
+
+func headerHasToken(h http.Header, key, token string) bool {
+	for _, v := range h.Values(key) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkOrigin reports whether the Origin of the handshake request "r" is allowed by the default policy.
+func (s *ServeMux) checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return s.cors != nil && s.cors.allowsOrigin(origin)
+}
+
+// serveWebSocket completes the WebSocket handshake "r" and serves the connection with "h".
+func (s *ServeMux) serveWebSocket(w http.ResponseWriter, r *http.Request, h *handler, pathParams map[string]string) {
+	ctx := r.Context()
+	inboundMarshaler, outboundMarshaler := MarshalerForRequest(s, r)
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		s.errorHandler(ctx, s, outboundMarshaler, w, r, &HTTPStatusError{
+			HTTPStatus: http.StatusUpgradeRequired,
+			Err:        status.Error(codes.FailedPrecondition, "unsupported WebSocket version"),
+		})
+		return
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if k, err := base64.StdEncoding.DecodeString(key); err != nil || len(k) != 16 {
+		s.errorHandler(ctx, s, outboundMarshaler, w, r, &HTTPStatusError{
+			HTTPStatus: http.StatusBadRequest,
+			Err:        status.Error(codes.InvalidArgument, "invalid Sec-WebSocket-Key"),
+		})
+		return
+	}
+	checkOrigin := s.webSocket.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = s.checkWebSocketOrigin
+	}
+	if !checkOrigin(r) {
+		s.errorHandler(ctx, s, outboundMarshaler, w, r, &HTTPStatusError{
+			HTTPStatus: http.StatusForbidden,
+			Err:        status.Error(codes.PermissionDenied, "WebSocket origin not allowed"),
+		})
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		grpclog.Infof("WebSocket not supported by %T", w)
+		s.errorHandler(ctx, s, outboundMarshaler, w, r, status.Error(codes.Internal, "unexpected type of web server"))
+		return
+	}
+	netConn, brw, err := hj.Hijack()
+	if err != nil {
+		grpclog.Infof("Failed to hijack the connection: %v", err)
+		return
+	}
+	defer netConn.Close()
+
+	maxMessageSize := s.webSocket.MaxMessageSize
+	if maxMessageSize <= 0 {
+		maxMessageSize = defaultMaxWebSocketMessageSize
+	}
+	accept := sha1.Sum([]byte(key + webSocketGUID))
+	conn := &webSocketConn{
+		conn:           netConn,
+		br:             brw.Reader,
+		bw:             brw.Writer,
+		maxMessageSize: maxMessageSize,
+		accept:         base64.StdEncoding.EncodeToString(accept[:]),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The messages are read from the connection while the responses are written.
+	ctx = withFullDuplex(ctx, true)
+	pr, pw := io.Pipe()
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		defer cancel()
+		conn.readMessages(pw, inboundMarshaler)
+	}()
+
+	req := r.Clone(ctx)
+	req.Method = h.meth
+	req.Body = &webSocketRequestBody{pr: pr, conn: conn}
+	req.ContentLength = -1
+	ww := &webSocketResponseWriter{conn: conn, header: make(http.Header), metadata: make(http.Header)}
+	h.h(ww, req, pathParams)
+
+	pr.Close()
+	if err := conn.writeHandshake(nil); err != nil {
+		grpclog.Infof("Failed to write the WebSocket handshake: %v", err)
+		return
+	}
+	if len(ww.metadata) > 0 {
+		var buf bytes.Buffer
+		if err := ww.metadata.Write(&buf); err != nil {
+			grpclog.Infof("Failed to format the WebSocket metadata: %v", err)
+		} else if err := conn.writeFrame(wsText, buf.Bytes()); err != nil && err != errWebSocketClosed {
+			grpclog.Infof("Failed to send the WebSocket metadata: %v", err)
+		}
+	}
+	code, reason := ww.closeStatus()
+	if err := conn.writeClose(code, reason); err != nil && err != errWebSocketClosed {
+		grpclog.Infof("Failed to close the WebSocket connection: %v", err)
+	}
+	if err := netConn.SetReadDeadline(time.Now().Add(webSocketCloseTimeout)); err != nil {
+		grpclog.Infof("Failed to set the WebSocket read deadline: %v", err)
+	}
+	<-readerDone
+}
+
+// webSocketConn is the server side of a WebSocket connection.
+type webSocketConn struct {
+	conn           net.Conn
+	br             *bufio.Reader
+	maxMessageSize int64
+	// accept is the Sec-WebSocket-Accept header of the handshake response.
+	accept string
+
+	// mu guards bw, handshakeSent and closeSent.
+	mu            sync.Mutex
+	bw            *bufio.Writer
+	handshakeSent bool
+	closeSent     bool
+}
+
+// writeHandshake writes the handshake response with the headers "h", unless it was already written.
+func (c *webSocketConn) writeHandshake(h http.Header) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.writeHandshakeLocked(h)
+	return err
+}
+
+// writeHandshakeLocked is writeHandshake with "mu" held. It reports whether it wrote the response.
+func (c *webSocketConn) writeHandshakeLocked(h http.Header) (bool, error) {
+	if c.handshakeSent {
+		return false, nil
+	}
+	c.handshakeSent = true
+	fmt.Fprintf(c.bw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n", c.accept)
+	if err := h.Write(c.bw); err != nil {
+		return true, err
+	}
+	if _, err := c.bw.WriteString("\r\n"); err != nil {
+		return true, err
+	}
+	return true, c.bw.Flush()
+}
+
+// readMessages writes the messages read from the connection into "pw", framed for "marshaler",
+// until the connection is closed. An empty message closes "pw".
+func (c *webSocketConn) readMessages(pw *io.PipeWriter, marshaler Marshaler) {
+	var inputClosed bool
+	for {
+		msg, err := c.readMessage()
+		if err != nil {
+			var closeErr *webSocketCloseError
+			if errors.As(err, &closeErr) {
+				if werr := c.writeClose(closeErr.code, closeErr.reason); werr != nil && werr != errWebSocketClosed {
+					grpclog.Infof("Failed to close the WebSocket connection: %v", werr)
+				}
+			}
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			pw.CloseWithError(err)
+			return
+		}
+		if inputClosed {
+			continue
+		}
+		if len(msg) == 0 {
+			inputClosed = true
+			pw.Close()
+			continue
+		}
+		msg = frameRecord(marshaler, msg)
+		if d, ok := marshaler.(Delimited); ok {
+			msg = append(msg, d.Delimiter()...)
+		}
+		if _, err := pw.Write(msg); err != nil {
+			// The handler does not read the request body anymore.
+			inputClosed = true
+		}
+	}
+}
+
+// readMessage reads the next data message, handling the control frames read before it.
+// It returns a *webSocketCloseError on protocol errors and when a Close frame is received.
+func (c *webSocketConn) readMessage() ([]byte, error) {
+	var (
+		msg    []byte
+		opcode byte
+	)
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case wsPing:
+			if err := c.writeFrame(wsPong, payload); err != nil && err != errWebSocketClosed {
+				return nil, err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			code := wsCloseNormal
+			if len(payload) >= 2 {
+				code = int(payload[0])<<8 | int(payload[1])
+			}
+			return nil, &webSocketCloseError{code: code, reason: "closed by the client"}
+		case wsContinuation:
+			if opcode == 0 {
+				return nil, &webSocketCloseError{code: wsCloseProtocolError, reason: "unexpected continuation frame"}
+			}
+		case wsText, wsBinary:
+			if opcode != 0 {
+				return nil, &webSocketCloseError{code: wsCloseProtocolError, reason: "unfinished fragmented message"}
+			}
+			opcode = op
+		default:
+			return nil, &webSocketCloseError{code: wsCloseProtocolError, reason: "unknown opcode"}
+		}
+		if int64(len(msg)+len(payload)) > c.maxMessageSize {
+			return nil, &webSocketCloseError{code: wsCloseMessageTooBig, reason: "message too big"}
+		}
+		msg = append(msg, payload...)
+		if !fin {
+			continue
+		}
+		if opcode == wsText && !utf8.Valid(msg) {
+			return nil, &webSocketCloseError{code: wsCloseInvalidPayload, reason: "invalid UTF-8 text"}
+		}
+		return msg, nil
+	}
+}
+
+// readFrame reads a frame of the client, RFC 6455 section 5.2.
+func (c *webSocketConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.br, hdr[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, opcode = hdr[0]&0x80 != 0, hdr[0]&0x0f
+	if hdr[0]&0x70 != 0 {
+		return false, 0, nil, &webSocketCloseError{code: wsCloseProtocolError, reason: "unexpected reserved bits"}
+	}
+	if hdr[1]&0x80 == 0 {
+		return false, 0, nil, &webSocketCloseError{code: wsCloseProtocolError, reason: "unmasked client frame"}
+	}
+	size := uint64(hdr[1] & 0x7f)
+	switch size {
+	case 126:
+		size, err = readBigEndian(c.br, 2)
+	case 127:
+		size, err = readBigEndian(c.br, 8)
+	}
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if opcode >= wsClose && (size > 125 || !fin) {
+		return false, 0, nil, &webSocketCloseError{code: wsCloseProtocolError, reason: "invalid control frame"}
+	}
+	if size > uint64(c.maxMessageSize) {
+		return false, 0, nil, &webSocketCloseError{code: wsCloseMessageTooBig, reason: "message too big"}
+	}
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, unexpectedEOF(err)
+	}
+	payload = make([]byte, size)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, unexpectedEOF(err)
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// writeFrame writes an unfragmented frame. Nothing can be written after a Close frame.
+func (c *webSocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closeSent {
+		return errWebSocketClosed
+	}
+	if _, err := c.writeHandshakeLocked(nil); err != nil {
+		return err
+	}
+	if opcode == wsClose {
+		c.closeSent = true
+	}
+	hdr := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		hdr = append(hdr, byte(n))
+	case n <= 0xffff:
+		hdr = appendBigEndian(append(hdr, 126), uint64(n), 2)
+	default:
+		hdr = appendBigEndian(append(hdr, 127), uint64(n), 8)
+	}
+	if _, err := c.bw.Write(hdr); err != nil {
+		return err
+	}
+	if _, err := c.bw.Write(payload); err != nil {
+		return err
+	}
+	return c.bw.Flush()
+}
+
+// writeClose writes a Close frame with "code" and "reason", truncated to fit the frame.
+func (c *webSocketConn) writeClose(code int, reason string) error {
+	if len(reason) > maxWebSocketCloseReason {
+		reason = reason[:maxWebSocketCloseReason]
+		for !utf8.ValidString(reason) {
+			reason = reason[:len(reason)-1]
+		}
+	}
+	return c.writeFrame(wsClose, append([]byte{byte(code >> 8), byte(code)}, reason...))
+}
+
+// webSocketRequestBody is the body of the requests of WebSocket connections. The client cannot send
+// messages before the handshake response, so it is written when the handler first reads the body.
+type webSocketRequestBody struct {
+	pr   *io.PipeReader
+	conn *webSocketConn
+}
+
+func (b *webSocketRequestBody) Read(p []byte) (int, error) {
+	if err := b.conn.writeHandshake(nil); err != nil {
+		return 0, err
+	}
+	return b.pr.Read(p)
+}
+
+func (b *webSocketRequestBody) Close() error {
+	return b.pr.Close()
+}
+
+// webSocketResponseWriter is the http.ResponseWriter given to the handlers of WebSocket connections.
+// Each Write sends a message. The headers set by the handler only define the type of the messages:
+// the header metadata are sent in the handshake response, see setHeaderMetadata.
+type webSocketResponseWriter struct {
+	conn   *webSocketConn
+	header http.Header
+	status *status.Status
+	// metadata holds the header metadata received after the handshake response and the
+	// trailer metadata, sent in a text message before the Close frame.
+	metadata http.Header
+}
+
+func (w *webSocketResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader writes the handshake response, without any header metadata if setHeaderMetadata
+// was not called before.
+func (w *webSocketResponseWriter) WriteHeader(int) {
+	if err := w.conn.writeHandshake(nil); err != nil {
+		grpclog.Infof("Failed to write the WebSocket handshake: %v", err)
+	}
+}
+
+func (w *webSocketResponseWriter) Write(b []byte) (int, error) {
+	opcode := byte(wsBinary)
+	if isTextualContentType(w.header.Get("Content-Type")) && utf8.Valid(b) {
+		opcode = wsText
+	}
+	if err := w.conn.writeFrame(opcode, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *webSocketResponseWriter) Flush() {
+	w.WriteHeader(http.StatusSwitchingProtocols)
+}
+
+// setHeaderMetadata sends the header metadata of "md" allowed by the outgoing header matcher of
+// "mux" in the handshake response, or in the metadata message if the response was already written.
+func (w *webSocketResponseWriter) setHeaderMetadata(mux *ServeMux, md ServerMetadata) {
+	h := make(http.Header)
+	for k, vs := range md.HeaderMD {
+		if key, ok := mux.outgoingHeaderMatcher(k); ok {
+			for _, v := range vs {
+				h.Add(key, v)
+			}
+		}
+	}
+	w.conn.mu.Lock()
+	sent, err := w.conn.writeHandshakeLocked(h)
+	w.conn.mu.Unlock()
+	if err != nil {
+		grpclog.Infof("Failed to write the WebSocket handshake: %v", err)
+	}
+	if sent {
+		return
+	}
+	for k, vs := range h {
+		w.metadata[k] = append(w.metadata[k], vs...)
+	}
+}
+
+// setTrailerMetadata adds the trailer metadata of "md" and of the stream of "ctx" to the metadata message.
+func (w *webSocketResponseWriter) setTrailerMetadata(ctx context.Context, md ServerMetadata) {
+	trailer := md.TrailerMD
+	if f, ok := streamTrailerFromContext(ctx); ok {
+		trailer = metadata.Join(trailer, f())
+	}
+	for k, vs := range trailer {
+		for _, v := range vs {
+			w.metadata.Add(MetadataTrailerPrefix+k, v)
+		}
+	}
+}
+
+// setError records the status of "err" to be sent when closing the connection.
+func (w *webSocketResponseWriter) setError(err error) {
+	var customStatus *HTTPStatusError
+	if errors.As(err, &customStatus) {
+		err = customStatus.Err
+	}
+	w.status = status.Convert(err)
+}
+
+// closeStatus returns the code and the reason of the Close frame ending the call.
+func (w *webSocketResponseWriter) closeStatus() (int, string) {
+	if w.status == nil || w.status.Code() == codes.OK {
+		return wsCloseNormal, ""
+	}
+	return wsCloseGRPCStatusStart + int(w.status.Code()), w.status.Message()
+}
+
+// isTextualContentType reports whether "contentType" is a text or JSON media type.
+func isTextualContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// forwardResponseWebSocket forwards the stream from gRPC server to a WebSocket client, each message
+// being sent as a WebSocket message. An error is sent in the Close frame of the connection.
+func forwardResponseWebSocket(ctx context.Context, mux *ServeMux, marshaler Marshaler, w *webSocketResponseWriter, recv func() (proto.Message, error), opts []func(context.Context, http.ResponseWriter, proto.Message) error) {
+	md, ok := ServerMetadataFromContext(ctx)
+	if !ok {
+		grpclog.Infof("Failed to extract ServerMetadata from context")
+		w.setError(status.Error(codes.Internal, "unexpected error"))
+		return
+	}
+	w.setHeaderMetadata(mux, md)
+	defer w.setTrailerMetadata(ctx, md)
+
+	if err := handleForwardResponseOptions(ctx, w, nil, opts); err != nil {
+		w.setError(err)
+		return
+	}
+	for {
+		resp, err := recv()
+		if err == io.EOF {
+			return
+		}
+		if err == nil {
+			err = handleForwardResponseOptions(ctx, w, resp, opts)
+		}
+		if err != nil {
+			w.status = mux.streamErrorHandler(ctx, err)
+			return
+		}
+		buf, err := marshalStreamMessage(marshaler, resp)
+		if err != nil {
+			grpclog.Infof("Failed to marshal response chunk: %v", err)
+			w.status = mux.streamErrorHandler(ctx, err)
+			return
+		}
+		w.Header().Set("Content-Type", marshaler.ContentType(resp))
+		if _, err := w.Write(buf); err != nil {
+			grpclog.Infof("Failed to send response chunk: %v", err)
+			return
+		}
+	}
+}
+
+// forwardResponseMessageWebSocket sends the response of a unary or client streaming call to a
+// WebSocket client as a single message, with its metadata like forwardResponseWebSocket.
+func forwardResponseMessageWebSocket(ctx context.Context, mux *ServeMux, marshaler Marshaler, w *webSocketResponseWriter, resp proto.Message, md ServerMetadata, opts []func(context.Context, http.ResponseWriter, proto.Message) error) {
+	w.setHeaderMetadata(mux, md)
+	defer w.setTrailerMetadata(ctx, md)
+
+	if err := handleForwardResponseOptions(ctx, w, resp, opts); err != nil {
+		w.setError(err)
+		return
+	}
+	buf, err := marshalStreamMessage(marshaler, resp)
+	if err != nil {
+		grpclog.Infof("Marshal error: %v", err)
+		w.setError(err)
+		return
+	}
+	w.Header().Set("Content-Type", marshaler.ContentType(resp))
+	if _, err := w.Write(buf); err != nil {
+		grpclog.Infof("Failed to write response: %v", err)
+	}
+}
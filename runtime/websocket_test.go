@@ -0,0 +1,422 @@
+package runtime_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// newWebSocketEchoServer returns a server echoing the messages of POST /echo, like a bidirectional
+// streaming method, until it receives a message with Id "fail".
+func newWebSocketEchoServer(t *testing.T, opts ...runtime.ServeMuxOption) *httptest.Server {
+	t.Helper()
+	mux := runtime.NewServeMux(opts...)
+	pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"echo"}, "")
+	if err != nil {
+		t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+	}
+	mux.Handle("POST", pat, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+		dec := inboundMarshaler.NewDecoder(r.Body)
+		recv := func() (proto.Message, error) {
+			var msg pb.SimpleMessage
+			if err := dec.Decode(&msg); err != nil {
+				if err == io.EOF {
+					return nil, err
+				}
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			if msg.Id == "fail" {
+				return nil, status.Error(codes.Aborted, "failed")
+			}
+			return &msg, nil
+		}
+		ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{})
+		runtime.ForwardResponseStream(ctx, mux, outboundMarshaler, w, r, recv)
+	}, runtime.WithRPCMethodName("/example.EchoService/BidiEcho"))
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+type webSocketClient struct {
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+// dialWebSocket sends a WebSocket handshake to "s" and returns the connection and the handshake response.
+func dialWebSocket(t *testing.T, s *httptest.Server) (*webSocketClient, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", s.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("net.Dial failed with %v; want success", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.SetDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		t.Fatalf("conn.SetDeadline failed with %v; want success", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, s.URL+"/echo", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest failed with %v; want success", err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if err := req.Write(conn); err != nil {
+		t.Fatalf("req.Write failed with %v; want success", err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatalf("http.ReadResponse failed with %v; want success", err)
+	}
+	return &webSocketClient{conn: conn, br: br}, resp
+}
+
+// writeFrame writes a masked frame.
+func (c *webSocketClient) writeFrame(t *testing.T, opcode byte, payload []byte) {
+	t.Helper()
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, 0x80|byte(n))
+	default:
+		frame = append(frame, 0x80|126, byte(n>>8), byte(n))
+	}
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatalf("conn.Write failed with %v; want success", err)
+	}
+}
+
+// readFrame reads an unfragmented frame.
+func (c *webSocketClient) readFrame(t *testing.T) (byte, []byte) {
+	t.Helper()
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.br, hdr[:]); err != nil {
+		t.Fatalf("failed to read a frame header: %v", err)
+	}
+	if hdr[0]&0x80 == 0 || hdr[1]&0x80 != 0 {
+		t.Fatalf("frame header = %x; want a final unmasked frame", hdr)
+	}
+	size := int(hdr[1])
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			t.Fatalf("failed to read a frame size: %v", err)
+		}
+		size = int(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			t.Fatalf("failed to read a frame size: %v", err)
+		}
+		size = int(binary.BigEndian.Uint64(ext[:]))
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		t.Fatalf("failed to read a frame payload: %v", err)
+	}
+	return hdr[0] & 0x0f, payload
+}
+
+func TestWebSocket(t *testing.T) {
+	for _, spec := range []struct {
+		name       string
+		send       []string
+		want       []string
+		wantCode   int
+		wantReason string
+	}{
+		{
+			name:     "echo",
+			send:     []string{`{"id":"One"}`, `{"id":"Two"}`, ""},
+			want:     []string{`{"id":"One"}`, `{"id":"Two"}`},
+			wantCode: 1000,
+		},
+		{
+			name:       "error",
+			send:       []string{`{"id":"One"}`, `{"id":"fail"}`},
+			want:       []string{`{"id":"One"}`},
+			wantCode:   4000 + int(codes.Aborted),
+			wantReason: "failed",
+		},
+		{
+			name:     "invalid message",
+			send:     []string{`{"id":`, ""},
+			wantCode: 4000 + int(codes.InvalidArgument),
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			s := newWebSocketEchoServer(t, runtime.WithWebSocket(runtime.WebSocketOptions{}))
+			c, resp := dialWebSocket(t, s)
+			if got, want := resp.StatusCode, http.StatusSwitchingProtocols; got != want {
+				t.Fatalf("resp.StatusCode = %d; want %d", got, want)
+			}
+			if got, want := resp.Header.Get("Sec-WebSocket-Accept"), "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="; got != want {
+				t.Errorf(`resp.Header.Get("Sec-WebSocket-Accept") = %q; want %q`, got, want)
+			}
+
+			for _, msg := range spec.send {
+				c.writeFrame(t, 0x1, []byte(msg))
+			}
+			for _, want := range spec.want {
+				opcode, payload := c.readFrame(t)
+				if opcode != 0x1 {
+					t.Errorf("opcode = %#x; want a text frame", opcode)
+				}
+				var buf bytes.Buffer
+				if err := json.Compact(&buf, payload); err != nil {
+					t.Fatalf("json.Compact(%q) failed with %v; want success", payload, err)
+				}
+				if got := buf.String(); got != want {
+					t.Errorf("message = %q; want %q", got, want)
+				}
+			}
+
+			opcode, payload := c.readFrame(t)
+			if opcode != 0x8 || len(payload) < 2 {
+				t.Fatalf("frame = %#x %q; want a close frame", opcode, payload)
+			}
+			if got := int(binary.BigEndian.Uint16(payload)); got != spec.wantCode {
+				t.Errorf("close code = %d; want %d", got, spec.wantCode)
+			}
+			if spec.wantReason != "" {
+				if got := string(payload[2:]); got != spec.wantReason {
+					t.Errorf("close reason = %q; want %q", got, spec.wantReason)
+				}
+			}
+			c.writeFrame(t, 0x8, payload[:2])
+		})
+	}
+}
+
+func TestWebSocketMetadata(t *testing.T) {
+	for _, spec := range []struct {
+		name            string
+		readFirst       bool
+		clientStreaming bool
+		wantHeader      string
+		wantMetadata    string
+	}{
+		{
+			name:         "before the handshake",
+			wantHeader:   "bar",
+			wantMetadata: "Grpc-Trailer-Baz: qux\r\n",
+		},
+		{
+			name:         "after the handshake",
+			readFirst:    true,
+			wantMetadata: "Grpc-Metadata-Foo: bar\r\nGrpc-Trailer-Baz: qux\r\n",
+		},
+		{
+			name:            "client streaming",
+			clientStreaming: true,
+			wantMetadata:    "Grpc-Metadata-Foo: bar\r\nGrpc-Trailer-Baz: qux\r\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(runtime.WithWebSocket(runtime.WebSocketOptions{}))
+			pat, err := runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"echo"}, "")
+			if err != nil {
+				t.Fatalf("runtime.NewPattern failed with %v; want success", err)
+			}
+			mux.Handle("POST", pat, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+				if spec.readFirst {
+					var msg pb.SimpleMessage
+					if err := inboundMarshaler.NewDecoder(r.Body).Decode(&msg); err != nil {
+						runtime.HTTPError(r.Context(), mux, outboundMarshaler, w, r, err)
+						return
+					}
+				}
+				ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{
+					HeaderMD:  metadata.Pairs("foo", "bar"),
+					TrailerMD: metadata.Pairs("baz", "qux"),
+				})
+				if spec.clientStreaming {
+					dec := inboundMarshaler.NewDecoder(r.Body)
+					last := new(pb.SimpleMessage)
+					for {
+						msg := new(pb.SimpleMessage)
+						if err := dec.Decode(msg); err == io.EOF {
+							break
+						} else if err != nil {
+							runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+							return
+						}
+						last = msg
+					}
+					runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, r, last)
+					return
+				}
+				var sent bool
+				recv := func() (proto.Message, error) {
+					if sent {
+						return nil, io.EOF
+					}
+					sent = true
+					return &pb.SimpleMessage{Id: "One"}, nil
+				}
+				runtime.ForwardResponseStream(ctx, mux, outboundMarshaler, w, r, recv)
+			}, runtime.WithRPCMethodName("/example.EchoService/BidiEcho"))
+			s := httptest.NewServer(mux)
+			defer s.Close()
+
+			c, resp := dialWebSocket(t, s)
+			if got, want := resp.StatusCode, http.StatusSwitchingProtocols; got != want {
+				t.Fatalf("resp.StatusCode = %d; want %d", got, want)
+			}
+			if got := resp.Header.Get("Grpc-Metadata-Foo"); got != spec.wantHeader {
+				t.Errorf(`resp.Header.Get("Grpc-Metadata-Foo") = %q; want %q`, got, spec.wantHeader)
+			}
+			if spec.readFirst || spec.clientStreaming {
+				c.writeFrame(t, 0x1, []byte(`{"id":"One"}`))
+			}
+			if spec.clientStreaming {
+				c.writeFrame(t, 0x1, nil)
+			}
+
+			if opcode, payload := c.readFrame(t); opcode != 0x1 || !strings.Contains(string(payload), "One") {
+				t.Errorf("frame = %#x %q; want the response message", opcode, payload)
+			}
+			if opcode, payload := c.readFrame(t); opcode != 0x1 || string(payload) != spec.wantMetadata {
+				t.Errorf("frame = %#x %q; want a text frame with %q", opcode, payload, spec.wantMetadata)
+			}
+			opcode, payload := c.readFrame(t)
+			if opcode != 0x8 || len(payload) < 2 {
+				t.Fatalf("frame = %#x %q; want a close frame", opcode, payload)
+			}
+			if got, want := int(binary.BigEndian.Uint16(payload)), 1000; got != want {
+				t.Errorf("close code = %d; want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestWebSocketPing(t *testing.T) {
+	s := newWebSocketEchoServer(t, runtime.WithWebSocket(runtime.WebSocketOptions{}))
+	c, resp := dialWebSocket(t, s)
+	if got, want := resp.StatusCode, http.StatusSwitchingProtocols; got != want {
+		t.Fatalf("resp.StatusCode = %d; want %d", got, want)
+	}
+	c.writeFrame(t, 0x9, []byte("ping"))
+	if opcode, payload := c.readFrame(t); opcode != 0xa || string(payload) != "ping" {
+		t.Errorf("frame = %#x %q; want a pong frame with %q", opcode, payload, "ping")
+	}
+}
+
+func TestWebSocketClientClose(t *testing.T) {
+	s := newWebSocketEchoServer(t, runtime.WithWebSocket(runtime.WebSocketOptions{}))
+	c, _ := dialWebSocket(t, s)
+	c.writeFrame(t, 0x8, []byte{0x03, 0xe9})
+	opcode, payload := c.readFrame(t)
+	if opcode != 0x8 || len(payload) < 2 {
+		t.Fatalf("frame = %#x %q; want a close frame", opcode, payload)
+	}
+	if got, want := int(binary.BigEndian.Uint16(payload)), 1001; got != want {
+		t.Errorf("close code = %d; want %d", got, want)
+	}
+}
+
+func TestWebSocketMessageTooBig(t *testing.T) {
+	s := newWebSocketEchoServer(t, runtime.WithWebSocket(runtime.WebSocketOptions{MaxMessageSize: 8}))
+	c, _ := dialWebSocket(t, s)
+	c.writeFrame(t, 0x1, []byte(`{"id":"too long"}`))
+	opcode, payload := c.readFrame(t)
+	if opcode != 0x8 || len(payload) < 2 {
+		t.Fatalf("frame = %#x %q; want a close frame", opcode, payload)
+	}
+	if got, want := int(binary.BigEndian.Uint16(payload)), 1009; got != want {
+		t.Errorf("close code = %d; want %d", got, want)
+	}
+}
+
+func TestWebSocketHandshakeErrors(t *testing.T) {
+	for _, spec := range []struct {
+		name       string
+		opts       []runtime.ServeMuxOption
+		header     http.Header
+		wantStatus int
+	}{
+		{
+			name:       "disabled",
+			header:     make(http.Header),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "cross origin",
+			opts:       []runtime.ServeMuxOption{runtime.WithWebSocket(runtime.WebSocketOptions{})},
+			header:     http.Header{"Origin": []string{"https://evil.example.com"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "origin rejected by CheckOrigin",
+			opts: []runtime.ServeMuxOption{runtime.WithWebSocket(runtime.WebSocketOptions{
+				CheckOrigin: func(r *http.Request) bool { return false },
+			})},
+			header:     make(http.Header),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "unsupported version",
+			opts:       []runtime.ServeMuxOption{runtime.WithWebSocket(runtime.WebSocketOptions{})},
+			header:     http.Header{"Sec-Websocket-Version": []string{"8"}},
+			wantStatus: http.StatusUpgradeRequired,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			s := newWebSocketEchoServer(t, spec.opts...)
+			version := spec.header.Get("Sec-WebSocket-Version")
+			c, err := net.Dial("tcp", s.Listener.Addr().String())
+			if err != nil {
+				t.Fatalf("net.Dial failed with %v; want success", err)
+			}
+			defer c.Close()
+			if version == "" {
+				version = "13"
+			}
+			var req strings.Builder
+			req.WriteString("GET /echo HTTP/1.1\r\nHost: " + s.Listener.Addr().String() + "\r\n")
+			req.WriteString("Connection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n")
+			req.WriteString("Sec-WebSocket-Version: " + version + "\r\n")
+			if origin := spec.header.Get("Origin"); origin != "" {
+				req.WriteString("Origin: " + origin + "\r\n")
+			}
+			req.WriteString("\r\n")
+			if _, err := io.WriteString(c, req.String()); err != nil {
+				t.Fatalf("failed to write the handshake: %v", err)
+			}
+			resp, err := http.ReadResponse(bufio.NewReader(c), nil)
+			if err != nil {
+				t.Fatalf("http.ReadResponse failed with %v; want success", err)
+			}
+			defer resp.Body.Close()
+			if got := resp.StatusCode; got != spec.wantStatus {
+				t.Errorf("resp.StatusCode = %d; want %d", got, spec.wantStatus)
+			}
+		})
+	}
+}